@@ -0,0 +1,170 @@
+package dataframe
+
+import "fmt"
+
+// AddDF adds other to df element-wise, see frameOp.
+func (df *DataFrame) AddDF(other *DataFrame) (*DataFrame, error) {
+	return df.frameOp(other, opAdd)
+}
+
+// SubDF subtracts other from df element-wise, see frameOp.
+func (df *DataFrame) SubDF(other *DataFrame) (*DataFrame, error) {
+	return df.frameOp(other, opSub)
+}
+
+// MulDF multiplies df by other element-wise, see frameOp.
+func (df *DataFrame) MulDF(other *DataFrame) (*DataFrame, error) {
+	return df.frameOp(other, opMul)
+}
+
+// DivDF divides df by other element-wise, see frameOp. A zero divisor
+// yields NaN, as in Series.Div.
+func (df *DataFrame) DivDF(other *DataFrame) (*DataFrame, error) {
+	return df.frameOp(other, opDiv)
+}
+
+// AddSeries adds s to every numeric column of df, see seriesOp.
+func (df *DataFrame) AddSeries(s *Series, axis int) (*DataFrame, error) {
+	return df.seriesOp(s, axis, opAdd)
+}
+
+// SubSeries subtracts s from every numeric column of df, see seriesOp.
+func (df *DataFrame) SubSeries(s *Series, axis int) (*DataFrame, error) {
+	return df.seriesOp(s, axis, opSub)
+}
+
+// MulSeries multiplies every numeric column of df by s, see seriesOp.
+func (df *DataFrame) MulSeries(s *Series, axis int) (*DataFrame, error) {
+	return df.seriesOp(s, axis, opMul)
+}
+
+// DivSeries divides every numeric column of df by s, see seriesOp. A zero
+// divisor yields NaN, as in Series.Div.
+func (df *DataFrame) DivSeries(s *Series, axis int) (*DataFrame, error) {
+	return df.seriesOp(s, axis, opDiv)
+}
+
+// frameOp applies op to the cells of df and other matched by row label and
+// column name. The result has the union of both indexes and of both column
+// lists, in order of first appearance, df first. Cells whose row or column
+// is missing from either side are nil, and so are the columns that are not
+// numeric in both frames. Labels are matched like GetLoc matches them.
+// Frames with equal indexes are combined row by row; otherwise both indexes
+// must be unique, since a repeated label has no single row to match.
+// Computed columns follow the dtype rules of Series.Add.
+func (df *DataFrame) frameOp(other *DataFrame, op func(float64, float64) float64) (*DataFrame, error) {
+	if other == nil {
+		return nil, fmt.Errorf("other DataFrame is nil")
+	}
+	index := df.index.Copy()
+	var leftPos, rightPos []int
+	if !df.index.Equals(other.index) {
+		if !df.index.IsUnique() || !other.index.IsUnique() {
+			return nil, fmt.Errorf("cannot align DataFrames with repeated index labels")
+		}
+		index = df.index.Union(other.index)
+		leftPos = alignPositions(df.index, index)
+		rightPos = alignPositions(other.index, index)
+	}
+
+	var columns []string
+	seen := make(map[string]bool, len(df.columns)+len(other.columns))
+	for _, cols := range [][]string{df.columns, other.columns} {
+		for _, col := range cols {
+			if !seen[col] {
+				seen[col] = true
+				columns = append(columns, col)
+			}
+		}
+	}
+
+	n := index.Len()
+	data := make(map[string]*Series, len(columns))
+	for _, col := range columns {
+		left, inLeft := df.data[col]
+		right, inRight := other.data[col]
+		if inLeft && inRight && left.dtype.isNumeric() && right.dtype.isNumeric() {
+			data[col] = alignSeries(left, index, leftPos).arithmeticOp(alignSeries(right, index, rightPos), op)
+			continue
+		}
+		dtype := DTypeFloat64
+		if inLeft && !left.dtype.isNumeric() || inRight && !right.dtype.isNumeric() {
+			dtype = DTypeObject
+		}
+		data[col] = &Series{name: col, data: make([]interface{}, n), dtype: dtype, index: index.Copy()}
+	}
+	return &DataFrame{
+		columns: columns,
+		data:    data,
+		index:   index,
+		shape:   [2]int{n, len(columns)},
+	}, nil
+}
+
+// seriesOp applies op between every numeric column of df and s. With axis 0
+// s is matched to the rows of df by index label and combined with each
+// column; with axis 1 the index labels of s name columns of df, and each
+// column is combined with its value of s. Rows, or columns, with no match
+// in s become nil. With axis 0 and an index different from that of df, s
+// must not repeat labels. Non-numeric columns are passed through unchanged,
+// and the shape, column order and index are those of df.
+func (df *DataFrame) seriesOp(s *Series, axis int, op func(float64, float64) float64) (*DataFrame, error) {
+	if s == nil {
+		return nil, fmt.Errorf("series is nil")
+	}
+	switch axis {
+	case 0:
+		aligned := s
+		if !s.index.Equals(df.index) {
+			if !s.index.IsUnique() {
+				return nil, fmt.Errorf("cannot align series with repeated index labels")
+			}
+			aligned = alignSeries(s, df.index, alignPositions(s.index, df.index))
+		}
+		return df.transformColumns(nil, func(col *Series) *Series {
+			return col.arithmeticOp(aligned, op)
+		})
+	case 1:
+		values := make(map[*Series]interface{}, len(df.columns))
+		for _, col := range df.columns {
+			if pos, err := s.index.GetLoc(col); err == nil {
+				values[df.data[col]] = s.data[pos]
+			}
+		}
+		// A column without a value is combined with nil, which gives nil
+		return df.transformColumns(nil, func(col *Series) *Series {
+			return col.arithmeticOp(values[col], op)
+		})
+	default:
+		return nil, fmt.Errorf("axis must be 0 or 1, got %d", axis)
+	}
+}
+
+// alignPositions returns the position in idx of every label of target, or
+// -1 for labels idx does not have.
+func alignPositions(idx, target *Index) []int {
+	positions := make([]int, target.Len())
+	for i, label := range target.values() {
+		pos, err := idx.GetLoc(label)
+		if err != nil {
+			pos = -1
+		}
+		positions[i] = pos
+	}
+	return positions
+}
+
+// alignSeries returns s with the values at positions, nil for -1, labelled
+// by index. A nil positions means s already has the labels of index.
+func alignSeries(s *Series, index *Index, positions []int) *Series {
+	if positions == nil {
+		return s
+	}
+	data := make([]interface{}, len(positions))
+	for i, pos := range positions {
+		if pos >= 0 {
+			data[i] = s.data[pos]
+		}
+	}
+	return &Series{name: s.name, data: data, dtype: s.dtype, index: index.Copy(), na: s.na}
+}
@@ -0,0 +1,91 @@
+package dataframe
+
+import (
+	"fmt"
+	"time"
+)
+
+// Builder constructs a DataFrame column by column from typed slices.
+// Columns keep the order they are added in; lengths and duplicate names are
+// checked by Build, so the Add methods can be chained:
+//
+//	df, err := dataframe.NewBuilder().
+//		AddStrings("name", []string{"Alice", "Bob"}).
+//		AddInts("age", []int{25, 30}).
+//		Build()
+type Builder struct {
+	columns []*Series
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// AddInts adds an int64 column.
+func (b *Builder) AddInts(name string, values []int) *Builder {
+	return b.add(NewSeriesFromInts(values, name))
+}
+
+// AddFloats adds a float64 column.
+func (b *Builder) AddFloats(name string, values []float64) *Builder {
+	return b.add(NewSeriesFromFloat64s(values, name))
+}
+
+// AddStrings adds a string column.
+func (b *Builder) AddStrings(name string, values []string) *Builder {
+	return b.add(NewSeriesFromStrings(values, name))
+}
+
+// AddBools adds a bool column.
+func (b *Builder) AddBools(name string, values []bool) *Builder {
+	return b.add(NewSeriesFromBools(values, name))
+}
+
+// AddTimes adds a datetime column. Zero times are NA unless zero-time NA
+// handling is turned off.
+func (b *Builder) AddTimes(name string, values []time.Time) *Builder {
+	data := make([]interface{}, len(values))
+	for i, v := range values {
+		data[i] = v
+	}
+	return b.add(&Series{
+		name:  name,
+		data:  data,
+		dtype: DTypeDateTime,
+		index: NewRangeIndex(len(values)),
+	})
+}
+
+func (b *Builder) add(s *Series) *Builder {
+	b.columns = append(b.columns, s)
+	return b
+}
+
+// Build returns a DataFrame holding the added columns in order. It fails
+// when a column name is repeated or the columns differ in length. The
+// Builder can be reused; every DataFrame it builds has its own copy of the
+// data.
+func (b *Builder) Build() (*DataFrame, error) {
+	columns := make([]string, len(b.columns))
+	data := make(map[string]*Series, len(b.columns))
+	rowCount := 0
+	for i, s := range b.columns {
+		if _, ok := data[s.name]; ok {
+			return nil, fmt.Errorf("duplicate column '%s'", s.name)
+		}
+		if i == 0 {
+			rowCount = len(s.data)
+		} else if len(s.data) != rowCount {
+			return nil, lengthMismatch(rowCount, len(s.data), "column '%s' length %d does not match %d", s.name, len(s.data), rowCount)
+		}
+		columns[i] = s.name
+		data[s.name] = s.Copy()
+	}
+	return &DataFrame{
+		columns: columns,
+		data:    data,
+		index:   NewRangeIndex(rowCount),
+		shape:   [2]int{rowCount, len(columns)},
+	}, nil
+}
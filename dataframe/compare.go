@@ -0,0 +1,143 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// CompareOptions defines options for Compare.
+type CompareOptions struct {
+	Tolerance     float64 // maximum absolute difference for numbers to be equal
+	NaNEqual      bool    // treat NaN (and nil against NaN) as equal to each other
+	AllowMismatch bool    // report missing rows and columns as differing cells instead of an error
+}
+
+// Compare returns the cells that differ between a and b as a long-format
+// DataFrame with the columns "index", "column", "left" and "right". Rows are
+// matched by position and columns by name; the index column holds the row
+// label of a (or of b for rows only b has). Two nil values are always equal,
+// numbers are compared within Tolerance, and other values by type and value.
+//
+// Frames of different shape or columns are an error unless AllowMismatch is
+// set, in which case every cell present in only one frame is reported with
+// nil on the other side.
+func Compare(a, b *DataFrame, opts CompareOptions) (*DataFrame, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("both DataFrames must be non-nil")
+	}
+	var onlyLeft, onlyRight []string
+	for _, col := range a.columns {
+		if _, ok := b.data[col]; !ok {
+			onlyLeft = append(onlyLeft, col)
+		}
+	}
+	for _, col := range b.columns {
+		if _, ok := a.data[col]; !ok {
+			onlyRight = append(onlyRight, col)
+		}
+	}
+	if !opts.AllowMismatch {
+		if len(onlyLeft) > 0 || len(onlyRight) > 0 {
+			return nil, fmt.Errorf("columns differ: only in left %v, only in right %v", onlyLeft, onlyRight)
+		}
+		if a.shape[0] != b.shape[0] {
+			return nil, fmt.Errorf("row count differs: %d vs %d", a.shape[0], b.shape[0])
+		}
+	}
+
+	columns := append(append([]string{}, a.columns...), onlyRight...)
+	rows := a.shape[0]
+	if b.shape[0] > rows {
+		rows = b.shape[0]
+	}
+
+	var labels, names, lefts, rights []interface{}
+	for i := 0; i < rows; i++ {
+		label := labelAt(a, i)
+		if i >= a.shape[0] {
+			label = labelAt(b, i)
+		}
+		for _, col := range columns {
+			lv, lok := cellAt(a, col, i)
+			rv, rok := cellAt(b, col, i)
+			if lok && rok && valuesEqual(lv, rv, opts) {
+				continue
+			}
+			labels = append(labels, label)
+			names = append(names, col)
+			lefts = append(lefts, lv)
+			rights = append(rights, rv)
+		}
+	}
+
+	resultCols := []string{"index", "column", "left", "right"}
+	seriesMap := map[string]*Series{
+		"index":  NewSeries(labels, "index"),
+		"column": NewSeries(names, "column"),
+		"left":   NewSeries(lefts, "left"),
+		"right":  NewSeries(rights, "right"),
+	}
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   NewRangeIndex(len(labels)),
+		shape:   [2]int{len(labels), len(resultCols)},
+	}, nil
+}
+
+// labelAt returns the index label of row pos.
+func labelAt(df *DataFrame, pos int) interface{} {
+	label, _ := df.index.Get(pos)
+	return label
+}
+
+// cellAt returns the value at column col and row pos, and false when the
+// cell does not exist.
+func cellAt(df *DataFrame, col string, pos int) (interface{}, bool) {
+	s, ok := df.data[col]
+	if !ok || pos >= s.Len() {
+		return nil, false
+	}
+	return s.data[pos], true
+}
+
+// valuesEqual reports whether two cell values are equal under opts.
+func valuesEqual(a, b interface{}, opts CompareOptions) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	aNaN, bNaN := isNaNValue(a), isNaNValue(b)
+	if aNaN || bNaN {
+		return opts.NaNEqual && (aNaN || a == nil) && (bNaN || b == nil)
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if ia, ok := joinInt(a); ok {
+		if ib, ok := joinInt(b); ok && ia == ib {
+			return true
+		}
+	}
+	if isNumber(a) && isNumber(b) {
+		fa, _ := toFloat64(a)
+		fb, _ := toFloat64(b)
+		return math.Abs(fa-fb) <= opts.Tolerance
+	}
+	ka, kb := hashKey(a), hashKey(b)
+	if _, ok := ka.(fallbackKey); ok {
+		return reflect.DeepEqual(a, b)
+	}
+	return ka == kb
+}
+
+// isNaNValue reports whether v is a float NaN.
+func isNaNValue(v interface{}) bool {
+	switch f := v.(type) {
+	case float64:
+		return math.IsNaN(f)
+	case float32:
+		return f != f
+	}
+	return false
+}
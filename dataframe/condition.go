@@ -0,0 +1,123 @@
+package dataframe
+
+import "fmt"
+
+// Expr is an operand of a Condition: a column or a literal value.
+type Expr struct {
+	node exprNode
+}
+
+// Col returns an Expr referring to the named column. Unknown columns are
+// reported when the condition is evaluated.
+func Col(name string) Expr {
+	return Expr{node: &columnNode{name: name}}
+}
+
+// Lit returns an Expr holding a literal value.
+func Lit(value interface{}) Expr {
+	return Expr{node: &literalNode{value: value}}
+}
+
+// Condition is a boolean expression built from Col and evaluated column-wise
+// by FilterExpr, for example
+//
+//	Col("age").Gt(30).And(Col("city").Eq("Berlin"))
+//
+// Comparisons follow the Query rules: numbers compare numerically, other
+// values by their string form, and comparisons involving NA are false
+// except Ne. Column values are NA as their Series settings say, while a
+// literal is NA only when it is nil or NaN, so Eq("NA") matches the string
+// "NA" in a column that does not treat it as NA.
+type Condition struct {
+	node exprNode
+}
+
+// operand returns the node for v, which is an Expr or a literal value.
+func operand(v interface{}) exprNode {
+	if e, ok := v.(Expr); ok {
+		return e.node
+	}
+	return &literalNode{value: v}
+}
+
+func (e Expr) compare(op string, v interface{}) Condition {
+	return Condition{node: &binaryNode{op: op, left: e.node, right: operand(v)}}
+}
+
+// Gt matches rows where the expression is greater than v, which may be a
+// literal or another Expr such as Col("other").
+func (e Expr) Gt(v interface{}) Condition { return e.compare(">", v) }
+
+// Ge matches rows where the expression is greater than or equal to v.
+func (e Expr) Ge(v interface{}) Condition { return e.compare(">=", v) }
+
+// Lt matches rows where the expression is less than v.
+func (e Expr) Lt(v interface{}) Condition { return e.compare("<", v) }
+
+// Le matches rows where the expression is less than or equal to v.
+func (e Expr) Le(v interface{}) Condition { return e.compare("<=", v) }
+
+// Eq matches rows where the expression equals v.
+func (e Expr) Eq(v interface{}) Condition { return e.compare("==", v) }
+
+// Ne matches rows where the expression does not equal v.
+func (e Expr) Ne(v interface{}) Condition { return e.compare("!=", v) }
+
+// IsIn matches rows where the expression equals one of values. NA values
+// never match.
+func (e Expr) IsIn(values ...interface{}) Condition {
+	return Condition{node: &inNode{operand: e.node, list: values}}
+}
+
+// IsNull matches rows where the expression is NA.
+func (e Expr) IsNull() Condition {
+	return e.compare("==", nil)
+}
+
+// And matches rows where both conditions match.
+func (c Condition) And(other Condition) Condition {
+	return Condition{node: &binaryNode{op: "&&", left: c.node, right: other.node}}
+}
+
+// Or matches rows where either condition matches.
+func (c Condition) Or(other Condition) Condition {
+	return Condition{node: &binaryNode{op: "||", left: c.node, right: other.node}}
+}
+
+// Not matches rows where the condition does not match.
+func (c Condition) Not() Condition {
+	return Condition{node: &unaryNode{op: "!", operand: c.node}}
+}
+
+// FilterExpr returns the rows matching cond. The condition is evaluated one
+// column at a time into a bool mask, and the matching rows are then taken in
+// a single pass, keeping their index labels.
+func (df *DataFrame) FilterExpr(cond Condition) (*DataFrame, error) {
+	if cond.node == nil || hasNilNode(cond.node) {
+		return nil, fmt.Errorf("condition is empty")
+	}
+	if err := cond.node.check(df); err != nil {
+		return nil, err
+	}
+	result := cond.node.eval(df)
+	mask := make([]interface{}, df.shape[0])
+	for i := range mask {
+		mask[i] = result.truthyAt(i)
+	}
+	return df.selectByMask(mask)
+}
+
+// hasNilNode reports whether a combined condition contains a zero Condition.
+func hasNilNode(node exprNode) bool {
+	switch n := node.(type) {
+	case nil:
+		return true
+	case *binaryNode:
+		return hasNilNode(n.left) || hasNilNode(n.right)
+	case *unaryNode:
+		return hasNilNode(n.operand)
+	case *inNode:
+		return hasNilNode(n.operand)
+	}
+	return false
+}
@@ -1,8 +1,10 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"iter"
+	"time"
 )
 
 // DataFrame represents a 2-dimensional labeled data structure.
@@ -14,13 +16,110 @@ type DataFrame struct {
 }
 
 // Row represents a single row of a DataFrame.
+// A Row references the columns of its DataFrame instead of copying the
+// values, so it is cheap to create and reflects later changes to the data.
 type Row struct {
-	data map[string]interface{}
+	df  *DataFrame
+	pos int
 }
 
 // Get returns the value for the given column name.
 func (r Row) Get(column string) interface{} {
-	return r.data[column]
+	if r.df == nil {
+		return nil
+	}
+	s, ok := r.df.data[column]
+	if !ok {
+		return nil
+	}
+	return s.data[r.pos]
+}
+
+var (
+	// ErrColumnNotFound is matched by errors.Is for a ColumnNotFoundError,
+	// returned for example by the typed Row getters when the row has no
+	// such column.
+	ErrColumnNotFound = errors.New("column not found")
+	// ErrNAValue is returned by the typed Row getters when the value is NA.
+	ErrNAValue = errors.New("value is NA")
+)
+
+// Has reports whether the row has the given column.
+func (r Row) Has(column string) bool {
+	if r.df == nil {
+		return false
+	}
+	_, ok := r.df.data[column]
+	return ok
+}
+
+// Columns returns a copy of the column names of the row.
+func (r Row) Columns() []string {
+	if r.df == nil {
+		return nil
+	}
+	return r.df.Columns()
+}
+
+// ToMap returns the values of the row keyed by column name.
+func (r Row) ToMap() map[string]interface{} {
+	if r.df == nil {
+		return map[string]interface{}{}
+	}
+	values := make(map[string]interface{}, len(r.df.columns))
+	for _, col := range r.df.columns {
+		values[col] = r.df.data[col].data[r.pos]
+	}
+	return values
+}
+
+// GetInt returns the value of column as an int64 using the ConvertToType
+// rules. A missing column returns ErrColumnNotFound, an NA value ErrNAValue
+// and a failed conversion ErrTypeConversion; all can be checked with
+// errors.Is.
+func (r Row) GetInt(column string) (int64, error) {
+	return rowTyped(r, column, toInt64)
+}
+
+// GetFloat returns the value of column as a float64, see GetInt.
+func (r Row) GetFloat(column string) (float64, error) {
+	return rowTyped(r, column, toFloat64)
+}
+
+// GetString returns the value of column as a string, see GetInt.
+func (r Row) GetString(column string) (string, error) {
+	return rowTyped(r, column, toString)
+}
+
+// GetBool returns the value of column as a bool, see GetInt.
+func (r Row) GetBool(column string) (bool, error) {
+	return rowTyped(r, column, toBool)
+}
+
+// GetTime returns the value of column as a time.Time, see GetInt.
+func (r Row) GetTime(column string) (time.Time, error) {
+	return rowTyped(r, column, toDateTime)
+}
+
+// rowTyped returns the value of column converted to T.
+func rowTyped[T any](r Row, column string, convert func(interface{}) (T, error)) (T, error) {
+	var zero T
+	if !r.Has(column) {
+		return zero, &ColumnNotFoundError{Column: column, msg: fmt.Sprintf("%v: '%s'", ErrColumnNotFound, column)}
+	}
+	s := r.df.data[column]
+	v := s.data[r.pos]
+	if typed, ok := v.(T); ok {
+		return typed, nil
+	}
+	if s.isNA(v) {
+		return zero, fmt.Errorf("column '%s': %w", column, ErrNAValue)
+	}
+	converted, err := convert(v)
+	if err != nil {
+		return zero, fmt.Errorf("column '%s': %w", column, &TypeConversionError{Value: v, Target: InferDType(zero), Err: err})
+	}
+	return converted, nil
 }
 
 // New creates a DataFrame from a map of column name to values.
@@ -36,7 +135,7 @@ func New(data map[string][]interface{}) (*DataFrame, error) {
 		if rowCount == 0 {
 			rowCount = len(values)
 		} else if len(values) != rowCount {
-			return nil, fmt.Errorf("column '%s' length %d does not match %d", col, len(values), rowCount)
+			return nil, lengthMismatch(rowCount, len(values), "column '%s' length %d does not match %d", col, len(values), rowCount)
 		}
 	}
 
@@ -54,6 +153,7 @@ func New(data map[string][]interface{}) (*DataFrame, error) {
 }
 
 // FromRecords creates a DataFrame from records and columns.
+// The columns keep the given order.
 func FromRecords(records [][]interface{}, columns []string) (*DataFrame, error) {
 	if len(records) == 0 {
 		return &DataFrame{columns: columns, data: map[string]*Series{}, index: NewRangeIndex(0), shape: [2]int{0, len(columns)}}, nil
@@ -64,24 +164,61 @@ func FromRecords(records [][]interface{}, columns []string) (*DataFrame, error)
 
 	colData := make(map[string][]interface{})
 	for _, col := range columns {
+		if _, ok := colData[col]; ok {
+			return nil, fmt.Errorf("duplicate column '%s'", col)
+		}
 		colData[col] = make([]interface{}, 0, len(records))
 	}
 
 	for i, row := range records {
 		if len(row) != len(columns) {
-			return nil, fmt.Errorf("row %d length %d does not match columns length %d", i, len(row), len(columns))
+			return nil, lengthMismatch(len(columns), len(row), "row %d length %d does not match columns length %d", i, len(row), len(columns))
 		}
 		for j, col := range columns {
 			colData[col] = append(colData[col], row[j])
 		}
 	}
 
-	return New(colData)
+	df, err := New(colData)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the caller's column order instead of map iteration order
+	df.columns = append([]string(nil), columns...)
+	return df, nil
+}
+
+// ToRecords returns the rows of the DataFrame as records in column order.
+// It is the inverse of FromRecords: FromRecords(df.ToRecords(), df.Columns())
+// rebuilds the same data.
+func (df *DataFrame) ToRecords() [][]interface{} {
+	records := make([][]interface{}, df.shape[0])
+	for i := range records {
+		record := make([]interface{}, len(df.columns))
+		for j, col := range df.columns {
+			record[j] = df.data[col].data[i]
+		}
+		records[i] = record
+	}
+	return records
+}
+
+// ToMaps returns the rows of the DataFrame as maps of column name to value.
+func (df *DataFrame) ToMaps() []map[string]interface{} {
+	rows := make([]map[string]interface{}, df.shape[0])
+	for i := range rows {
+		row := make(map[string]interface{}, len(df.columns))
+		for _, col := range df.columns {
+			row[col] = df.data[col].data[i]
+		}
+		rows[i] = row
+	}
+	return rows
 }
 
-// Columns returns the column names.
+// Columns returns a copy of the column names.
 func (df *DataFrame) Columns() []string {
-	return df.columns
+	return append([]string(nil), df.columns...)
 }
 
 // GetSeries returns the Series for a column name.
@@ -95,8 +232,11 @@ func (df *DataFrame) GetSeries(column string) (*Series, bool) {
 
 // SetColumn sets or replaces a column with the provided Series.
 func (df *DataFrame) SetColumn(name string, series *Series) error {
+	if series == nil {
+		return fmt.Errorf("series is nil")
+	}
 	if series.Len() != df.shape[0] {
-		return fmt.Errorf("series length %d does not match dataframe rows %d", series.Len(), df.shape[0])
+		return lengthMismatch(df.shape[0], series.Len(), "series length %d does not match dataframe rows %d", series.Len(), df.shape[0])
 	}
 	if _, ok := df.data[name]; !ok {
 		df.columns = append(df.columns, name)
@@ -112,12 +252,19 @@ func (df *DataFrame) Index() *Index {
 	return df.index
 }
 
+// IndexIsUnique reports whether no row label occurs more than once.
+func (df *DataFrame) IndexIsUnique() bool {
+	return df.index.IsUnique()
+}
+
 // Shape returns the (rows, cols).
 func (df *DataFrame) Shape() [2]int {
 	return df.shape
 }
 
-// Copy returns a shallow copy of the DataFrame.
+// Copy returns a copy of the DataFrame.
+// Column data is copied, so mutating the copy never affects df; the values
+// themselves are not deep-copied.
 func (df *DataFrame) Copy() *DataFrame {
 	seriesMap := make(map[string]*Series)
 	for _, col := range df.columns {
@@ -128,7 +275,7 @@ func (df *DataFrame) Copy() *DataFrame {
 	return &DataFrame{columns: cols, data: seriesMap, index: df.index.Copy(), shape: df.shape}
 }
 
-// Head returns the first n rows.
+// Head returns a copy of the first n rows.
 func (df *DataFrame) Head(n int) *DataFrame {
 	if n > df.shape[0] {
 		n = df.shape[0]
@@ -136,7 +283,7 @@ func (df *DataFrame) Head(n int) *DataFrame {
 	return df.ILoc(0, n, 0, df.shape[1])
 }
 
-// Tail returns the last n rows.
+// Tail returns a copy of the last n rows.
 func (df *DataFrame) Tail(n int) *DataFrame {
 	if n > df.shape[0] {
 		n = df.shape[0]
@@ -146,6 +293,8 @@ func (df *DataFrame) Tail(n int) *DataFrame {
 }
 
 // Select returns a DataFrame with the specified columns.
+// Columns that do not exist are skipped; use ReorderColumns or GetSeries
+// when a missing column should be an error.
 func (df *DataFrame) Select(columns ...string) *DataFrame {
 	seriesMap := make(map[string]*Series)
 	cols := make([]string, 0, len(columns))
@@ -166,71 +315,120 @@ func (df *DataFrame) At(rowLabel interface{}, column string) (interface{}, error
 	}
 	series, ok := df.data[column]
 	if !ok {
-		return nil, fmt.Errorf("column '%s' not found", column)
+		return nil, &ColumnNotFoundError{Column: column}
 	}
 	return series.Get(rowPos)
 }
 
-// Row returns a Row by position.
-func (df *DataFrame) Row(pos int) (Row, error) {
-	if pos < 0 || pos >= df.shape[0] {
-		return Row{}, fmt.Errorf("row %d out of range", pos)
+// SetOptions defines options for SetAt and SetRow.
+type SetOptions struct {
+	Strict bool // return an error instead of changing the column dtype
+}
+
+// SetAt sets the cell at row index label and column name in place. If the
+// value does not fit the column dtype, the dtype is promoted (an int64
+// column receiving a string becomes object); with Strict this is an error.
+func (df *DataFrame) SetAt(rowLabel interface{}, column string, value interface{}, opts ...SetOptions) error {
+	return df.SetRow(rowLabel, map[string]interface{}{column: value}, opts...)
+}
+
+// SetRow sets several cells of the row with the given index label in place.
+// All columns are validated before any value is written, and dtypes are
+// updated as in SetAt.
+func (df *DataFrame) SetRow(rowLabel interface{}, values map[string]interface{}, opts ...SetOptions) error {
+	var opt SetOptions
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
-	row := make(map[string]interface{})
-	for _, col := range df.columns {
-		v, _ := df.data[col].Get(pos)
-		row[col] = v
+	pos, err := df.index.GetLoc(rowLabel)
+	if err != nil {
+		return err
+	}
+	dtypes := make(map[string]DType, len(values))
+	for col, v := range values {
+		s, ok := df.data[col]
+		if !ok {
+			return &ColumnNotFoundError{Column: col}
+		}
+		dtype := dtypeAfterSet(s, v)
+		if opt.Strict && dtype != s.dtype {
+			return fmt.Errorf("cannot set %T in column '%s' of dtype %s", v, col, s.dtype)
+		}
+		dtypes[col] = dtype
+	}
+	for col, v := range values {
+		s := df.data[col]
+		s.data[pos] = v
+		s.dtype = dtypes[col]
 	}
-	return Row{data: row}, nil
+	return nil
 }
 
-// String returns a string representation of the DataFrame.
-func (df *DataFrame) String() string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("DataFrame: rows=%d, cols=%d\n", df.shape[0], df.shape[1]))
-	if df.shape[1] == 0 {
-		return sb.String()
+// dtypeAfterSet returns the dtype s has after a value of v is written to it.
+func dtypeAfterSet(s *Series, v interface{}) DType {
+	if v == nil {
+		return s.dtype
 	}
-
-	// Header
-	sb.WriteString("index\t")
-	for _, col := range df.columns {
-		sb.WriteString(col + "\t")
+	if s.dtype == DTypeObject || s.dtype == DTypeUnknown {
+		// An object column may only hold nil so far
+		return promoteDType(InferDTypeFromSlice(s.data), InferDType(v))
 	}
-	sb.WriteString("\n")
+	return promoteDType(s.dtype, InferDType(v))
+}
 
-	maxShow := 5
-	rows := df.shape[0]
-	if rows <= maxShow*2 {
-		for i := 0; i < rows; i++ {
-			label, _ := df.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v\t", label))
-			for _, col := range df.columns {
-				v, _ := df.data[col].Get(i)
-				sb.WriteString(fmt.Sprintf("%v\t", v))
-			}
-			sb.WriteString("\n")
-		}
-	} else {
-		for i := 0; i < maxShow; i++ {
-			label, _ := df.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v\t", label))
-			for _, col := range df.columns {
-				v, _ := df.data[col].Get(i)
-				sb.WriteString(fmt.Sprintf("%v\t", v))
-			}
-			sb.WriteString("\n")
+// AppendRow returns a new DataFrame with one more row holding values. Columns
+// not in values are filled with nil, and dtypes are promoted as in SetAt.
+// A nil label uses the new row's position.
+func (df *DataFrame) AppendRow(values map[string]interface{}, label interface{}) (*DataFrame, error) {
+	for col := range values {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
 		}
-		sb.WriteString("...\n")
-		for i := rows - maxShow; i < rows; i++ {
+	}
+	if label == nil {
+		label = df.shape[0]
+	}
+	newDF := df.Copy()
+	for _, col := range newDF.columns {
+		s := newDF.data[col]
+		v := values[col]
+		s.dtype = dtypeAfterSet(s, v)
+		s.data = append(s.data, v)
+		s.index = s.index.Append(label)
+	}
+	newDF.index = newDF.index.Append(label)
+	newDF.shape[0]++
+	return newDF, nil
+}
+
+// Row returns a Row by position.
+func (df *DataFrame) Row(pos int) (Row, error) {
+	if pos < 0 || pos >= df.shape[0] {
+		return Row{}, &IndexOutOfRangeError{Pos: pos, Len: df.shape[0], msg: fmt.Sprintf("row %d out of range", pos)}
+	}
+	return Row{df: df, pos: pos}, nil
+}
+
+// RowAsof returns the row at the last index label that is less than or equal
+// to label, see Index.Asof.
+func (df *DataFrame) RowAsof(label interface{}, opts ...SearchSortedOptions) (Row, error) {
+	pos, err := df.index.Asof(label, opts...)
+	if err != nil {
+		return Row{}, err
+	}
+	return df.Row(pos)
+}
+
+// IterRows returns an iterator over (label, Row) pairs.
+// Each Row is built only when it is reached.
+func (df *DataFrame) IterRows() iter.Seq2[interface{}, Row] {
+	return func(yield func(interface{}, Row) bool) {
+		for i := 0; i < df.shape[0]; i++ {
 			label, _ := df.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v\t", label))
-			for _, col := range df.columns {
-				v, _ := df.data[col].Get(i)
-				sb.WriteString(fmt.Sprintf("%v\t", v))
+			row, _ := df.Row(i)
+			if !yield(label, row) {
+				return
 			}
-			sb.WriteString("\n")
 		}
 	}
-	return sb.String()
 }
@@ -0,0 +1,235 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ellipsis marks truncated cells and elided rows and columns.
+const ellipsis = "…"
+
+// DisplayOptions controls how DataFrame.String and Series.String render data.
+type DisplayOptions struct {
+	MaxRows     int    // rows shown before the middle rows are elided; 0 shows all
+	MaxCols     int    // columns shown before the middle columns are elided; 0 shows all
+	MaxColWidth int    // cells wider than this are truncated with an ellipsis; 0 disables
+	FloatFormat string // format verb for floats such as "%.2f"; empty uses %v
+}
+
+// DefaultDisplayOptions returns the display options used until
+// SetDisplayOptions is called.
+func DefaultDisplayOptions() DisplayOptions {
+	return DisplayOptions{MaxRows: 10, MaxCols: 20, MaxColWidth: 50}
+}
+
+var (
+	displayMu      sync.RWMutex
+	displayOptions = DefaultDisplayOptions()
+)
+
+// SetDisplayOptions sets the package-level options used by String.
+func SetDisplayOptions(opts DisplayOptions) {
+	displayMu.Lock()
+	defer displayMu.Unlock()
+	displayOptions = opts
+}
+
+// GetDisplayOptions returns the package-level options used by String.
+func GetDisplayOptions() DisplayOptions {
+	displayMu.RLock()
+	defer displayMu.RUnlock()
+	return displayOptions
+}
+
+// String returns the DataFrame rendered as an aligned table using the
+// package-level display options.
+func (df *DataFrame) String() string {
+	return df.StringWith(GetDisplayOptions())
+}
+
+// StringWith returns the DataFrame rendered as an aligned table. Numeric
+// columns are right-aligned, and rows and columns beyond MaxRows and MaxCols
+// are elided in the middle.
+func (df *DataFrame) StringWith(opts DisplayOptions) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("DataFrame: rows=%d, cols=%d\n", df.shape[0], df.shape[1]))
+	if df.shape[1] == 0 {
+		return sb.String()
+	}
+
+	rows := displayPositions(df.shape[0], opts.MaxRows)
+	table := []displayColumn{indexColumn(df.index, rows, opts)}
+	for _, c := range displayPositions(len(df.columns), opts.MaxCols) {
+		if c < 0 {
+			table = append(table, elidedColumn(len(rows)))
+			continue
+		}
+		table = append(table, valueColumn(df.data[df.columns[c]], df.columns[c], rows, opts))
+	}
+	writeTable(&sb, table, true, opts)
+	return sb.String()
+}
+
+// String returns the Series rendered with the package-level display options.
+func (s *Series) String() string {
+	return s.StringWith(GetDisplayOptions())
+}
+
+// StringWith returns the Series rendered as aligned label and value columns.
+// MaxCols does not apply to a Series.
+func (s *Series) StringWith(opts DisplayOptions) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Series: %s (dtype: %s, length: %d)\n", s.name, s.dtype, len(s.data)))
+	rows := displayPositions(len(s.data), opts.MaxRows)
+	table := []displayColumn{indexColumn(s.index, rows, opts), valueColumn(s, "", rows, opts)}
+	writeTable(&sb, table, false, opts)
+	return sb.String()
+}
+
+// displayColumn is a rendered column of a table.
+type displayColumn struct {
+	header     string
+	cells      []string
+	rightAlign bool
+}
+
+// displayPositions returns the positions of n items to display when at most
+// limit are shown, with -1 marking the elided middle.
+func displayPositions(n, limit int) []int {
+	if limit <= 0 || n <= limit {
+		positions := make([]int, n)
+		for i := range positions {
+			positions[i] = i
+		}
+		return positions
+	}
+	head := (limit + 1) / 2
+	positions := make([]int, 0, limit+1)
+	for i := 0; i < head; i++ {
+		positions = append(positions, i)
+	}
+	positions = append(positions, -1)
+	for i := n - (limit - head); i < n; i++ {
+		positions = append(positions, i)
+	}
+	return positions
+}
+
+func indexColumn(idx *Index, rows []int, opts DisplayOptions) displayColumn {
+	col := displayColumn{header: idx.Name(), cells: make([]string, len(rows))}
+	for i, pos := range rows {
+		if pos < 0 {
+			col.cells[i] = ellipsis
+			continue
+		}
+		label, _ := idx.Get(pos)
+		col.cells[i] = formatCell(label, opts)
+	}
+	return col
+}
+
+func valueColumn(s *Series, header string, rows []int, opts DisplayOptions) displayColumn {
+	col := displayColumn{
+		header:     header,
+		cells:      make([]string, len(rows)),
+		rightAlign: s.dtype.isNumeric(),
+	}
+	for i, pos := range rows {
+		if pos < 0 {
+			col.cells[i] = ellipsis
+			continue
+		}
+		col.cells[i] = formatCell(s.data[pos], opts)
+	}
+	return col
+}
+
+func elidedColumn(n int) displayColumn {
+	col := displayColumn{header: ellipsis, cells: make([]string, n)}
+	for i := range col.cells {
+		col.cells[i] = ellipsis
+	}
+	return col
+}
+
+// formatCell formats v for display and truncates it to MaxColWidth.
+func formatCell(v interface{}, opts DisplayOptions) string {
+	var str string
+	switch val := v.(type) {
+	case float64:
+		str = formatFloat(val, opts.FloatFormat)
+	case float32:
+		if opts.FloatFormat == "" {
+			str = strconv.FormatFloat(float64(val), 'g', -1, 32)
+		} else {
+			str = formatFloat(float64(val), opts.FloatFormat)
+		}
+	default:
+		str = fmt.Sprintf("%v", v)
+	}
+	return truncateCell(str, opts.MaxColWidth)
+}
+
+func formatFloat(f float64, format string) string {
+	if format == "" {
+		return fmt.Sprintf("%v", f)
+	}
+	return fmt.Sprintf(format, f)
+}
+
+func truncateCell(str string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(str) <= width {
+		return str
+	}
+	if width == 1 {
+		return ellipsis
+	}
+	runes := []rune(str)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// writeTable writes the columns separated by two spaces, padded to the width
+// of their widest cell. Trailing spaces are trimmed from every line.
+func writeTable(sb *strings.Builder, table []displayColumn, header bool, opts DisplayOptions) {
+	widths := make([]int, len(table))
+	for j := range table {
+		table[j].header = truncateCell(table[j].header, opts.MaxColWidth)
+		if header {
+			widths[j] = utf8.RuneCountInString(table[j].header)
+		}
+		for _, cell := range table[j].cells {
+			widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+		}
+	}
+
+	writeLine := func(cell func(col displayColumn) string) {
+		var line strings.Builder
+		for j, col := range table {
+			if j > 0 {
+				line.WriteString("  ")
+			}
+			text := cell(col)
+			pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(text))
+			if col.rightAlign {
+				line.WriteString(pad + text)
+			} else {
+				line.WriteString(text + pad)
+			}
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+
+	if header {
+		writeLine(func(col displayColumn) string { return col.header })
+	}
+	if len(table) == 0 {
+		return
+	}
+	for i := range table[0].cells {
+		writeLine(func(col displayColumn) string { return col.cells[i] })
+	}
+}
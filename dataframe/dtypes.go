@@ -3,8 +3,14 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -48,6 +54,12 @@ func (d DType) String() string {
 	}
 }
 
+// isNumeric reports whether d is one of the integer or floating-point
+// dtypes.
+func (d DType) isNumeric() bool {
+	return d == DTypeInt64 || d == DTypeFloat64
+}
+
 // InferDType infers the DType from a Go value
 func InferDType(v interface{}) DType {
 	if v == nil {
@@ -70,65 +82,355 @@ func InferDType(v interface{}) DType {
 	}
 }
 
-// InferDTypeFromSlice infers the DType from a slice of values
+// InferDTypeFromSlice infers the DType from a slice of values.
+// Every non-nil value is examined and the column types are promoted:
+// integers mixed with floats give DTypeFloat64, any other mix of types
+// (for example numbers and strings) gives DTypeObject, and a slice with no
+// non-nil values is DTypeObject.
 func InferDTypeFromSlice(values []interface{}) DType {
-	if len(values) == 0 {
-		return DTypeObject
-	}
-
-	// Find first non-nil value
-	var firstType DType = DTypeObject
+	result := DTypeUnknown
 	for _, v := range values {
-		if v != nil {
-			firstType = InferDType(v)
+		if v == nil {
+			continue
+		}
+		result = promoteDType(result, InferDType(v))
+		if result == DTypeObject {
 			break
 		}
 	}
+	if result == DTypeUnknown {
+		return DTypeObject
+	}
+	return result
+}
 
-	return firstType
+// promoteDType returns the dtype of a slice holding values of both a and b.
+// Unlike PromoteDTypes, bools mixed with numbers give DTypeObject, as the
+// values are not converted.
+func promoteDType(a, b DType) DType {
+	if (a == DTypeBool && b.isNumeric()) || (a.isNumeric() && b == DTypeBool) {
+		return DTypeObject
+	}
+	return PromoteDTypes(a, b)
+}
+
+// PromoteDTypes returns the dtype of a column combining a column of dtype a
+// with one of dtype b, as Concat and Merge do:
+//
+//   - equal dtypes are kept, and DTypeUnknown, the dtype of a column with no
+//     values, gives the other dtype
+//   - DTypeInt64 with DTypeFloat64 gives DTypeFloat64
+//   - a numeric dtype with DTypeBool gives the numeric dtype
+//   - any other pair, such as a string with anything else, gives DTypeObject
+//
+// The result does not depend on the order of a and b.
+func PromoteDTypes(a, b DType) DType {
+	switch {
+	case a == DTypeUnknown:
+		return b
+	case b == DTypeUnknown, a == b:
+		return a
+	case a.isNumeric() && b.isNumeric():
+		return DTypeFloat64
+	case a.isNumeric() && b == DTypeBool:
+		return a
+	case a == DTypeBool && b.isNumeric():
+		return b
+	default:
+		return DTypeObject
+	}
+}
+
+// combinedSeries returns a Series named name holding data, the values of
+// parts put together. Its dtype is the PromoteDTypes promotion of the
+// dtypes of the parts, where parts with only NA values count as having no
+// dtype. When the parts have different numeric or bool dtypes, the values
+// are converted to the promoted dtype, true becoming 1, so that the dtype
+// describes every value.
+func combinedSeries(name string, data []interface{}, parts ...*Series) *Series {
+	dtype := DTypeUnknown
+	mixed := false
+	for _, part := range parts {
+		if part.Count() == 0 {
+			continue
+		}
+		if dtype != DTypeUnknown && part.dtype != dtype {
+			mixed = true
+		}
+		dtype = PromoteDTypes(dtype, part.dtype)
+	}
+	if !mixed {
+		return NewSeries(data, name)
+	}
+	if dtype.isNumeric() {
+		for i, v := range data {
+			if b, ok := v.(bool); ok {
+				v = 0
+				if b {
+					v = 1
+				}
+			}
+			if converted, err := ConvertToType(v, dtype); err == nil {
+				data[i] = converted
+			}
+		}
+	}
+	return &Series{
+		name:  name,
+		data:  data,
+		dtype: dtype,
+		index: NewRangeIndex(len(data)),
+	}
 }
 
-// ConvertToType converts a value to the specified DType
-func ConvertToType(v interface{}, dtype DType) (interface{}, error) {
+// ConvertToType converts a value to the specified DType. opts, if given,
+// control how numbers and datetimes are converted, see CastOptions. Failures
+// are TypeConversionErrors.
+func ConvertToType(v interface{}, dtype DType, opts ...CastOptions) (interface{}, error) {
 	if v == nil {
 		return nil, nil
 	}
+	var opt CastOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	converted, err := opt.convert(v, dtype)
+	if err != nil {
+		return nil, &TypeConversionError{Value: v, Target: dtype, Err: err}
+	}
+	return converted, nil
+}
 
+// convert converts v, which is not nil, to dtype.
+func (opts CastOptions) convert(v interface{}, dtype DType) (interface{}, error) {
 	switch dtype {
 	case DTypeInt64:
-		return toInt64(v)
+		return opts.toInt64(v)
 	case DTypeFloat64:
-		return toFloat64(v)
+		return opts.toFloat64(v)
 	case DTypeString:
 		return toString(v)
 	case DTypeBool:
 		return toBool(v)
 	case DTypeDateTime:
-		return toDateTime(v)
+		return opts.toDateTime(v)
 	default:
 		return v, nil
 	}
 }
 
 func toInt64(v interface{}) (int64, error) {
+	return CastOptions{}.toInt64(v)
+}
+
+// toInt64 converts v to an int64, rounding floats and checking for
+// overflow as opts say.
+func (opts CastOptions) toInt64(v interface{}) (int64, error) {
+	switch val := v.(type) {
+	case int:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case int32:
+		return int64(val), nil
+	case int16:
+		return int64(val), nil
+	case int8:
+		return int64(val), nil
+	case uint:
+		return opts.uintToInt64(uint64(val))
+	case uint64:
+		return opts.uintToInt64(val)
+	case uint32:
+		return int64(val), nil
+	case uint16:
+		return int64(val), nil
+	case uint8:
+		return int64(val), nil
+	case float64:
+		return opts.floatToInt64(val)
+	case float32:
+		return opts.floatToInt64(float64(val))
+	case string:
+		if !opts.Numeric.isZero() {
+			return opts.parseNumericInt64(val)
+		}
+		return opts.parseInt64(val)
+	}
+	return opts.reflectInt64(v)
+}
+
+// parseNumericInt64 parses s as opts.Numeric says. Whole numbers are parsed
+// as integers, so that they keep their precision beyond 2^53.
+func (opts CastOptions) parseNumericInt64(s string) (int64, error) {
+	t, divisor, err := opts.Numeric.normalize(s)
+	if err != nil {
+		return 0, err
+	}
+	if divisor == 1 {
+		if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return i, nil
+		}
+	}
+	f, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %q to int64", s)
+	}
+	return opts.floatToInt64(f / divisor)
+}
+
+// checked reports whether integer conversions must fail rather than lose
+// data in a way opts do not allow.
+func (opts CastOptions) checked() bool {
+	return opts.CheckOverflow || opts.Rounding == RoundingError
+}
+
+func (opts CastOptions) uintToInt64(u uint64) (int64, error) {
+	if opts.CheckOverflow && u > math.MaxInt64 {
+		return 0, fmt.Errorf("value %d overflows int64", u)
+	}
+	return int64(u), nil
+}
+
+// floatToInt64 converts f, truncating it unless opts.Rounding says
+// otherwise.
+func (opts CastOptions) floatToInt64(f float64) (int64, error) {
+	if opts.checked() && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return 0, fmt.Errorf("cannot convert %v to int64", f)
+	}
+	switch opts.Rounding {
+	case RoundingRound:
+		f = math.Round(f)
+	case RoundingError:
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("value %v has a fractional part", f)
+		}
+	}
+	if opts.CheckOverflow && !(f >= math.MinInt64 && f < math.MaxInt64) {
+		return 0, fmt.Errorf("value %v overflows int64", f)
+	}
+	return int64(f), nil
+}
+
+// parseInt64 parses s, ignoring surrounding space. Numbers with a fraction
+// or exponent, such as "3.7" or "1e5", are converted like float64 values.
+func (opts CastOptions) parseInt64(s string) (int64, error) {
+	t := strings.TrimSpace(s)
+	i, err := strconv.ParseInt(t, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, err
+	}
+	f, ferr := strconv.ParseFloat(t, 64)
+	if ferr == nil && (opts.CheckOverflow || (f >= math.MinInt64 && f < math.MaxInt64)) {
+		return opts.floatToInt64(f)
+	}
+	if opts.checked() {
+		if errors.Is(ferr, strconv.ErrRange) {
+			return 0, fmt.Errorf("value %q overflows int64", s)
+		}
+		return 0, fmt.Errorf("cannot convert %q to int64", s)
+	}
+	if !numericPrefix(t) {
+		return 0, fmt.Errorf("cannot convert %q to int64", s)
+	}
+	// Accept what fmt.Sscanf accepts, such as a number followed by text
+	var result int64
+	_, err = fmt.Sscanf(t, "%d", &result)
+	return result, err
+}
+
+// reflectInt64 converts values of types defined on the numeric and string
+// kinds.
+func (opts CastOptions) reflectInt64(v interface{}) (int64, error) {
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return rv.Int(), nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return int64(rv.Uint()), nil
+		return opts.uintToInt64(rv.Uint())
 	case reflect.Float32, reflect.Float64:
-		return int64(rv.Float()), nil
+		return opts.floatToInt64(rv.Float())
 	case reflect.String:
-		var result int64
-		_, err := fmt.Sscanf(rv.String(), "%d", &result)
-		return result, err
+		return opts.parseInt64(rv.String())
 	default:
 		return 0, fmt.Errorf("cannot convert %T to int64", v)
 	}
 }
 
 func toFloat64(v interface{}) (float64, error) {
+	switch val := v.(type) {
+	case float64:
+		return val, nil
+	case int:
+		return float64(val), nil
+	case int64:
+		return float64(val), nil
+	case float32:
+		return float64(val), nil
+	case int32:
+		return float64(val), nil
+	case int16:
+		return float64(val), nil
+	case int8:
+		return float64(val), nil
+	case uint:
+		return float64(val), nil
+	case uint64:
+		return float64(val), nil
+	case uint32:
+		return float64(val), nil
+	case uint16:
+		return float64(val), nil
+	case uint8:
+		return float64(val), nil
+	case string:
+		return parseFloat64(val)
+	}
+	return reflectFloat64(v)
+}
+
+// toFloat64 converts v to a float64, parsing strings as opts.Numeric says.
+func (opts CastOptions) toFloat64(v interface{}) (float64, error) {
+	if str, ok := v.(string); ok && !opts.Numeric.isZero() {
+		return opts.Numeric.parse(str)
+	}
+	return toFloat64(v)
+}
+
+// parseFloat64 parses s, ignoring surrounding space.
+func parseFloat64(s string) (float64, error) {
+	t := strings.TrimSpace(s)
+	f, err := strconv.ParseFloat(t, 64)
+	if err == nil {
+		return f, nil
+	}
+	if !numericPrefix(t) {
+		return 0, fmt.Errorf("cannot convert %q to float64", s)
+	}
+	// Accept what fmt.Sscanf accepts, such as a number followed by text
+	var result float64
+	_, err = fmt.Sscanf(t, "%f", &result)
+	return result, err
+}
+
+// numericPrefix reports whether s starts like a number, so that parsing it
+// with fmt.Sscanf may succeed.
+func numericPrefix(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if s != "" && s[0] == '.' {
+		s = s[1:]
+	}
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// reflectFloat64 converts values of types defined on the numeric and string
+// kinds.
+func reflectFloat64(v interface{}) (float64, error) {
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -138,9 +440,7 @@ func toFloat64(v interface{}) (float64, error) {
 	case reflect.Float32, reflect.Float64:
 		return rv.Float(), nil
 	case reflect.String:
-		var result float64
-		_, err := fmt.Sscanf(rv.String(), "%f", &result)
-		return result, err
+		return parseFloat64(rv.String())
 	default:
 		return 0, fmt.Errorf("cannot convert %T to float64", v)
 	}
@@ -154,35 +454,112 @@ func toBool(v interface{}) (bool, error) {
 	switch val := v.(type) {
 	case bool:
 		return val, nil
-	case int, int8, int16, int32, int64:
-		return reflect.ValueOf(v).Int() != 0, nil
-	case uint, uint8, uint16, uint32, uint64:
-		return reflect.ValueOf(v).Uint() != 0, nil
-	case float32, float64:
-		return reflect.ValueOf(v).Float() != 0, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		f, _ := toFloat64(v)
+		return f != 0, nil
 	case string:
-		return val != "" && val != "0" && val != "false", nil
+		return ParseBool(val)
 	default:
 		return false, fmt.Errorf("cannot convert %T to bool", v)
 	}
 }
 
+// boolTokens maps the lower-case strings read as booleans to their values:
+// the built-in tokens and those added with RegisterBoolToken.
+var (
+	boolTokensMu sync.RWMutex
+	boolTokens   = map[string]bool{
+		"true": true, "false": false,
+		"t": true, "f": false,
+		"yes": true, "no": false,
+		"y": true, "n": false,
+		"on": true, "off": false,
+		"1": true, "0": false,
+	}
+)
+
+// RegisterBoolToken makes ParseBool, and conversions to DTypeBool, read
+// token as value. Tokens are matched ignoring case, so localized exports
+// can be read with, for example, RegisterBoolToken("ja", true). Registering
+// a token again replaces its value. It is safe for concurrent use.
+func RegisterBoolToken(token string, value bool) {
+	boolTokensMu.Lock()
+	defer boolTokensMu.Unlock()
+	boolTokens[strings.ToLower(strings.TrimSpace(token))] = value
+}
+
+// ParseBool parses s, ignoring surrounding space and case, as one of the
+// tokens true/false, t/f, yes/no, y/n, on/off, 1/0 or a token registered
+// with RegisterBoolToken. Any other string is an error.
+func ParseBool(s string) (bool, error) {
+	boolTokensMu.RLock()
+	b, ok := boolTokens[strings.ToLower(strings.TrimSpace(s))]
+	boolTokensMu.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("cannot convert %q to bool", s)
+	}
+	return b, nil
+}
+
+// dateTimeFormats are the layouts strings are parsed with when converted to
+// datetimes: the built-in layouts followed by those added with
+// RegisterDateTimeFormat, in registration order.
+var (
+	dateTimeFormatsMu sync.RWMutex
+	dateTimeFormats   = []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		"2006/01/02",
+		"01/02/2006",
+		"02-01-2006",
+	}
+)
+
+// RegisterDateTimeFormat adds layout, written like the layouts of
+// time.Parse, to the layouts strings are parsed with when converted to
+// DTypeDateTime. Layouts are tried in registration order after the built-in
+// ones, and the first that parses a string wins, so a layout that reads the
+// same strings as an earlier one differently, such as "02/01/2006" after the
+// built-in "01/02/2006", is only used through CastOptions.DateTimeFormats.
+// Registering a layout again has no effect. It is safe for concurrent use.
+func RegisterDateTimeFormat(layout string) {
+	dateTimeFormatsMu.Lock()
+	defer dateTimeFormatsMu.Unlock()
+	for _, f := range dateTimeFormats {
+		if f == layout {
+			return
+		}
+	}
+	dateTimeFormats = append(dateTimeFormats, layout)
+}
+
 func toDateTime(v interface{}) (time.Time, error) {
+	return CastOptions{}.toDateTime(v)
+}
+
+// toDateTime converts v to a time.Time, parsing strings with the preferred
+// layouts of opts first and then with the registered ones. Strings without a
+// time zone are read in opts.Location.
+func (opts CastOptions) toDateTime(v interface{}) (time.Time, error) {
 	switch val := v.(type) {
 	case time.Time:
 		return val, nil
 	case string:
-		// Try common date formats
-		formats := []string{
-			time.RFC3339,
-			"2006-01-02 15:04:05",
-			"2006-01-02",
-			"2006/01/02",
-			"01/02/2006",
-			"02-01-2006",
+		loc := opts.Location
+		if loc == nil {
+			loc = time.UTC
 		}
+		for _, format := range opts.DateTimeFormats {
+			if t, err := time.ParseInLocation(format, val, loc); err == nil {
+				return t, nil
+			}
+		}
+		dateTimeFormatsMu.RLock()
+		formats := dateTimeFormats
+		dateTimeFormatsMu.RUnlock()
 		for _, format := range formats {
-			if t, err := time.Parse(format, val); err == nil {
+			if t, err := time.ParseInLocation(format, val, loc); err == nil {
 				return t, nil
 			}
 		}
@@ -192,18 +569,299 @@ func toDateTime(v interface{}) (time.Time, error) {
 	}
 }
 
-// IsNA checks if a value is considered as NA (Not Available)
+// IsNA checks if a value is considered as NA (Not Available): nil, a NaN
+// float, the zero time.Time unless SetZeroTimeNA turned that off, or a
+// string configured with SetNAStrings and SetEmptyStringNA, which by
+// default are "", "NA", "NaN" and "null".
 func IsNA(v interface{}) bool {
-	if v == nil {
-		return true
-	}
+	return globalNA.Load().isNA(v)
+}
+
+// naKey and nanKey are the hash keys used for nil and NaN values.
+type naKey struct{}
+type nanKey struct{}
+
+// timeKey is the hash key for time.Time values; equal instants share a key.
+type timeKey struct{ unixNano int64 }
+
+// fallbackKey is the hash key for values that are not comparable.
+type fallbackKey struct {
+	typ  string
+	repr string
+}
+
+// hashKey returns a comparable key for v that is type-aware: int64(1) and
+// "1" get different keys, while all integer types share one representation
+// so int(1) and int64(1) match. NaN values share a single key.
+func hashKey(v interface{}) interface{} {
 	switch val := v.(type) {
-	case string:
-		return val == "" || val == "NA" || val == "NaN" || val == "null"
-	case float64:
-		return val != val // NaN check
+	case nil:
+		return naKey{}
+	case int:
+		return int64(val)
+	case int8:
+		return int64(val)
+	case int16:
+		return int64(val)
+	case int32:
+		return int64(val)
+	case int64:
+		return val
+	case uint:
+		return uintKey(uint64(val))
+	case uint8:
+		return int64(val)
+	case uint16:
+		return int64(val)
+	case uint32:
+		return int64(val)
+	case uint64:
+		return uintKey(val)
 	case float32:
-		return val != val // NaN check
+		if val != val {
+			return nanKey{}
+		}
+		return float64(val)
+	case float64:
+		if val != val {
+			return nanKey{}
+		}
+		return val
+	case string, bool:
+		return val
+	case time.Time:
+		return timeKey{val.UnixNano()}
+	}
+	if reflect.TypeOf(v).Comparable() {
+		return v
+	}
+	return fallbackKey{typ: fmt.Sprintf("%T", v), repr: fmt.Sprintf("%v", v)}
+}
+
+// compositeKey returns a string key for a tuple of values that keeps the
+// type-awareness of hashKey.
+func compositeKey(values []interface{}) string {
+	var sb strings.Builder
+	for i, v := range values {
+		if i > 0 {
+			sb.WriteByte(0)
+		}
+		k := hashKey(v)
+		fmt.Fprintf(&sb, "%T:%v", k, k)
+	}
+	return sb.String()
+}
+
+func uintKey(v uint64) interface{} {
+	if v <= math.MaxInt64 {
+		return int64(v)
+	}
+	return v
+}
+
+// compareValues orders two non-nil values: numbers numerically, times
+// chronologically, and everything else by their string representation.
+// It returns -1, 0 or 1.
+func compareValues(a, b interface{}) int {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+	fa, erra := toFloat64(a)
+	fb, errb := toFloat64(b)
+	if erra == nil && errb == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	sa, sb := fmt.Sprintf("%v", a), fmt.Sprintf("%v", b)
+	switch {
+	case sa < sb:
+		return -1
+	case sa > sb:
+		return 1
+	}
+	return 0
+}
+
+// Error policies for CastOptions.Errors
+const (
+	// CastRaise fails the conversion when a value cannot be converted
+	CastRaise = "raise"
+	// CastCoerce replaces values that cannot be converted with nil
+	CastCoerce = "coerce"
+	// CastIgnore keeps a column unchanged when it cannot be converted
+	CastIgnore = "ignore"
+)
+
+// Rounding modes for CastOptions.Rounding
+const (
+	// RoundingTrunc drops the fractional part of floats converted to int64
+	RoundingTrunc = "trunc"
+	// RoundingRound rounds floats converted to int64 to the nearest integer,
+	// halves away from zero
+	RoundingRound = "round"
+	// RoundingError fails the conversion of floats with a fractional part
+	RoundingError = "error"
+)
+
+// NumericParseOptions defines how strings such as "1,234.5", "85%" or
+// "$1,000" are parsed as numbers, see Series.ParseNumeric and
+// CastOptions.Numeric. The zero value parses plain numbers only.
+type NumericParseOptions struct {
+	// Thousands is the thousands separator removed from numbers, such as
+	// ',' or '.'; 0 means none.
+	Thousands rune
+	// Decimal is the decimal separator; 0 means '.'. With another
+	// separator, such as ',', a '.' left after removing Thousands is an
+	// error.
+	Decimal rune
+	// Symbols are removed from the start or end of numbers, such as "$",
+	// "€" or " USD". A sign may come before or after a leading symbol.
+	Symbols []string
+	// Percent makes a trailing "%" divide the number by 100, so "85%" is
+	// 0.85.
+	Percent bool
+	// Errors is CastRaise (default) or CastCoerce for ParseNumeric. It is
+	// ignored in CastOptions.Numeric, where CastOptions.Errors applies.
+	Errors string
+}
+
+func (n NumericParseOptions) isZero() bool {
+	return n.Thousands == 0 && n.Decimal == 0 && len(n.Symbols) == 0 && !n.Percent
+}
+
+// normalize returns s without the symbols and separators n removes, in the
+// form strconv parses, and the number to divide the parsed value by.
+func (n NumericParseOptions) normalize(s string) (string, float64, error) {
+	t := strings.TrimSpace(s)
+	sign := ""
+	if t != "" && (t[0] == '-' || t[0] == '+') {
+		sign, t = t[:1], t[1:]
+	}
+	for _, sym := range n.Symbols {
+		if sym != "" {
+			t = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(t, sym), sym))
+		}
+	}
+	if sign == "" && t != "" && (t[0] == '-' || t[0] == '+') {
+		sign, t = t[:1], t[1:]
+	}
+	divisor := 1.0
+	if n.Percent && strings.HasSuffix(t, "%") {
+		t = strings.TrimSpace(strings.TrimSuffix(t, "%"))
+		divisor = 100
+	}
+	if n.Thousands != 0 {
+		t = strings.ReplaceAll(t, string(n.Thousands), "")
+	}
+	if n.Decimal != 0 && n.Decimal != '.' {
+		if strings.Contains(t, ".") {
+			return "", 0, fmt.Errorf("cannot convert %q to a number: unexpected '.'", s)
+		}
+		t = strings.ReplaceAll(t, string(n.Decimal), ".")
+	}
+	return sign + t, divisor, nil
+}
+
+// parse parses s as a float64 as n says.
+func (n NumericParseOptions) parse(s string) (float64, error) {
+	t, divisor, err := n.normalize(s)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %q to a number", s)
+	}
+	return f / divisor, nil
+}
+
+// CastOptions defines options for AsTypes.
+type CastOptions struct {
+	Errors string // CastRaise (default), CastCoerce or CastIgnore
+
+	// Rounding is how floats, and strings such as "3.5", are converted to
+	// int64: RoundingTrunc (default), RoundingRound or RoundingError. With
+	// RoundingError a string that is not a whole number is an error, rather
+	// than being read up to its first non-digit.
+	Rounding string
+	// CheckOverflow makes conversions to int64 fail for values outside its
+	// range, such as large uint64 values, instead of wrapping them.
+	CheckOverflow bool
+	// Numeric sets how strings are parsed when converted to numeric
+	// dtypes, for example with thousands separators or percent signs.
+	Numeric NumericParseOptions
+
+	// DateTimeFormats are layouts tried, in order, before the registered
+	// ones when strings are converted to DTypeDateTime. They settle
+	// ambiguous dates: with "02/01/2006", "03/04/2024" is the 3rd of April
+	// rather than the 4th of March read by the built-in "01/02/2006".
+	DateTimeFormats []string
+	// Location is the location of datetime strings without a time zone;
+	// nil means UTC.
+	Location *time.Location
+}
+
+// validate checks the error policy, rounding mode and separators of opts.
+func (opts CastOptions) validate() error {
+	switch opts.Errors {
+	case "", CastRaise, CastCoerce, CastIgnore:
+	default:
+		return fmt.Errorf("unknown cast error policy '%s'", opts.Errors)
+	}
+	switch opts.Rounding {
+	case "", RoundingTrunc, RoundingRound, RoundingError:
+	default:
+		return fmt.Errorf("unknown rounding mode '%s'", opts.Rounding)
+	}
+	if n := opts.Numeric; n.Thousands != 0 && (n.Thousands == n.Decimal || (n.Decimal == 0 && n.Thousands == '.')) {
+		return fmt.Errorf("thousands and decimal separators are both '%c'", n.Thousands)
+	}
+	return nil
+}
+
+// AsTypes returns a new DataFrame with the given columns converted to their
+// dtypes. Unknown columns are an error. With CastRaise the error lists every
+// column that failed to convert, not only the first one.
+func (df *DataFrame) AsTypes(dtypes map[string]DType, opts CastOptions) (*DataFrame, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	var missing []string
+	for col := range dtypes {
+		if _, ok := df.data[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, &ColumnNotFoundError{Column: missing[0], msg: "columns not found: " + strings.Join(missing, ", ")}
+	}
+
+	newDF := df.Copy()
+	var failures []string
+	for _, col := range df.columns {
+		dtype, ok := dtypes[col]
+		if !ok {
+			continue
+		}
+		converted, err := df.data[col].asType(dtype, opts)
+		if err != nil {
+			if opts.Errors != CastIgnore {
+				failures = append(failures, fmt.Sprintf("column '%s' to %s: %v", col, dtype, err))
+			}
+			continue
+		}
+		newDF.data[col] = converted
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("cannot convert %s", strings.Join(failures, "; "))
 	}
-	return false
+	return newDF, nil
 }
@@ -0,0 +1,125 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrIndexOutOfRange is matched by errors.Is for an IndexOutOfRangeError.
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// ErrLabelNotFound is matched by errors.Is for a LabelNotFoundError.
+	ErrLabelNotFound = errors.New("label not found")
+	// ErrLengthMismatch is matched by errors.Is for a LengthMismatchError.
+	ErrLengthMismatch = errors.New("length mismatch")
+	// ErrTypeConversion is matched by errors.Is for a TypeConversionError.
+	ErrTypeConversion = errors.New("type conversion failed")
+)
+
+// ColumnNotFoundError is returned when a DataFrame has no column of the
+// given name. errors.Is matches it with ErrColumnNotFound.
+type ColumnNotFoundError struct {
+	Column string
+	msg    string
+}
+
+func (e *ColumnNotFoundError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("column '%s' not found", e.Column)
+}
+
+// Is reports whether target is ErrColumnNotFound.
+func (e *ColumnNotFoundError) Is(target error) bool {
+	return target == ErrColumnNotFound
+}
+
+// IndexOutOfRangeError is returned when a position is outside [0, Len).
+// errors.Is matches it with ErrIndexOutOfRange.
+type IndexOutOfRangeError struct {
+	Pos, Len int
+	msg      string
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("index %d out of range [0, %d)", e.Pos, e.Len)
+}
+
+// Is reports whether target is ErrIndexOutOfRange.
+func (e *IndexOutOfRangeError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+// LabelNotFoundError is returned when an index, or a GroupBy, has no such
+// label. errors.Is matches it with ErrLabelNotFound.
+type LabelNotFoundError struct {
+	Label interface{}
+	msg   string
+}
+
+func (e *LabelNotFoundError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("label %v not found in index", e.Label)
+}
+
+// Is reports whether target is ErrLabelNotFound.
+func (e *LabelNotFoundError) Is(target error) bool {
+	return target == ErrLabelNotFound
+}
+
+// LengthMismatchError is returned when values have Got elements where Want
+// are needed, such as a mask for a DataFrame with Want rows. errors.Is
+// matches it with ErrLengthMismatch.
+type LengthMismatchError struct {
+	Want, Got int
+	msg       string
+}
+
+func (e *LengthMismatchError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("length %d does not match %d", e.Got, e.Want)
+}
+
+// Is reports whether target is ErrLengthMismatch.
+func (e *LengthMismatchError) Is(target error) bool {
+	return target == ErrLengthMismatch
+}
+
+// TypeConversionError is returned when Value cannot be converted to the
+// Target dtype. Err, when set, is the underlying error and gives the
+// message. errors.Is matches it with ErrTypeConversion.
+type TypeConversionError struct {
+	Value  interface{}
+	Target DType
+	Err    error
+}
+
+func (e *TypeConversionError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("cannot convert %v to %s", e.Value, e.Target)
+}
+
+func (e *TypeConversionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTypeConversion.
+func (e *TypeConversionError) Is(target error) bool {
+	return target == ErrTypeConversion
+}
+
+// lengthMismatch returns a LengthMismatchError with the message format
+// gives.
+func lengthMismatch(want, got int, format string, args ...interface{}) error {
+	return &LengthMismatchError{Want: want, Got: got, msg: fmt.Sprintf(format, args...)}
+}
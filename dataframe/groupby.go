@@ -1,17 +1,30 @@
 package dataframe
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
 	"sort"
 	"sync"
+	"time"
 )
 
-// GroupBy represents a grouped DataFrame for aggregation operations
+// GroupBy represents a grouped DataFrame for aggregation operations.
+// The convenience aggregations (Sum, Mean, Count, ...) are lenient and skip
+// columns that do not exist; Agg reports them as errors.
+// Aggregations return one row per group with the key columns first and a new
+// RangeIndex, or the key values as index with GroupByOptions.KeyIndex; row
+// selections such as HeadN and Filter keep the index labels of the selected
+// rows.
 type GroupBy struct {
 	df       *DataFrame
 	byKeys   []string                    // column names to group by
+	keys     []*Series                   // key values, one Series per name in byKeys
 	groups   map[string][]int            // group key -> row indices
 	keyOrder []string                    // maintain order of groups
+	keyIndex bool                        // index aggregation results by key value, see GroupByOptions
 	mu       sync.RWMutex
 }
 
@@ -47,6 +60,12 @@ var (
 	AggVar = func(s *Series) interface{} {
 		return s.Var()
 	}
+	AggStdP = func(s *Series) interface{} {
+		return s.Std(0)
+	}
+	AggVarP = func(s *Series) interface{} {
+		return s.Var(0)
+	}
 	AggFirst = func(s *Series) interface{} {
 		if s.Len() > 0 {
 			v, _ := s.Get(0)
@@ -61,57 +80,300 @@ var (
 		}
 		return nil
 	}
+	// AggMedian returns the median of the numeric values, skipping NA.
+	AggMedian = func(s *Series) interface{} {
+		return s.Median()
+	}
+	// AggNUnique counts the distinct non-NA values of any dtype.
+	AggNUnique = func(s *Series) interface{} {
+		seen := make(map[interface{}]bool)
+		for _, v := range s.data {
+			if !s.isNA(v) {
+				seen[hashKey(v)] = true
+			}
+		}
+		return len(seen)
+	}
 )
 
-// GroupBy groups the DataFrame by the specified columns
+// AggOptions controls how aggregations treat NA values. The Series
+// aggregations behind AggSum, AggMean and the like skip NA values, so an
+// all-NA group sums to 0 and averages to NaN; AggOptions decides when such
+// results are reported as NA instead.
+type AggOptions struct {
+	MinCount    int  // minimum number of non-NA values for a non-NA result
+	PropagateNA bool // any NA makes the result NA; by default NA values are skipped
+}
+
+// DefaultAggOptions returns options matching the plain aggregations: NA
+// values are skipped and no minimum count applies. It is the zero
+// AggOptions.
+func DefaultAggOptions() AggOptions {
+	return AggOptions{}
+}
+
+// naResult reports whether an aggregation of s must be NA under opts.
+func (opts AggOptions) naResult(s *Series) bool {
+	count := s.Count()
+	return count < opts.MinCount || (opts.PropagateNA && count < s.Len())
+}
+
+// WithAggOptions returns fn wrapped to return nil when the values of a group
+// do not satisfy opts.
+func WithAggOptions(fn AggFunc, opts AggOptions) AggFunc {
+	return func(s *Series) interface{} {
+		if opts.naResult(s) {
+			return nil
+		}
+		return fn(s)
+	}
+}
+
+// AggFuncDF defines an aggregation function over all columns of a group,
+// for aggregations that combine several columns.
+type AggFuncDF func(*DataFrame) interface{}
+
+// AggWeightedMean returns an aggregation function computing
+// sum(value*weight) / sum(weight) over the rows where both columns are
+// numeric and not NA. The result is NaN when the weights sum to zero or a
+// column is missing.
+func AggWeightedMean(valueCol, weightCol string) AggFuncDF {
+	return func(df *DataFrame) interface{} {
+		values, ok1 := df.data[valueCol]
+		weights, ok2 := df.data[weightCol]
+		if !ok1 || !ok2 {
+			return math.NaN()
+		}
+		var sum, weightSum float64
+		for i := 0; i < df.shape[0]; i++ {
+			v, w := values.data[i], weights.data[i]
+			if values.isNA(v) || weights.isNA(w) {
+				continue
+			}
+			fv, err1 := toFloat64(v)
+			fw, err2 := toFloat64(w)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			sum += fv * fw
+			weightSum += fw
+		}
+		if weightSum == 0 {
+			return math.NaN()
+		}
+		return sum / weightSum
+	}
+}
+
+// AggQuantile returns an aggregation function computing the q-th quantile
+// (0 <= q <= 1) of the numeric values with linear interpolation, skipping NA.
+// The result is NaN for groups without numeric values or q out of range.
+// Its result columns are not named automatically, so give AggNamed a Name.
+func AggQuantile(q float64) AggFunc {
+	return func(s *Series) interface{} {
+		if q < 0 || q > 1 {
+			return math.NaN()
+		}
+		values, _ := s.numericValues(false)
+		sort.Float64s(values)
+		return quantileSorted(values, q)
+	}
+}
+
+// GroupByOptions defines options for GroupByWith.
+type GroupByOptions struct {
+	DropNA bool // leave out rows with an NA value in any key; otherwise NA keys form their own groups
+	Sort   bool // order groups by key value instead of first appearance
+	// KeyIndex labels the rows of aggregation results with their key value,
+	// in an index named after the key column, instead of a new RangeIndex.
+	// It needs a single key column, which is kept as a column too.
+	KeyIndex bool
+}
+
+// DefaultGroupByOptions returns the options used by GroupBy: rows with NA
+// keys are dropped and groups keep their order of first appearance.
+func DefaultGroupByOptions() GroupByOptions {
+	return GroupByOptions{DropNA: true}
+}
+
+// GroupBy groups the DataFrame by the specified columns using
+// DefaultGroupByOptions.
 func (df *DataFrame) GroupBy(columns ...string) (*GroupBy, error) {
+	return df.GroupByWith(DefaultGroupByOptions(), columns...)
+}
+
+// GroupByWith groups the DataFrame by the specified columns according to
+// opts. With DropNA false, rows with NA keys are grouped by their nil key
+// value like any other value. Sorted groups are ordered by their key values
+// as SortBy orders them, with NA keys last.
+func (df *DataFrame) GroupByWith(opts GroupByOptions, columns ...string) (*GroupBy, error) {
+	if opts.KeyIndex && len(columns) != 1 {
+		return nil, fmt.Errorf("KeyIndex needs a single key column, got %d", len(columns))
+	}
 	// Validate columns exist
 	for _, col := range columns {
 		if _, ok := df.data[col]; !ok {
-			return nil, fmt.Errorf("column '%s' not found", col)
+			return nil, &ColumnNotFoundError{Column: col}
 		}
 	}
+	return newGroupBy(df, columns, keySeries(df, columns), opts, 1), nil
+}
 
+// GroupByParallel groups the DataFrame by the specified columns like
+// GroupBy, building the groups of large DataFrames with several goroutines.
+// The result is identical to GroupBy.
+func (df *DataFrame) GroupByParallel(opts ParallelOptions, columns ...string) (*GroupBy, error) {
+	for _, col := range columns {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+	}
+	workers := getNumWorkers(opts, df.shape[0])
+	return newGroupBy(df, columns, keySeries(df, columns), DefaultGroupByOptions(), workers), nil
+}
+
+// GroupByFunc groups the DataFrame by the value keyFn computes for each row.
+// The key appears under name in aggregation results, just like a grouping
+// column; a column of df with the same name is treated as that key and not
+// aggregated.
+func (df *DataFrame) GroupByFunc(name string, keyFn func(Row) interface{}) (*GroupBy, error) {
+	if name == "" {
+		return nil, fmt.Errorf("group key name must not be empty")
+	}
+	values := make([]interface{}, df.shape[0])
+	for i := range values {
+		values[i] = keyFn(Row{df: df, pos: i})
+	}
+	return newGroupBy(df, []string{name}, []*Series{NewSeries(values, name)}, DefaultGroupByOptions(), 1), nil
+}
+
+// GroupBySeries groups the DataFrame by the values of keys, matched to rows
+// by position. Each Series must have as many values as df has rows, and its
+// name, which must be unique, is used as the key column name in results
+// (key_i for the i-th Series when unnamed). As with GroupByFunc, a column of
+// df with a key's name is not aggregated.
+func (df *DataFrame) GroupBySeries(keys ...*Series) (*GroupBy, error) {
+	names := make([]string, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for i, s := range keys {
+		if s.Len() != df.shape[0] {
+			return nil, lengthMismatch(df.shape[0], s.Len(), "key series '%s' has length %d, expected %d", s.name, s.Len(), df.shape[0])
+		}
+		names[i] = s.name
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("key_%d", i)
+		}
+		if seen[names[i]] {
+			return nil, fmt.Errorf("duplicate group key name '%s'", names[i])
+		}
+		seen[names[i]] = true
+	}
+	return newGroupBy(df, names, keys, DefaultGroupByOptions(), 1), nil
+}
+
+// newGroupBy groups the rows of df by the values of keys, named names, using
+// up to workers goroutines.
+func newGroupBy(df *DataFrame, names []string, keys []*Series, opts GroupByOptions, workers int) *GroupBy {
 	gb := &GroupBy{
 		df:       df,
-		byKeys:   columns,
+		byKeys:   names,
+		keys:     keys,
 		groups:   make(map[string][]int),
 		keyOrder: make([]string, 0),
+		keyIndex: opts.KeyIndex,
 	}
 
-	// Build groups
-	for i := 0; i < df.shape[0]; i++ {
-		key := gb.buildGroupKey(i)
-		if _, exists := gb.groups[key]; !exists {
-			gb.keyOrder = append(gb.keyOrder, key)
+	// Build groups from row hashes; only the first row of each group
+	// needs a string key
+	ids, first := rowGroupsParallel(keys, df.shape[0], workers)
+	groupKeys := make([]string, len(first))
+	dropped := make([]bool, len(first))
+	runChunked(len(first), min(workers, len(first)), func(start, end int) {
+		for id := start; id < end; id++ {
+			if opts.DropNA && groupKeyHasNA(keys, first[id]) {
+				dropped[id] = true
+				continue
+			}
+			groupKeys[id] = gb.buildGroupKey(first[id])
+		}
+	})
+
+	sizes := make([]int, len(first))
+	for _, id := range ids {
+		sizes[id]++
+	}
+	indices := make([][]int, len(first))
+	for id := range first {
+		if !dropped[id] {
+			indices[id] = make([]int, 0, sizes[id])
+			gb.keyOrder = append(gb.keyOrder, groupKeys[id])
+		}
+	}
+	for i, id := range ids {
+		if !dropped[id] {
+			indices[id] = append(indices[id], i)
+		}
+	}
+	for id, key := range groupKeys {
+		if !dropped[id] {
+			gb.groups[key] = indices[id]
 		}
-		gb.groups[key] = append(gb.groups[key], i)
 	}
 
-	return gb, nil
+	if opts.Sort {
+		gb.sortGroups()
+	}
+	return gb
 }
 
-// buildGroupKey creates a unique string key for a row based on grouping columns
-func (gb *GroupBy) buildGroupKey(rowIdx int) string {
-	key := ""
-	for i, col := range gb.byKeys {
-		s := gb.df.data[col]
-		val, _ := s.Get(rowIdx)
-		if i > 0 {
-			key += "\x00" // null separator
+// sortGroups orders the groups by their key values, comparing key columns
+// in turn and placing NA keys last.
+func (gb *GroupBy) sortGroups() {
+	keyVals := make(map[string][]interface{}, len(gb.keyOrder))
+	for _, groupKey := range gb.keyOrder {
+		keyVals[groupKey] = gb.getGroupKeyValues(gb.groups[groupKey][0])
+	}
+	sort.SliceStable(gb.keyOrder, func(i, j int) bool {
+		a, b := keyVals[gb.keyOrder[i]], keyVals[gb.keyOrder[j]]
+		for k := range a {
+			aNA := gb.keys[k].isNA(a[k])
+			bNA := gb.keys[k].isNA(b[k])
+			switch {
+			case aNA && bNA:
+				continue
+			case aNA || bNA:
+				return bNA
+			}
+			if c := compareValues(a[k], b[k]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// groupKeyHasNA reports whether the key tuple of row pos contains a value
+// that is NA in its key Series, the same test sortGroups uses.
+func groupKeyHasNA(keys []*Series, pos int) bool {
+	for _, s := range keys {
+		if s.isNA(s.data[pos]) {
+			return true
 		}
-		key += fmt.Sprintf("%v", val)
 	}
-	return key
+	return false
+}
+
+// buildGroupKey creates a unique string key for a row based on grouping columns.
+// The key is type-aware, so 1 and "1" form different groups.
+func (gb *GroupBy) buildGroupKey(rowIdx int) string {
+	return compositeKey(gb.getGroupKeyValues(rowIdx))
 }
 
 // getGroupKeyValues extracts the actual values for a group key
 func (gb *GroupBy) getGroupKeyValues(rowIdx int) []interface{} {
-	values := make([]interface{}, len(gb.byKeys))
-	for i, col := range gb.byKeys {
-		s := gb.df.data[col]
-		val, _ := s.Get(rowIdx)
-		values[i] = val
+	values := make([]interface{}, len(gb.keys))
+	for i, s := range gb.keys {
+		values[i] = s.data[rowIdx]
 	}
 	return values
 }
@@ -121,141 +383,544 @@ func (gb *GroupBy) NGroups() int {
 	return len(gb.groups)
 }
 
-// Groups returns the group keys and their row indices
+// Groups returns the group keys and their row indices.
+// The keys are opaque, type-aware identifiers; read the grouping columns at
+// any of a group's rows to get its key values.
 func (gb *GroupBy) Groups() map[string][]int {
 	return gb.groups
 }
 
-// Size returns a Series with the size of each group
-func (gb *GroupBy) Size() *DataFrame {
-	keyData := make(map[string][]interface{})
-	for _, col := range gb.byKeys {
-		keyData[col] = make([]interface{}, 0, len(gb.keyOrder))
+// Keys returns the key values of every group, one value per grouping
+// column, in order of first appearance.
+func (gb *GroupBy) Keys() [][]interface{} {
+	keys := make([][]interface{}, len(gb.keyOrder))
+	for i, groupKey := range gb.keyOrder {
+		keys[i] = gb.getGroupKeyValues(gb.groups[groupKey][0])
 	}
-	sizes := make([]interface{}, 0, len(gb.keyOrder))
+	return keys
+}
 
+// GetGroup returns the rows of the group with the given key values, one per
+// grouping column. Keys match like grouping does, so "A" finds the group of
+// string "A" but not of 65, while int(1) and int64(1) are the same key. The
+// rows keep their index labels.
+func (gb *GroupBy) GetGroup(key ...interface{}) (*DataFrame, error) {
+	if len(key) != len(gb.byKeys) {
+		return nil, lengthMismatch(len(gb.byKeys), len(key), "group key has %d values, expected %d", len(key), len(gb.byKeys))
+	}
+	indices, ok := gb.groups[compositeKey(key)]
+	if !ok {
+		return nil, &LabelNotFoundError{Label: key, msg: fmt.Sprintf("group %v not found", key)}
+	}
+	return gb.df.takeRows(indices), nil
+}
+
+// ForEach calls fn with the key values and rows of every group in order of
+// first appearance, stopping at and returning the first error.
+func (gb *GroupBy) ForEach(fn func(key []interface{}, group *DataFrame) error) error {
 	for _, groupKey := range gb.keyOrder {
 		indices := gb.groups[groupKey]
-		if len(indices) > 0 {
-			keyVals := gb.getGroupKeyValues(indices[0])
-			for i, col := range gb.byKeys {
-				keyData[col] = append(keyData[col], keyVals[i])
-			}
-			sizes = append(sizes, len(indices))
+		if err := fn(gb.getGroupKeyValues(indices[0]), gb.df.takeRows(indices)); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// Build result DataFrame
-	data := make(map[string][]interface{})
-	for col, vals := range keyData {
-		data[col] = vals
+// Size returns the key columns followed by a size column holding the number
+// of rows of each group.
+func (gb *GroupBy) Size() *DataFrame {
+	sizes := make([]interface{}, len(gb.keyOrder))
+	for g, groupKey := range gb.keyOrder {
+		sizes[g] = len(gb.groups[groupKey])
 	}
-	data["size"] = sizes
-
-	result, _ := New(data)
+	result := gb.keyFrame()
+	result.addColumn("size", sizes)
 	return result
 }
 
-// Agg applies multiple aggregation functions to specified columns
+// NamedAgg describes one aggregation for AggNamed: Func applied to Column,
+// stored in a result column called Name.
+type NamedAgg struct {
+	Column string
+	Func   AggFunc
+	Name   string // result column name (default column_func for predefined functions)
+}
+
+// aggFuncNames holds the canonical names of the predefined aggregation
+// functions, used to name result columns.
+var aggFuncNames = []struct {
+	fn   AggFunc
+	name string
+}{
+	{AggSum, "sum"}, {AggMean, "mean"}, {AggMin, "min"}, {AggMax, "max"},
+	{AggCount, "count"}, {AggStd, "std"}, {AggVar, "var"}, {AggStdP, "stdp"},
+	{AggVarP, "varp"}, {AggFirst, "first"}, {AggLast, "last"},
+	{AggMedian, "median"}, {AggNUnique, "nunique"},
+}
+
+// aggFuncName returns the canonical name of a predefined aggregation function.
+func aggFuncName(fn AggFunc) (string, bool) {
+	if fn == nil {
+		return "", false
+	}
+	ptr := reflect.ValueOf(fn).Pointer()
+	for _, f := range aggFuncNames {
+		if reflect.ValueOf(f.fn).Pointer() == ptr {
+			return f.name, true
+		}
+	}
+	return "", false
+}
+
+// Agg applies multiple aggregation functions to specified columns.
+// Result columns are named column_func for the predefined functions (for
+// example value_sum) and column_i, with i the position in the list, for
+// other functions or a repeated predefined function. The key columns come
+// first, followed by the aggregated columns in column name order.
 func (gb *GroupBy) Agg(aggFuncs map[string][]AggFunc) (*DataFrame, error) {
-	// Validate columns
-	for col := range aggFuncs {
-		if _, ok := gb.df.data[col]; !ok {
-			return nil, fmt.Errorf("column '%s' not found", col)
+	specs, err := gb.aggSpecs(aggFuncs)
+	if err != nil {
+		return nil, err
+	}
+	return gb.aggregate(specs, 1)
+}
+
+// AggNamed applies the aggregations in specs and returns one result column
+// per spec, after the key columns and in spec order. A spec without a Name
+// is named column_func when Func is a predefined function such as AggSum;
+// other functions need an explicit Name.
+func (gb *GroupBy) AggNamed(specs ...NamedAgg) (*DataFrame, error) {
+	resolved := make([]NamedAgg, len(specs))
+	for i, spec := range specs {
+		if spec.Func == nil {
+			return nil, fmt.Errorf("aggregation %d on column '%s' has no function", i, spec.Column)
+		}
+		if spec.Name == "" {
+			name, ok := aggFuncName(spec.Func)
+			if !ok {
+				return nil, fmt.Errorf("aggregation %d on column '%s' needs a Name", i, spec.Column)
+			}
+			spec.Name = spec.Column + "_" + name
 		}
+		resolved[i] = spec
 	}
+	return gb.aggregate(resolved, 1)
+}
 
-	// Prepare result data
-	keyData := make(map[string][]interface{})
-	for _, col := range gb.byKeys {
-		keyData[col] = make([]interface{}, 0, len(gb.keyOrder))
+// AggDF applies each function in specs to the rows of every group and
+// stores the result in a column named by its key. The result has the key
+// columns followed by the spec columns in name order.
+func (gb *GroupBy) AggDF(specs map[string]AggFuncDF) (*DataFrame, error) {
+	names := make([]string, 0, len(specs))
+	for name, fn := range specs {
+		if fn == nil {
+			return nil, fmt.Errorf("aggregation '%s' has no function", name)
+		}
+		for _, key := range gb.byKeys {
+			if name == key {
+				return nil, fmt.Errorf("duplicate result column '%s'", name)
+			}
+		}
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	aggData := make(map[string][]interface{})
-	for col, funcs := range aggFuncs {
-		for i := range funcs {
-			aggCol := fmt.Sprintf("%s_%d", col, i)
-			aggData[aggCol] = make([]interface{}, 0, len(gb.keyOrder))
+	numGroups := len(gb.keyOrder)
+	values := make([][]interface{}, len(names))
+	for j := range values {
+		values[j] = make([]interface{}, numGroups)
+	}
+	for g, groupKey := range gb.keyOrder {
+		groupDF := gb.getGroupDataFrame(gb.groups[groupKey])
+		for j, name := range names {
+			values[j][g] = specs[name](groupDF)
 		}
 	}
 
-	// Apply aggregations
-	for _, groupKey := range gb.keyOrder {
-		indices := gb.groups[groupKey]
-		if len(indices) == 0 {
-			continue
+	result := gb.keyFrame()
+	for j, name := range names {
+		result.addColumn(name, values[j])
+	}
+	return result, nil
+}
+
+// Unstack aggregates aggCol per group with aggFunc (default AggSum) and
+// pivots the key column level into result columns: the other key columns
+// become leading columns with one row per combination, and each distinct
+// level value becomes a column named by its string form, in sorted order.
+// Combinations without a group are filled with fillValue.
+func (gb *GroupBy) Unstack(level string, aggCol string, fillValue interface{}, aggFunc ...AggFunc) (*DataFrame, error) {
+	var rowKeys []string
+	found := false
+	for _, key := range gb.byKeys {
+		switch key {
+		case level:
+			found = true
+		case aggCol:
+			return nil, fmt.Errorf("cannot unstack key column '%s'", aggCol)
+		default:
+			rowKeys = append(rowKeys, key)
 		}
+	}
+	if !found {
+		return nil, fmt.Errorf("level '%s' is not a group key", level)
+	}
+	if len(rowKeys) == 0 {
+		return nil, fmt.Errorf("unstack needs a group key besides '%s'", level)
+	}
+
+	fn := AggSum
+	if len(aggFunc) > 0 && aggFunc[0] != nil {
+		fn = aggFunc[0]
+	}
+	aggregated, err := gb.aggregate([]NamedAgg{{Column: aggCol, Func: fn, Name: aggCol}}, 1)
+	if err != nil {
+		return nil, err
+	}
+	return aggregated.pivot(PivotOptions{Index: rowKeys, Columns: level, Values: aggCol, FillValue: fillValue}, false)
+}
 
-		// Add key values
-		keyVals := gb.getGroupKeyValues(indices[0])
-		for i, col := range gb.byKeys {
-			keyData[col] = append(keyData[col], keyVals[i])
+// aggSpecs converts an Agg map to named specs in column name order.
+func (gb *GroupBy) aggSpecs(aggFuncs map[string][]AggFunc) ([]NamedAgg, error) {
+	cols := make([]string, 0, len(aggFuncs))
+	for col := range aggFuncs {
+		if _, ok := gb.df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
 		}
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
 
-		// Apply aggregation functions
-		for col, funcs := range aggFuncs {
-			groupSeries := gb.getGroupSeries(col, indices)
-			for i, fn := range funcs {
-				aggCol := fmt.Sprintf("%s_%d", col, i)
-				aggData[aggCol] = append(aggData[aggCol], fn(groupSeries))
+	var specs []NamedAgg
+	for _, col := range cols {
+		used := make(map[string]bool)
+		for i, fn := range aggFuncs[col] {
+			name := fmt.Sprintf("%s_%d", col, i)
+			if fnName, ok := aggFuncName(fn); ok && !used[fnName] {
+				name = col + "_" + fnName
+				used[fnName] = true
 			}
+			specs = append(specs, NamedAgg{Column: col, Func: fn, Name: name})
 		}
 	}
+	return specs, nil
+}
 
-	// Build result DataFrame
-	data := make(map[string][]interface{})
-	for col, vals := range keyData {
-		data[col] = vals
+// aggregate evaluates specs for every group using up to workers goroutines
+// and returns the key columns followed by one column per spec.
+func (gb *GroupBy) aggregate(specs []NamedAgg, workers int) (*DataFrame, error) {
+	return gb.aggregateCtx(context.Background(), specs, workers, ParallelOptions{})
+}
+
+// aggregateCtx is aggregate that stops and returns ctx.Err() once ctx is
+// done, with the groups aggregated as runChunks runs them with opts.
+func (gb *GroupBy) aggregateCtx(ctx context.Context, specs []NamedAgg, workers int, opts ParallelOptions) (*DataFrame, error) {
+	names := make(map[string]bool, len(specs))
+	for _, key := range gb.byKeys {
+		names[key] = true
+	}
+	for _, spec := range specs {
+		if _, ok := gb.df.data[spec.Column]; !ok {
+			return nil, &ColumnNotFoundError{Column: spec.Column}
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("duplicate result column '%s'", spec.Name)
+		}
+		names[spec.Name] = true
+	}
+
+	numGroups := len(gb.keyOrder)
+	values := make([][]interface{}, len(specs))
+	for j := range values {
+		values[j] = make([]interface{}, numGroups)
+	}
+	aggGroups := func(start, end int) error {
+		for g := start; g < end; g++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+			indices := gb.groups[gb.keyOrder[g]]
+			series := make(map[string]*Series)
+			for j, spec := range specs {
+				s, ok := series[spec.Column]
+				if !ok {
+					s = gb.getGroupSeries(spec.Column, indices)
+					series[spec.Column] = s
+				}
+				values[j][g] = spec.Func(s)
+			}
+		}
+		return nil
+	}
+
+	if err := runChunkedCtx(ctx, opts, numGroups, workers, aggGroups); err != nil {
+		return nil, err
+	}
+
+	result := gb.keyFrame()
+	for j, spec := range specs {
+		result.addColumn(spec.Name, values[j])
+	}
+	return result, nil
+}
+
+// keyFrame returns a DataFrame with one row per group holding the key values,
+// for aggregation results to add their columns to with addColumn. Its index
+// is a RangeIndex, or the key values with keyIndex.
+func (gb *GroupBy) keyFrame() *DataFrame {
+	numGroups := len(gb.keyOrder)
+	keyVals := make([][]interface{}, len(gb.byKeys))
+	for i := range gb.byKeys {
+		keyVals[i] = make([]interface{}, numGroups)
+		for g, groupKey := range gb.keyOrder {
+			keyVals[i][g] = gb.keys[i].data[gb.groups[groupKey][0]]
+		}
+	}
+	index := NewRangeIndex(numGroups)
+	if gb.keyIndex {
+		index = NewIndex(append([]interface{}(nil), keyVals[0]...), gb.byKeys[0])
+	}
+	result := &DataFrame{
+		columns: make([]string, 0, len(gb.byKeys)),
+		data:    make(map[string]*Series, len(gb.byKeys)),
+		index:   index,
+		shape:   [2]int{numGroups, 0},
+	}
+	for i, key := range gb.byKeys {
+		result.addColumn(key, keyVals[i])
+	}
+	return result
+}
+
+// addColumn appends a column holding values, labelled by the index of df.
+func (df *DataFrame) addColumn(name string, values []interface{}) {
+	df.columns = append(df.columns, name)
+	df.data[name] = NewSeriesWithIndex(values, name, df.index.Copy())
+	df.shape[1] = len(df.columns)
+}
+
+// runChunked calls fn on consecutive ranges covering [0, n), running up to
+// workers ranges concurrently. A panic in fn is raised again in the calling
+// goroutine as a *PanicError once all ranges are done.
+func runChunked(n, workers int, fn func(start, end int)) {
+	runChunks(ParallelOptions{}, n, workers, func(_, start, end int) error {
+		fn(start, end)
+		return nil
+	})
+}
+
+// runChunks is runChunked for parallel operations run with opts: the ranges
+// run on opts.Pool if it is set, fn is also given the number of its range,
+// and the completion of each range, or the error it returns, is reported to
+// the hooks of opts.
+func runChunks(opts ParallelOptions, n, workers int, fn func(chunk, start, end int) error) {
+	prog := newProgress(opts, n)
+	defer prog.close()
+	if workers <= 1 || n <= 1 {
+		prog.chunkFinished(0, n, fn(0, 0, n))
+		return
+	}
+	chunkSize := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	var wp workerPanic
+	for chunk, start := 0, 0; start < n; chunk, start = chunk+1, start+chunkSize {
+		end := min(start+chunkSize, n)
+		wg.Add(1)
+		goTask(opts.Pool, func() {
+			defer wg.Done()
+			pos := -1
+			defer wp.catch(&pos)
+			prog.chunkFinished(chunk, end-start, fn(chunk, start, end))
+		})
+	}
+	wg.Wait()
+	wp.repanic()
+}
+
+// chunkCount returns the number of ranges runChunks splits n values into
+// for workers.
+func chunkCount(n, workers int) int {
+	if n == 0 {
+		return 0
+	}
+	if workers <= 1 || n <= 1 {
+		return 1
 	}
-	for col, vals := range aggData {
-		data[col] = vals
+	chunkSize := (n + workers - 1) / workers
+	return (n + chunkSize - 1) / chunkSize
+}
+
+// runChunkedCtx is runChunks for fn that returns ctx.Err() when it sees
+// ctx done. All ranges are waited for, and the context error is returned if
+// any range stopped early.
+func runChunkedCtx(ctx context.Context, opts ParallelOptions, n, workers int, fn func(start, end int) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	var once sync.Once
+	var firstErr error
+	runChunks(opts, n, workers, func(_, start, end int) error {
+		err := fn(start, end)
+		if err != nil {
+			once.Do(func() { firstErr = err })
+		}
+		return err
+	})
+	return firstErr
+}
 
-	return New(data)
+// CumCount numbers the rows of each group from 0 in row order. The result is
+// aligned with the rows and index of the DataFrame, with nil for rows that
+// belong to no group, so it can be added with SetColumn.
+func (gb *GroupBy) CumCount() *Series {
+	return gb.broadcastGroups("cumcount", func(indices []int, values []interface{}) {
+		for i, idx := range indices {
+			values[idx] = int64(i)
+		}
+	})
+}
+
+// SizeBroadcast returns the size of its group for every row, aligned like
+// CumCount.
+func (gb *GroupBy) SizeBroadcast() *Series {
+	return gb.broadcastGroups("size", func(indices []int, values []interface{}) {
+		size := int64(len(indices))
+		for _, idx := range indices {
+			values[idx] = size
+		}
+	})
+}
+
+// broadcastGroups returns an int64 Series aligned with the DataFrame whose
+// values fill writes group by group. Large frames are processed in parallel.
+func (gb *GroupBy) broadcastGroups(name string, fill func(indices []int, values []interface{})) *Series {
+	rows := gb.df.shape[0]
+	values := make([]interface{}, rows)
+	workers := 1
+	if rows > parallelTransformCells {
+		workers = min(getNumWorkers(DefaultParallelOptions(), rows), len(gb.keyOrder))
+	}
+	runChunked(len(gb.keyOrder), workers, func(start, end int) {
+		for _, groupKey := range gb.keyOrder[start:end] {
+			fill(gb.groups[groupKey], values)
+		}
+	})
+	return &Series{name: name, data: values, dtype: DTypeInt64, index: gb.df.index.Copy()}
 }
 
 // Sum computes sum for all numeric columns
 func (gb *GroupBy) Sum(columns ...string) *DataFrame {
-	return gb.applyAgg(AggSum, "sum", columns...)
+	return gb.applyAgg(AggSum, "sum", true, columns...)
 }
 
 // Mean computes mean for all numeric columns
 func (gb *GroupBy) Mean(columns ...string) *DataFrame {
-	return gb.applyAgg(AggMean, "mean", columns...)
+	return gb.applyAgg(AggMean, "mean", true, columns...)
 }
 
 // Min computes minimum for all numeric columns
 func (gb *GroupBy) Min(columns ...string) *DataFrame {
-	return gb.applyAgg(AggMin, "min", columns...)
+	return gb.applyAgg(AggMin, "min", true, columns...)
 }
 
 // Max computes maximum for all numeric columns
 func (gb *GroupBy) Max(columns ...string) *DataFrame {
-	return gb.applyAgg(AggMax, "max", columns...)
+	return gb.applyAgg(AggMax, "max", true, columns...)
 }
 
 // Count computes count for all columns
 func (gb *GroupBy) Count(columns ...string) *DataFrame {
-	return gb.applyAgg(AggCount, "count", columns...)
+	return gb.applyAgg(AggCount, "count", false, columns...)
+}
+
+// Median computes median for all numeric columns
+func (gb *GroupBy) Median(columns ...string) *DataFrame {
+	return gb.applyAgg(AggMedian, "median", true, columns...)
+}
+
+// NUnique counts the distinct non-NA values in each group for all columns
+func (gb *GroupBy) NUnique(columns ...string) *DataFrame {
+	return gb.applyAgg(AggNUnique, "nunique", false, columns...)
+}
+
+// SumOpt computes sum for all numeric columns like Sum, with a nil sum for
+// groups that do not satisfy opts.
+func (gb *GroupBy) SumOpt(opts AggOptions, columns ...string) *DataFrame {
+	return gb.applyAgg(WithAggOptions(AggSum, opts), "sum", true, columns...)
+}
+
+// MeanOpt computes mean for all numeric columns like Mean, see SumOpt.
+func (gb *GroupBy) MeanOpt(opts AggOptions, columns ...string) *DataFrame {
+	return gb.applyAgg(WithAggOptions(AggMean, opts), "mean", true, columns...)
+}
+
+// MinOpt computes minimum for all numeric columns like Min, see SumOpt.
+func (gb *GroupBy) MinOpt(opts AggOptions, columns ...string) *DataFrame {
+	return gb.applyAgg(WithAggOptions(AggMin, opts), "min", true, columns...)
+}
+
+// MaxOpt computes maximum for all numeric columns like Max, see SumOpt.
+func (gb *GroupBy) MaxOpt(opts AggOptions, columns ...string) *DataFrame {
+	return gb.applyAgg(WithAggOptions(AggMax, opts), "max", true, columns...)
 }
 
 // Std computes standard deviation for all numeric columns
 func (gb *GroupBy) Std(columns ...string) *DataFrame {
-	return gb.applyAgg(AggStd, "std", columns...)
+	return gb.applyAgg(AggStd, "std", true, columns...)
 }
 
 // First returns first value in each group
 func (gb *GroupBy) First(columns ...string) *DataFrame {
-	return gb.applyAgg(AggFirst, "first", columns...)
+	return gb.applyAgg(AggFirst, "first", false, columns...)
 }
 
 // Last returns last value in each group
 func (gb *GroupBy) Last(columns ...string) *DataFrame {
-	return gb.applyAgg(AggLast, "last", columns...)
+	return gb.applyAgg(AggLast, "last", false, columns...)
+}
+
+// Describe computes count, mean, std, min and max of each numeric column
+// (all non-key columns by default), named like sales_mean. The result has
+// the key columns followed by the statistics of each column in turn.
+func (gb *GroupBy) Describe(columns ...string) *DataFrame {
+	result, _ := gb.applyAggs(
+		[]AggFunc{AggCount, AggMean, AggStd, AggMin, AggMax},
+		[]string{"count", "mean", "std", "min", "max"},
+		true, columns...)
+	return result
 }
 
-// applyAgg applies a single aggregation function to columns
-func (gb *GroupBy) applyAgg(aggFunc AggFunc, suffix string, columns ...string) *DataFrame {
+// AggAll applies each of funcs to every numeric non-key column. funcs must be
+// predefined aggregation functions such as AggSum, whose names are used for
+// the result columns (sales_sum). The result has the key columns followed,
+// for each column in DataFrame order, by one column per function in the
+// given order.
+func (gb *GroupBy) AggAll(funcs ...AggFunc) (*DataFrame, error) {
+	names := make([]string, len(funcs))
+	for i, fn := range funcs {
+		name, ok := aggFuncName(fn)
+		if !ok {
+			return nil, fmt.Errorf("aggregation function %d is not a predefined function, use AggNamed to name it", i)
+		}
+		names[i] = name
+	}
+	return gb.applyAggs(funcs, names, true)
+}
+
+// applyAgg applies a single aggregation function to columns. The result has
+// the key columns followed by one column_suffix column per aggregated column.
+// When numericOnly is set, columns without any numeric values are skipped
+// rather than aggregated to meaningless zeros.
+func (gb *GroupBy) applyAgg(aggFunc AggFunc, suffix string, numericOnly bool, columns ...string) *DataFrame {
+	result, _ := gb.applyAggs([]AggFunc{aggFunc}, []string{suffix}, numericOnly, columns...)
+	return result
+}
+
+// applyAggs applies every function to each of columns, or to all non-key
+// columns when none are given, naming the results column_suffix. Missing
+// columns are skipped, see GroupBy.
+func (gb *GroupBy) applyAggs(funcs []AggFunc, suffixes []string, numericOnly bool, columns ...string) (*DataFrame, error) {
 	// If no columns specified, use all non-key columns
 	if len(columns) == 0 {
 		for _, col := range gb.df.columns {
@@ -272,51 +937,24 @@ func (gb *GroupBy) applyAgg(aggFunc AggFunc, suffix string, columns ...string) *
 		}
 	}
 
-	// Prepare result data
-	keyData := make(map[string][]interface{})
-	for _, col := range gb.byKeys {
-		keyData[col] = make([]interface{}, 0, len(gb.keyOrder))
-	}
-
-	aggData := make(map[string][]interface{})
+	specs := make([]NamedAgg, 0, len(columns)*len(funcs))
+	seen := make(map[string]bool, len(columns))
 	for _, col := range columns {
-		aggData[col+"_"+suffix] = make([]interface{}, 0, len(gb.keyOrder))
-	}
-
-	// Apply aggregation
-	for _, groupKey := range gb.keyOrder {
-		indices := gb.groups[groupKey]
-		if len(indices) == 0 {
+		s, ok := gb.df.data[col]
+		if !ok || seen[col] {
+			// Lenient mode, see GroupBy
 			continue
 		}
-
-		// Add key values
-		keyVals := gb.getGroupKeyValues(indices[0])
-		for i, col := range gb.byKeys {
-			keyData[col] = append(keyData[col], keyVals[i])
+		if numericOnly && !s.IsNumeric() {
+			continue
 		}
-
-		// Apply aggregation
-		for _, col := range columns {
-			if _, ok := gb.df.data[col]; !ok {
-				continue
-			}
-			groupSeries := gb.getGroupSeries(col, indices)
-			aggData[col+"_"+suffix] = append(aggData[col+"_"+suffix], aggFunc(groupSeries))
+		seen[col] = true
+		for i, fn := range funcs {
+			specs = append(specs, NamedAgg{Column: col, Func: fn, Name: col + "_" + suffixes[i]})
 		}
 	}
 
-	// Build result DataFrame
-	data := make(map[string][]interface{})
-	for col, vals := range keyData {
-		data[col] = vals
-	}
-	for col, vals := range aggData {
-		data[col] = vals
-	}
-
-	result, _ := New(data)
-	return result
+	return gb.aggregate(specs, 1)
 }
 
 // getGroupSeries extracts a Series for a specific group
@@ -326,11 +964,26 @@ func (gb *GroupBy) getGroupSeries(col string, indices []int) *Series {
 	for i, idx := range indices {
 		groupData[i], _ = s.Get(idx)
 	}
-	return NewSeries(groupData, col)
+	group := NewSeries(groupData, col)
+	group.na = s.na
+	return group
 }
 
-// Apply applies a custom function to each group
+// Apply applies a custom function to each group and concatenates the
+// results, keeping the union of their columns (missing values are nil).
+// Results that are nil or empty are skipped.
 func (gb *GroupBy) Apply(fn func(*DataFrame) *DataFrame) *DataFrame {
+	return gb.applyGroups(fn, false)
+}
+
+// ApplyWithKeys is Apply with the key columns of each group prepended to its
+// result, repeated for every result row. Result columns named like a key
+// column are replaced by the key.
+func (gb *GroupBy) ApplyWithKeys(fn func(*DataFrame) *DataFrame) *DataFrame {
+	return gb.applyGroups(fn, true)
+}
+
+func (gb *GroupBy) applyGroups(fn func(*DataFrame) *DataFrame, withKeys bool) *DataFrame {
 	var results []*DataFrame
 
 	for _, groupKey := range gb.keyOrder {
@@ -344,9 +997,13 @@ func (gb *GroupBy) Apply(fn func(*DataFrame) *DataFrame) *DataFrame {
 
 		// Apply function
 		result := fn(groupDF)
-		if result != nil && result.shape[0] > 0 {
-			results = append(results, result)
+		if result == nil || result.shape[0] == 0 {
+			continue
+		}
+		if withKeys {
+			result = gb.prependKeys(result, gb.getGroupKeyValues(indices[0]))
 		}
+		results = append(results, result)
 	}
 
 	// Concatenate results
@@ -354,7 +1011,31 @@ func (gb *GroupBy) Apply(fn func(*DataFrame) *DataFrame) *DataFrame {
 		return &DataFrame{columns: []string{}, data: map[string]*Series{}, index: NewRangeIndex(0), shape: [2]int{0, 0}}
 	}
 
-	return Concat(results...)
+	cols, _ := concatColumns(results, "outer")
+	return concatRows(results, cols, false, nil)
+}
+
+// prependKeys returns df with a constant column per key value in front of
+// its other columns.
+func (gb *GroupBy) prependKeys(df *DataFrame, keyVals []interface{}) *DataFrame {
+	rows := df.shape[0]
+	cols := make([]string, 0, len(gb.byKeys)+len(df.columns))
+	seriesMap := make(map[string]*Series, len(gb.byKeys)+len(df.columns))
+	for i, key := range gb.byKeys {
+		values := make([]interface{}, rows)
+		for r := range values {
+			values[r] = keyVals[i]
+		}
+		cols = append(cols, key)
+		seriesMap[key] = &Series{name: key, data: values, dtype: gb.keys[i].dtype, index: df.index.Copy()}
+	}
+	for _, col := range df.columns {
+		if _, ok := seriesMap[col]; !ok {
+			cols = append(cols, col)
+			seriesMap[col] = df.data[col]
+		}
+	}
+	return &DataFrame{columns: cols, data: seriesMap, index: df.index, shape: [2]int{rows, len(cols)}}
 }
 
 // getGroupDataFrame extracts a DataFrame for a specific group
@@ -380,7 +1061,8 @@ func (gb *GroupBy) getGroupDataFrame(indices []int) *DataFrame {
 	}
 }
 
-// Filter filters groups based on a predicate
+// Filter filters groups based on a predicate. The rows of the groups kept
+// are returned in their original order with their index labels.
 func (gb *GroupBy) Filter(predicate func(*DataFrame) bool) *DataFrame {
 	var allIndices []int
 
@@ -396,83 +1078,379 @@ func (gb *GroupBy) Filter(predicate func(*DataFrame) bool) *DataFrame {
 		}
 	}
 
-	if len(allIndices) == 0 {
-		return &DataFrame{columns: gb.df.columns, data: map[string]*Series{}, index: NewRangeIndex(0), shape: [2]int{0, len(gb.df.columns)}}
-	}
-
 	// Sort indices to maintain order
 	sort.Ints(allIndices)
+	return gb.df.takeRows(allIndices)
+}
 
-	// Build result DataFrame
-	seriesMap := make(map[string]*Series)
-	for _, col := range gb.df.columns {
-		s := gb.df.data[col]
-		newData := make([]interface{}, len(allIndices))
-		for i, idx := range allIndices {
-			newData[i], _ = s.Get(idx)
+// Nth returns the n-th row of each group (counting from 0, or from the end
+// for negative n, so -1 is the last row). Groups with too few rows are left
+// out. The rows keep their index labels and original order.
+func (gb *GroupBy) Nth(n int) *DataFrame {
+	return gb.selectGroupRows(func(indices []int) []int {
+		pos := n
+		if pos < 0 {
+			pos += len(indices)
+		}
+		if pos < 0 || pos >= len(indices) {
+			return nil
+		}
+		return indices[pos : pos+1]
+	})
+}
+
+// HeadN returns the first n rows of each group, or all rows of smaller
+// groups. The rows keep their index labels and original order.
+func (gb *GroupBy) HeadN(n int) *DataFrame {
+	return gb.selectGroupRows(func(indices []int) []int {
+		return indices[:max(min(n, len(indices)), 0)]
+	})
+}
+
+// TailN returns the last n rows of each group, or all rows of smaller
+// groups. The rows keep their index labels and original order.
+func (gb *GroupBy) TailN(n int) *DataFrame {
+	return gb.selectGroupRows(func(indices []int) []int {
+		return indices[len(indices)-max(min(n, len(indices)), 0):]
+	})
+}
+
+// IdxMax returns the index label of the row holding the largest value of
+// column in each group, in the order of Keys. NA values are ignored, ties
+// resolve to the first row, and groups without values get a nil label.
+func (gb *GroupBy) IdxMax(column string) (*Series, error) {
+	return gb.extremeLabels(column, 1)
+}
+
+// IdxMin returns the index label of the row holding the smallest value of
+// column in each group, see IdxMax.
+func (gb *GroupBy) IdxMin(column string) (*Series, error) {
+	return gb.extremeLabels(column, -1)
+}
+
+// MaxRows returns the row holding the largest value of column in each group,
+// in group order, with its index label. NA values are ignored, ties resolve
+// to the first row, and groups without values contribute no row.
+func (gb *GroupBy) MaxRows(column string) (*DataFrame, error) {
+	positions, err := gb.extremePositions(column, 1)
+	if err != nil {
+		return nil, err
+	}
+	kept := positions[:0]
+	for _, pos := range positions {
+		if pos >= 0 {
+			kept = append(kept, pos)
 		}
-		seriesMap[col] = NewSeries(newData, col)
 	}
+	return gb.df.takeRows(kept), nil
+}
 
-	cols := make([]string, len(gb.df.columns))
-	copy(cols, gb.df.columns)
+func (gb *GroupBy) extremeLabels(column string, sign int) (*Series, error) {
+	positions, err := gb.extremePositions(column, sign)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]interface{}, len(positions))
+	for i, pos := range positions {
+		if pos >= 0 {
+			labels[i], _ = gb.df.index.Get(pos)
+		}
+	}
+	return NewSeries(labels, column), nil
+}
 
-	return &DataFrame{
-		columns: cols,
-		data:    seriesMap,
-		index:   NewRangeIndex(len(allIndices)),
-		shape:   [2]int{len(allIndices), len(cols)},
+// extremePositions returns the row position of the first largest (sign 1)
+// or smallest (sign -1) non-NA value of column in each group, or -1.
+func (gb *GroupBy) extremePositions(column string, sign int) ([]int, error) {
+	s, ok := gb.df.data[column]
+	if !ok {
+		return nil, &ColumnNotFoundError{Column: column}
 	}
+	positions := make([]int, len(gb.keyOrder))
+	for g, groupKey := range gb.keyOrder {
+		best := -1
+		for _, idx := range gb.groups[groupKey] {
+			v := s.data[idx]
+			if s.isNA(v) {
+				continue
+			}
+			if best < 0 || compareValues(v, s.data[best])*sign > 0 {
+				best = idx
+			}
+		}
+		positions[g] = best
+	}
+	return positions, nil
 }
 
-// Transform applies a function to each group and returns result with original index
-func (gb *GroupBy) Transform(col string, fn func(*Series) *Series) (*Series, error) {
-	if _, ok := gb.df.data[col]; !ok {
-		return nil, fmt.Errorf("column '%s' not found", col)
+// SampleOptions defines options for GroupBy.Sample.
+type SampleOptions struct {
+	Frac   float64 // fraction of each group to sample, rounded; used instead of n when > 0
+	Seed   int64   // seed of the random generator; equal seeds give equal samples, 0 seeds from the clock
+	Strict bool    // return an error when a group has fewer than n rows instead of taking all of them
+}
+
+// Sample draws up to n random rows from each group without replacement, or
+// the fraction opts.Frac of each group. Groups with fewer rows contribute
+// all of them unless opts.Strict is set. The rows keep their index labels
+// and appear in group order, in original row order within a group.
+func (gb *GroupBy) Sample(n int, opts SampleOptions) (*DataFrame, error) {
+	if opts.Frac < 0 || opts.Frac > 1 {
+		return nil, fmt.Errorf("sample fraction %g must be between 0 and 1", opts.Frac)
+	}
+	if opts.Frac == 0 && n < 0 {
+		return nil, fmt.Errorf("sample size %d must not be negative", n)
 	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
 
-	result := make([]interface{}, gb.df.shape[0])
+	var positions []int
+	for _, groupKey := range gb.keyOrder {
+		indices := gb.groups[groupKey]
+		k := n
+		if opts.Frac > 0 {
+			k = int(math.Round(opts.Frac * float64(len(indices))))
+		}
+		if k > len(indices) {
+			if opts.Strict {
+				return nil, fmt.Errorf("group %v has %d rows, fewer than the sample size %d", gb.getGroupKeyValues(indices[0]), len(indices), n)
+			}
+			k = len(indices)
+		}
 
-	for _, indices := range gb.groups {
-		if len(indices) == 0 {
-			continue
+		// Partial Fisher-Yates shuffle of a copy of the group's rows
+		picked := append([]int(nil), indices...)
+		for i := 0; i < k; i++ {
+			j := i + rng.Intn(len(picked)-i)
+			picked[i], picked[j] = picked[j], picked[i]
 		}
+		picked = picked[:k]
+		sort.Ints(picked)
+		positions = append(positions, picked...)
+	}
+	return gb.df.takeRows(positions), nil
+}
 
-		groupSeries := gb.getGroupSeries(col, indices)
-		transformed := fn(groupSeries)
+// selectGroupRows returns the rows picked from each group's row positions,
+// sorted back into original row order.
+func (gb *GroupBy) selectGroupRows(pick func(indices []int) []int) *DataFrame {
+	var positions []int
+	for _, groupKey := range gb.keyOrder {
+		positions = append(positions, pick(gb.groups[groupKey])...)
+	}
+	sort.Ints(positions)
+	return gb.df.takeRows(positions)
+}
 
-		for i, idx := range indices {
-			if i < transformed.Len() {
-				val, _ := transformed.Get(i)
-				result[idx] = val
+// Transform applies fn to the values of col in each group and returns a
+// Series aligned with the rows of the DataFrame: the i-th value of a
+// transformed group is written to the i-th row of that group. fn must return
+// as many values as it was given. Rows that belong to no group are nil.
+func (gb *GroupBy) Transform(col string, fn func(*Series) *Series) (*Series, error) {
+	return gb.transformGroups(col, col+"_transformed", func(s *Series) (*Series, error) {
+		return fn(s), nil
+	})
+}
+
+// CumSum returns the running sum of col within each group, aligned with the
+// rows of the DataFrame. NA and non-numeric values are nil and do not add to
+// the sum. Groups of integers keep int64 sums; otherwise sums are float64.
+func (gb *GroupBy) CumSum(col string) (*Series, error) {
+	return gb.transformGroups(col, col+"_cumsum", func(s *Series) (*Series, error) {
+		sums := make([]interface{}, len(s.data))
+		allInt := true
+		for _, v := range s.data {
+			if _, ok := joinInt(v); !ok && !s.isNA(v) {
+				allInt = false
+			}
+		}
+		var intSum int64
+		var floatSum float64
+		for i, v := range s.data {
+			if s.isNA(v) {
+				continue
+			}
+			if allInt {
+				n, _ := joinInt(v)
+				intSum += n
+				sums[i] = intSum
+				continue
+			}
+			if f, err := toFloat64(v); err == nil {
+				floatSum += f
+				sums[i] = floatSum
+			}
+		}
+		return NewSeries(sums, s.name), nil
+	})
+}
+
+// RankWithin ranks col within each group, see Series.Rank for the methods
+// and NA handling. The ranks are aligned with the rows of the DataFrame.
+func (gb *GroupBy) RankWithin(col string, method string) (*Series, error) {
+	if err := validateRankMethod(method); err != nil {
+		return nil, err
+	}
+	return gb.transformGroups(col, col+"_rank", func(s *Series) (*Series, error) {
+		return s.Rank(RankOptions{Method: method})
+	})
+}
+
+// ZScore standardizes col within each group as (x - mean) / std, using the
+// sample standard deviation of the group. NA and non-numeric values are nil;
+// groups with fewer than two values or no spread give NaN.
+func (gb *GroupBy) ZScore(col string) (*Series, error) {
+	return gb.transformGroups(col, col+"_zscore", func(s *Series) (*Series, error) {
+		mean, std := s.Mean(), s.Std()
+		scores := make([]interface{}, len(s.data))
+		for i, v := range s.data {
+			if s.isNA(v) {
+				continue
+			}
+			if f, err := toFloat64(v); err == nil {
+				scores[i] = (f - mean) / std
 			}
 		}
+		return NewSeries(scores, s.name), nil
+	})
+}
+
+// transformGroups applies fn to the values of col in each group and writes
+// the results back to the rows of the group, returning a Series named name
+// with the DataFrame's index.
+func (gb *GroupBy) transformGroups(col, name string, fn func(*Series) (*Series, error)) (*Series, error) {
+	if _, ok := gb.df.data[col]; !ok {
+		return nil, &ColumnNotFoundError{Column: col}
 	}
 
-	return NewSeries(result, col+"_transformed"), nil
+	result := make([]interface{}, gb.df.shape[0])
+	for _, groupKey := range gb.keyOrder {
+		indices := gb.groups[groupKey]
+		transformed, err := fn(gb.getGroupSeries(col, indices))
+		if err != nil {
+			return nil, err
+		}
+		if transformed == nil || transformed.Len() != len(indices) {
+			n := 0
+			if transformed != nil {
+				n = transformed.Len()
+			}
+			return nil, lengthMismatch(len(indices), n, "transform of column '%s' returned %d values for a group of %d rows", col, n, len(indices))
+		}
+		for i, idx := range indices {
+			result[idx] = transformed.data[i]
+		}
+	}
+
+	return &Series{name: name, data: result, dtype: InferDTypeFromSlice(result), index: gb.df.index.Copy()}, nil
 }
 
-// Concat concatenates multiple DataFrames vertically
+// ConcatOptions defines options for ConcatWith
+type ConcatOptions struct {
+	Join        string   // "outer" (default) keeps all columns, "inner" only the columns every DataFrame has
+	Axis        int      // 0 stacks rows, 1 places the columns side by side
+	IgnoreIndex bool     // reset the result to a RangeIndex instead of keeping the source labels
+	Keys        []string // one identifier per DataFrame: a leading "_key" column for axis 0, a column name prefix for axis 1
+}
+
+// Concat concatenates multiple DataFrames vertically.
+// The result has the columns of the first DataFrame; columns missing from
+// later DataFrames are filled with nil and extra columns are dropped, and
+// the result has a new RangeIndex. Use ConcatWith to keep the union of
+// columns or the source index labels.
 func Concat(dfs ...*DataFrame) *DataFrame {
 	if len(dfs) == 0 {
 		return &DataFrame{columns: []string{}, data: map[string]*Series{}, index: NewRangeIndex(0), shape: [2]int{0, 0}}
 	}
+	return concatRows(dfs, dfs[0].columns, true, nil)
+}
+
+// ConcatWith concatenates DataFrames according to opts.
+// Along axis 0 the rows are stacked, with columns missing from a DataFrame
+// filled with nil. Along axis 1 the DataFrames must have the same number of
+// rows and their columns are placed side by side; column names must be
+// unique after applying Keys.
+func ConcatWith(opts ConcatOptions, dfs ...*DataFrame) (*DataFrame, error) {
+	if len(opts.Keys) > 0 && len(opts.Keys) != len(dfs) {
+		return nil, lengthMismatch(len(dfs), len(opts.Keys), "keys length %d does not match number of DataFrames %d", len(opts.Keys), len(dfs))
+	}
+	if len(dfs) == 0 {
+		return Concat(), nil
+	}
 
-	// Use first DataFrame's columns as reference
-	cols := make([]string, len(dfs[0].columns))
-	copy(cols, dfs[0].columns)
+	switch opts.Axis {
+	case 0:
+		cols, err := concatColumns(dfs, opts.Join)
+		if err != nil {
+			return nil, err
+		}
+		return concatRows(dfs, cols, opts.IgnoreIndex, opts.Keys), nil
+	case 1:
+		return concatColumnsSideBySide(dfs, opts)
+	default:
+		return nil, fmt.Errorf("invalid axis %d, expected 0 or 1", opts.Axis)
+	}
+}
 
-	// Collect all data
-	colData := make(map[string][]interface{})
-	for _, col := range cols {
-		colData[col] = []interface{}{}
+// concatColumns returns the result columns of a row-wise concat in order of
+// first appearance.
+func concatColumns(dfs []*DataFrame, join string) ([]string, error) {
+	var cols []string
+	seen := make(map[string]int)
+	for _, df := range dfs {
+		for _, col := range df.columns {
+			if _, ok := seen[col]; !ok {
+				cols = append(cols, col)
+			}
+			seen[col]++
+		}
 	}
+	switch join {
+	case "", "outer":
+		return cols, nil
+	case "inner":
+		common := make([]string, 0, len(cols))
+		for _, col := range cols {
+			if seen[col] == len(dfs) {
+				common = append(common, col)
+			}
+		}
+		return common, nil
+	default:
+		return nil, fmt.Errorf("invalid join '%s', expected \"outer\" or \"inner\"", join)
+	}
+}
 
+// concatRows stacks the rows of dfs for the given columns, filling missing
+// columns with nil. Columns of different dtypes are promoted with
+// PromoteDTypes.
+func concatRows(dfs []*DataFrame, cols []string, ignoreIndex bool, keys []string) *DataFrame {
 	totalRows := 0
 	for _, df := range dfs {
+		totalRows += df.shape[0]
+	}
+
+	colData := make(map[string][]interface{})
+	colParts := make(map[string][]*Series)
+	for _, col := range cols {
+		colData[col] = make([]interface{}, 0, totalRows)
+	}
+	var labels, keyData []interface{}
+	if !ignoreIndex {
+		labels = make([]interface{}, 0, totalRows)
+	}
+	if len(keys) > 0 {
+		keyData = make([]interface{}, 0, totalRows)
+	}
+
+	for d, df := range dfs {
 		for _, col := range cols {
 			if s, ok := df.data[col]; ok {
 				colData[col] = append(colData[col], s.data...)
+				colParts[col] = append(colParts[col], s)
 			} else {
 				// Fill with nil if column doesn't exist
 				for i := 0; i < df.shape[0]; i++ {
@@ -480,19 +1458,78 @@ func Concat(dfs ...*DataFrame) *DataFrame {
 				}
 			}
 		}
-		totalRows += df.shape[0]
+		if labels != nil {
+			labels = append(labels, df.index.values()...)
+		}
+		if keyData != nil {
+			for i := 0; i < df.shape[0]; i++ {
+				keyData = append(keyData, keys[d])
+			}
+		}
 	}
 
 	// Build result DataFrame
+	resultCols := make([]string, 0, len(cols)+1)
 	seriesMap := make(map[string]*Series)
+	if keyData != nil {
+		resultCols = append(resultCols, "_key")
+		seriesMap["_key"] = NewSeries(keyData, "_key")
+	}
 	for _, col := range cols {
-		seriesMap[col] = NewSeries(colData[col], col)
+		resultCols = append(resultCols, col)
+		seriesMap[col] = combinedSeries(col, colData[col], colParts[col]...)
 	}
 
+	index := NewRangeIndex(totalRows)
+	if labels != nil {
+		index = NewIndex(labels, dfs[0].index.name)
+		for _, s := range seriesMap {
+			s.index = index.Copy()
+		}
+	}
 	return &DataFrame{
-		columns: cols,
+		columns: resultCols,
 		data:    seriesMap,
-		index:   NewRangeIndex(totalRows),
-		shape:   [2]int{totalRows, len(cols)},
+		index:   index,
+		shape:   [2]int{totalRows, len(resultCols)},
 	}
 }
+
+// concatColumnsSideBySide concatenates DataFrames along axis 1.
+func concatColumnsSideBySide(dfs []*DataFrame, opts ConcatOptions) (*DataFrame, error) {
+	rows := dfs[0].shape[0]
+	var cols []string
+	seriesMap := make(map[string]*Series)
+	for d, df := range dfs {
+		if df.shape[0] != rows {
+			return nil, lengthMismatch(rows, df.shape[0], "DataFrame %d has %d rows, expected %d", d, df.shape[0], rows)
+		}
+		for _, col := range df.columns {
+			name := col
+			if len(opts.Keys) > 0 {
+				name = opts.Keys[d] + "_" + col
+			}
+			if _, ok := seriesMap[name]; ok {
+				return nil, fmt.Errorf("duplicate column '%s' in concat result", name)
+			}
+			s := df.data[col].Copy()
+			s.SetName(name)
+			cols = append(cols, name)
+			seriesMap[name] = s
+		}
+	}
+
+	index := dfs[0].index.Copy()
+	if opts.IgnoreIndex {
+		index = NewRangeIndex(rows)
+	}
+	for _, s := range seriesMap {
+		s.index = index.Copy()
+	}
+	return &DataFrame{
+		columns: cols,
+		data:    seriesMap,
+		index:   index,
+		shape:   [2]int{rows, len(cols)},
+	}, nil
+}
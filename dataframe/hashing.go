@@ -0,0 +1,300 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FNV-1a parameters used for row hashes
+const (
+	fnvOffset uint64 = 14695981039346656037
+	fnvPrime  uint64 = 1099511628211
+)
+
+// Type tags written before each value so that equal bytes of different kinds
+// hash differently
+const (
+	hashTagNil byte = iota
+	hashTagNaN
+	hashTagInt
+	hashTagUint
+	hashTagFloat
+	hashTagString
+	hashTagBool
+	hashTagTime
+	hashTagOther
+)
+
+// HashRows returns a 64-bit hash of the values in columns for every row.
+// Rows with equal values get equal hashes. Hashing is type-aware in the same
+// way as GroupBy: all integer types of equal value hash alike, while 1, 1.0
+// and "1" hash differently, nil and NaN each have their own hash, and a nil
+// never hashes like a zero value. Different rows may still collide, so equal
+// hashes must be confirmed by comparing the values. Unknown columns are
+// ignored.
+func HashRows(df *DataFrame, columns []string) []uint64 {
+	cols := make([]*Series, 0, len(columns))
+	for _, col := range columns {
+		if s, ok := df.data[col]; ok {
+			cols = append(cols, s)
+		}
+	}
+	return hashSeriesRows(cols, df.shape[0], false)
+}
+
+// hashSeriesRows hashes the first n rows of cols. With join, integral floats
+// hash like the equal integer, matching joinKey.
+func hashSeriesRows(cols []*Series, n int, join bool) []uint64 {
+	return hashRowRange(cols, 0, n, join)
+}
+
+// hashRowRange hashes rows start through end-1 of cols, see hashSeriesRows.
+func hashRowRange(cols []*Series, start, end int, join bool) []uint64 {
+	hashes := make([]uint64, end-start)
+	for i := range hashes {
+		hashes[i] = fnvOffset
+	}
+	for _, s := range cols {
+		for i, v := range s.data[start:end] {
+			hashes[i] = hashValue(hashes[i], v, join)
+		}
+	}
+	return hashes
+}
+
+func hashByte(h uint64, b byte) uint64 {
+	return (h ^ uint64(b)) * fnvPrime
+}
+
+func hashUint64(h uint64, x uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h = hashByte(h, byte(x))
+		x >>= 8
+	}
+	return h
+}
+
+func hashString(h uint64, s string) uint64 {
+	h = hashUint64(h, uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h = hashByte(h, s[i])
+	}
+	return h
+}
+
+// hashValue mixes v into h without allocating for the common value types.
+func hashValue(h uint64, v interface{}, join bool) uint64 {
+	if i, ok := joinInt(v); ok {
+		return hashUint64(hashByte(h, hashTagInt), uint64(i))
+	}
+	switch val := v.(type) {
+	case nil:
+		return hashByte(h, hashTagNil)
+	case uint:
+		return hashUint64(hashByte(h, hashTagUint), uint64(val))
+	case uint64:
+		return hashUint64(hashByte(h, hashTagUint), val)
+	case float32:
+		return hashFloat(h, float64(val), join)
+	case float64:
+		return hashFloat(h, val, join)
+	case string:
+		return hashString(hashByte(h, hashTagString), val)
+	case bool:
+		b := byte(0)
+		if val {
+			b = 1
+		}
+		return hashByte(hashByte(h, hashTagBool), b)
+	case time.Time:
+		return hashUint64(hashByte(h, hashTagTime), uint64(val.UnixNano()))
+	}
+	return hashString(hashByte(h, hashTagOther), fmt.Sprintf("%T:%v", v, v))
+}
+
+func hashFloat(h uint64, f float64, join bool) uint64 {
+	if f != f {
+		return hashByte(h, hashTagNaN)
+	}
+	if join && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return hashUint64(hashByte(h, hashTagInt), uint64(int64(f)))
+	}
+	if f == 0 {
+		f = 0 // -0 and +0 are equal
+	}
+	return hashUint64(hashByte(h, hashTagFloat), math.Float64bits(f))
+}
+
+// valuesMatch reports whether a and b have the same hash key (join key with
+// join), which is the equality HashRows and hashSeriesRows hash by.
+func valuesMatch(a, b interface{}, join bool) bool {
+	if ia, ok := joinInt(a); ok {
+		if ib, ok := joinInt(b); ok {
+			return ia == ib
+		}
+	}
+	switch va := a.(type) {
+	case string:
+		vb, ok := b.(string)
+		return ok && va == vb
+	case float64:
+		if vb, ok := b.(float64); ok && va == va && vb == vb {
+			return va == vb
+		}
+	}
+	if join {
+		return joinKey(a) == joinKey(b)
+	}
+	return hashKey(a) == hashKey(b)
+}
+
+// rowsMatch reports whether row a of aCols and row b of bCols hold matching
+// values in every column.
+func rowsMatch(aCols []*Series, a int, bCols []*Series, b int, join bool) bool {
+	for k := range aCols {
+		if !valuesMatch(aCols[k].data[a], bCols[k].data[b], join) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowGroups assigns every row a group id so that rows with matching values
+// in cols share an id. Ids are numbered in order of first appearance, and
+// first holds the first row of each group.
+func rowGroups(cols []*Series, n int) (ids []int, first []int) {
+	_, ids, first = groupRowRange(cols, 0, n)
+	return ids, first
+}
+
+// groupRowRange is rowGroups for rows start through end-1. ids are indexed
+// from start, first holds row positions, and hashes are the row hashes.
+func groupRowRange(cols []*Series, start, end int) (hashes []uint64, ids []int, first []int) {
+	hashes = hashRowRange(cols, start, end, false)
+	buckets := make(map[uint64][]int, len(hashes))
+	ids = make([]int, len(hashes))
+	for i, h := range hashes {
+		id := -1
+		for _, g := range buckets[h] {
+			if rowsMatch(cols, first[g], cols, start+i, false) {
+				id = g
+				break
+			}
+		}
+		if id < 0 {
+			id = len(first)
+			first = append(first, start+i)
+			buckets[h] = append(buckets[h], id)
+		}
+		ids[i] = id
+	}
+	return hashes, ids, first
+}
+
+// rowGroupsParallel is rowGroups with the rows split into chunks that are
+// grouped concurrently by up to workers goroutines. The chunk groups are then
+// merged in chunk order, so ids are numbered exactly as rowGroups numbers
+// them.
+func rowGroupsParallel(cols []*Series, n, workers int) (ids []int, first []int) {
+	if workers <= 1 || n < 2*workers {
+		return rowGroups(cols, n)
+	}
+	chunkSize := (n + workers - 1) / workers
+	type chunkGroups struct {
+		start  int
+		hashes []uint64
+		ids    []int
+		first  []int
+	}
+	chunks := make([]chunkGroups, (n+chunkSize-1)/chunkSize)
+	runChunked(n, workers, func(start, end int) {
+		hashes, ids, first := groupRowRange(cols, start, end)
+		chunks[start/chunkSize] = chunkGroups{start, hashes, ids, first}
+	})
+
+	// Map the groups of each chunk to global ids in order of first appearance
+	buckets := make(map[uint64][]int)
+	globalIDs := make([][]int, len(chunks))
+	for c, chunk := range chunks {
+		globalIDs[c] = make([]int, len(chunk.first))
+		for local, row := range chunk.first {
+			h := chunk.hashes[row-chunk.start]
+			id := -1
+			for _, g := range buckets[h] {
+				if rowsMatch(cols, first[g], cols, row, false) {
+					id = g
+					break
+				}
+			}
+			if id < 0 {
+				id = len(first)
+				first = append(first, row)
+				buckets[h] = append(buckets[h], id)
+			}
+			globalIDs[c][local] = id
+		}
+	}
+
+	ids = make([]int, n)
+	runChunked(n, workers, func(start, end int) {
+		chunk := chunks[start/chunkSize]
+		mapping := globalIDs[start/chunkSize]
+		for i, local := range chunk.ids {
+			ids[chunk.start+i] = mapping[local]
+		}
+	})
+	return ids, first
+}
+
+// joinIndex is a hash index over the key columns of a join side.
+type joinIndex struct {
+	cols    []*Series
+	groups  [][]int          // rows of each distinct key
+	buckets map[uint64][]int // key hash -> group ids
+}
+
+// buildJoinIndex builds a hash index for join operations.
+// Rows whose key contains NA are left out since they never match.
+func buildJoinIndex(df *DataFrame, keys []string) *joinIndex {
+	cols := keySeries(df, keys)
+	hashes := hashSeriesRows(cols, df.shape[0], true)
+	idx := &joinIndex{cols: cols, buckets: make(map[uint64][]int)}
+	for i, h := range hashes {
+		if keyRowHasNA(cols, i) {
+			continue
+		}
+		found := false
+		for _, g := range idx.buckets[h] {
+			if rowsMatch(cols, idx.groups[g][0], cols, i, true) {
+				idx.groups[g] = append(idx.groups[g], i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			idx.buckets[h] = append(idx.buckets[h], len(idx.groups))
+			idx.groups = append(idx.groups, []int{i})
+		}
+	}
+	return idx
+}
+
+// lookup returns the indexed rows whose key matches row pos of cols, and
+// false when there are none or the key contains NA.
+func (idx *joinIndex) lookup(cols []*Series, pos int) ([]int, bool) {
+	if keyRowHasNA(cols, pos) {
+		return nil, false
+	}
+	h := fnvOffset
+	for _, s := range cols {
+		h = hashValue(h, s.data[pos], true)
+	}
+	for _, g := range idx.buckets[h] {
+		rows := idx.groups[g]
+		if rowsMatch(idx.cols, rows[0], cols, pos, true) {
+			return rows, true
+		}
+	}
+	return nil, false
+}
@@ -1,13 +1,38 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// Index represents the row/column index of a DataFrame or Series
+// Index represents the row/column index of a DataFrame or Series.
+// Integer labels are equal when their values are, whatever their Go type, so
+// the int64 values read from a CSV file find the int labels of a range
+// index. Floats, strings and integers never equal each other.
+// Labels are looked up through a hash map built on the first lookup; since
+// an Index is never modified in place, the slice returned by Labels must
+// not be modified either.
+//
+// A range index, see NewRangeIndex, stores only its first label, step and
+// length. Lookups and slices are computed arithmetically, and the labels are
+// materialized only when Labels or a set operation needs them.
 type Index struct {
-	labels []interface{} // Index labels
+	labels []interface{} // Index labels, materialized on demand for a range
 	name   string        // Index name
+
+	rng        *rangeLabels // labels of a range index, nil otherwise
+	labelsOnce sync.Once
+
+	locOnce  sync.Once
+	locs     map[interface{}]int // label -> first position, for comparable labels
+	hasDups  bool                // some label occurs more than once
+	scanOnly bool                // some label is not comparable, see buildLocs
 }
 
 // NewIndex creates a new Index from labels
@@ -18,20 +43,131 @@ func NewIndex(labels []interface{}, name string) *Index {
 	}
 }
 
-// NewRangeIndex creates a new integer range index
+// NewRangeIndex creates a new integer range index with the labels 0
+// through size-1. It takes constant memory whatever its size.
 func NewRangeIndex(size int) *Index {
-	labels := make([]interface{}, size)
-	for i := 0; i < size; i++ {
-		labels[i] = i
+	return &Index{rng: &rangeLabels{start: 0, step: 1, size: max(size, 0)}}
+}
+
+// rangeLabels describes the int labels start, start+step, ... of a range
+// index.
+type rangeLabels struct {
+	start, step, size int
+}
+
+// at returns the label at pos.
+func (r *rangeLabels) at(pos int) int {
+	return r.start + pos*r.step
+}
+
+// loc returns the position of label, or false if the range does not
+// contain it.
+func (r *rangeLabels) loc(label interface{}) (int, bool) {
+	v, ok := joinInt(label)
+	if !ok {
+		return 0, false
 	}
-	return &Index{
-		labels: labels,
-		name:   "",
+	offset := v - int64(r.start)
+	if offset%int64(r.step) != 0 {
+		return 0, false
+	}
+	pos := offset / int64(r.step)
+	if pos < 0 || pos >= int64(r.size) {
+		return 0, false
+	}
+	return int(pos), true
+}
+
+// values returns the labels of the index, materializing those of a range
+// index on the first call.
+func (idx *Index) values() []interface{} {
+	if idx.rng != nil {
+		idx.labelsOnce.Do(func() {
+			labels := make([]interface{}, idx.rng.size)
+			for i := range labels {
+				labels[i] = idx.rng.at(i)
+			}
+			idx.labels = labels
+		})
+	}
+	return idx.labels
+}
+
+// NewDatetimeIndex creates an index of times from start through end (if it
+// falls on a step) spaced by freq: a positive count followed by "s", "min",
+// "h", "d", "w" or "M", such as "1d" or "15min". Day, week and month steps
+// follow the calendar: month steps keep the day of start, or use the last
+// day of shorter months (Jan 31, Feb 29, Mar 31 ...).
+func NewDatetimeIndex(start, end time.Time, freq string, name string) (*Index, error) {
+	n, unit, err := parseFreq(freq)
+	if err != nil {
+		return nil, err
+	}
+	var labels []interface{}
+	for i := 0; ; i++ {
+		var t time.Time
+		switch unit {
+		case "M":
+			t = addMonths(start, i*n)
+		case "w":
+			t = start.AddDate(0, 0, 7*i*n)
+		case "d":
+			t = start.AddDate(0, 0, i*n)
+		default:
+			t = start.Add(time.Duration(i*n) * freqUnits[unit])
+		}
+		if t.After(end) {
+			break
+		}
+		labels = append(labels, t)
+	}
+	if labels == nil {
+		labels = []interface{}{}
 	}
+	return NewIndex(labels, name), nil
+}
+
+// addMonths adds months to t, moving to the last day of the target month
+// when it is shorter than the day of t.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// freqUnits holds the fixed-length units of a frequency string.
+var freqUnits = map[string]time.Duration{
+	"s":   time.Second,
+	"min": time.Minute,
+	"h":   time.Hour,
+}
+
+// parseFreq splits a frequency such as "15min" into its count and unit.
+func parseFreq(freq string) (int, string, error) {
+	digits := len(freq) - len(strings.TrimLeft(freq, "0123456789"))
+	unit := freq[digits:]
+	n := 1
+	if digits > 0 {
+		var err error
+		if n, err = strconv.Atoi(freq[:digits]); err != nil {
+			return 0, "", fmt.Errorf("invalid frequency '%s': %v", freq, err)
+		}
+	}
+	if _, fixed := freqUnits[unit]; !fixed && unit != "d" && unit != "w" && unit != "M" {
+		return 0, "", fmt.Errorf("invalid frequency '%s', expected a unit of s, min, h, d, w or M", freq)
+	}
+	if n <= 0 {
+		return 0, "", fmt.Errorf("invalid frequency '%s', the count must be positive", freq)
+	}
+	return n, unit, nil
 }
 
 // Len returns the length of the index
 func (idx *Index) Len() int {
+	if idx.rng != nil {
+		return idx.rng.size
+	}
 	return len(idx.labels)
 }
 
@@ -45,27 +181,110 @@ func (idx *Index) SetName(name string) {
 	idx.name = name
 }
 
-// Labels returns all labels in the index
+// Labels returns all labels in the index. The labels of a range index are
+// allocated on the first call.
 func (idx *Index) Labels() []interface{} {
-	return idx.labels
+	return idx.values()
 }
 
 // Get returns the label at the specified position
 func (idx *Index) Get(pos int) (interface{}, error) {
-	if pos < 0 || pos >= len(idx.labels) {
-		return nil, fmt.Errorf("index %d out of range [0, %d)", pos, len(idx.labels))
+	if pos < 0 || pos >= idx.Len() {
+		return nil, &IndexOutOfRangeError{Pos: pos, Len: idx.Len()}
+	}
+	if idx.rng != nil {
+		return idx.rng.at(pos), nil
 	}
 	return idx.labels[pos], nil
 }
 
-// GetLoc returns the position of the specified label
+// GetLoc returns the position of the specified label, the first one if the
+// label occurs more than once. Lookups take constant time after the first.
 func (idx *Index) GetLoc(label interface{}) (int, error) {
+	if idx.rng != nil {
+		if pos, ok := idx.rng.loc(label); ok {
+			return pos, nil
+		}
+		return -1, &LabelNotFoundError{Label: label}
+	}
+	idx.locOnce.Do(idx.buildLocs)
+	if !idx.scanOnly && isComparable(label) {
+		if pos, ok := idx.locs[labelKey(label)]; ok {
+			return pos, nil
+		}
+	} else {
+		for i, l := range idx.values() {
+			if labelsEqual(l, label) {
+				return i, nil
+			}
+		}
+	}
+	return -1, &LabelNotFoundError{Label: label}
+}
+
+// GetLocs returns all positions of the specified label in order, or nil if
+// the index does not contain it.
+func (idx *Index) GetLocs(label interface{}) []int {
+	if idx.rng == nil {
+		idx.locOnce.Do(idx.buildLocs)
+	}
+	if idx.rng != nil || !idx.hasDups && !idx.scanOnly {
+		if pos, err := idx.GetLoc(label); err == nil {
+			return []int{pos}
+		}
+		return nil
+	}
+	var positions []int
+	for i, l := range idx.values() {
+		if labelsEqual(l, label) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// buildLocs builds the label lookup map. Labels that cannot be map keys,
+// such as slices, make all lookups fall back to a linear scan.
+func (idx *Index) buildLocs() {
+	locs := make(map[interface{}]int, len(idx.labels))
 	for i, l := range idx.labels {
-		if l == label {
-			return i, nil
+		if !isComparable(l) {
+			idx.scanOnly = true
+			return
+		}
+		key := labelKey(l)
+		if _, ok := locs[key]; ok {
+			idx.hasDups = true
+			continue
 		}
+		locs[key] = i
+	}
+	idx.locs = locs
+}
+
+// isComparable reports whether v can be used as a map key.
+func isComparable(v interface{}) bool {
+	return v == nil || reflect.TypeOf(v).Comparable()
+}
+
+// labelKey returns the lookup key of a label. Integer labels of any type
+// are keyed by their value, so int(1), int64(1) and uint8(1) are the same
+// label, while 1.0 and "1" are not. Times are keyed by their instant, so
+// equal times in different locations match.
+func labelKey(v interface{}) interface{} {
+	if i, ok := joinInt(v); ok {
+		return i
 	}
-	return -1, fmt.Errorf("label %v not found in index", label)
+	return hashKey(v)
+}
+
+// labelsEqual reports whether two labels are equal without panicking on
+// labels that are not comparable.
+func labelsEqual(a, b interface{}) bool {
+	if !isComparable(a) || !isComparable(b) {
+		return false
+	}
+	return labelKey(a) == labelKey(b)
 }
 
 // Contains checks if the index contains the specified label
@@ -74,13 +293,20 @@ func (idx *Index) Contains(label interface{}) bool {
 	return err == nil
 }
 
-// Slice returns a new index with elements from start to end
+// Slice returns a new index with elements from start to end. The slice of a
+// range index is a range index.
 func (idx *Index) Slice(start, end int) *Index {
 	if start < 0 {
 		start = 0
 	}
-	if end > len(idx.labels) {
-		end = len(idx.labels)
+	if end > idx.Len() {
+		end = idx.Len()
+	}
+	if idx.rng != nil {
+		return &Index{
+			name: idx.name,
+			rng:  &rangeLabels{start: idx.rng.at(start), step: idx.rng.step, size: max(end-start, 0)},
+		}
 	}
 	newLabels := make([]interface{}, end-start)
 	copy(newLabels, idx.labels[start:end])
@@ -90,19 +316,121 @@ func (idx *Index) Slice(start, end int) *Index {
 	}
 }
 
-// Append adds a new label to the index
+// Append adds a new label to the index. Appending the next label of a range
+// index keeps it a range; any other label materializes the labels.
 func (idx *Index) Append(label interface{}) *Index {
-	newLabels := make([]interface{}, len(idx.labels)+1)
-	copy(newLabels, idx.labels)
-	newLabels[len(idx.labels)] = label
+	if r := idx.rng; r != nil {
+		if next, ok := joinInt(label); ok && next == int64(r.at(r.size)) {
+			return &Index{name: idx.name, rng: &rangeLabels{start: r.start, step: r.step, size: r.size + 1}}
+		}
+	}
+	labels := idx.values()
+	newLabels := make([]interface{}, len(labels)+1)
+	copy(newLabels, labels)
+	newLabels[len(labels)] = label
 	return &Index{
 		labels: newLabels,
 		name:   idx.name,
 	}
 }
 
+// Insert returns a new index with label inserted before position pos. pos
+// may equal Len to insert at the end.
+func (idx *Index) Insert(pos int, label interface{}) (*Index, error) {
+	n := idx.Len()
+	if pos < 0 || pos > n {
+		return nil, &IndexOutOfRangeError{Pos: pos, Len: n + 1, msg: fmt.Sprintf("insert position %d out of range [0, %d]", pos, n)}
+	}
+	if pos == n {
+		return idx.Append(label), nil
+	}
+	labels := idx.values()
+	newLabels := make([]interface{}, 0, n+1)
+	newLabels = append(newLabels, labels[:pos]...)
+	newLabels = append(newLabels, label)
+	newLabels = append(newLabels, labels[pos:]...)
+	return NewIndex(newLabels, idx.name), nil
+}
+
+// Delete returns a new index without the label at position pos.
+func (idx *Index) Delete(pos int) (*Index, error) {
+	n := idx.Len()
+	if pos < 0 || pos >= n {
+		return nil, &IndexOutOfRangeError{Pos: pos, Len: n}
+	}
+	if idx.rng != nil && pos == 0 {
+		return idx.Slice(1, n), nil
+	}
+	if idx.rng != nil && pos == n-1 {
+		return idx.Slice(0, n-1), nil
+	}
+	labels := idx.values()
+	newLabels := make([]interface{}, 0, n-1)
+	newLabels = append(newLabels, labels[:pos]...)
+	newLabels = append(newLabels, labels[pos+1:]...)
+	return NewIndex(newLabels, idx.name), nil
+}
+
+// Drop returns a new index without every occurrence of the given labels.
+// A label that is not in the index is an error.
+func (idx *Index) Drop(labels []interface{}) (*Index, error) {
+	keep, err := idx.keepPositions(labels)
+	if err != nil {
+		return nil, err
+	}
+	return NewIndex(extractLabels(idx, keep), idx.name), nil
+}
+
+// keepPositions returns the positions of the labels that are not in drop.
+func (idx *Index) keepPositions(drop []interface{}) ([]int, error) {
+	dropped := make([]bool, idx.Len())
+	count := 0
+	for _, label := range drop {
+		positions := idx.GetLocs(label)
+		if positions == nil {
+			return nil, &LabelNotFoundError{Label: label}
+		}
+		for _, pos := range positions {
+			if !dropped[pos] {
+				dropped[pos] = true
+				count++
+			}
+		}
+	}
+	keep := make([]int, 0, len(dropped)-count)
+	for pos, d := range dropped {
+		if !d {
+			keep = append(keep, pos)
+		}
+	}
+	return keep, nil
+}
+
+// Rename returns a new index with the labels replaced according to mapping.
+// Mapping keys match labels like GetLoc does; labels without an entry are
+// kept.
+func (idx *Index) Rename(mapping map[interface{}]interface{}) *Index {
+	renames := make(map[interface{}]interface{}, len(mapping))
+	for from, to := range mapping {
+		renames[labelKey(from)] = to
+	}
+	labels := idx.values()
+	newLabels := make([]interface{}, len(labels))
+	for i, l := range labels {
+		newLabels[i] = l
+		if to, ok := renames[labelKey(l)]; ok {
+			newLabels[i] = to
+		}
+	}
+	return NewIndex(newLabels, idx.name)
+}
+
 // Copy creates a copy of the index
 func (idx *Index) Copy() *Index {
+	if idx.rng != nil {
+		r := *idx.rng
+		return &Index{name: idx.name, rng: &r}
+	}
 	newLabels := make([]interface{}, len(idx.labels))
 	copy(newLabels, idx.labels)
 	return &Index{
@@ -111,95 +439,339 @@ func (idx *Index) Copy() *Index {
 	}
 }
 
+// Min returns the smallest label, ordering labels like SortBy orders values
+// and skipping NA labels, or nil for an index without labels.
+func (idx *Index) Min() interface{} {
+	return idx.extreme(-1)
+}
+
+// Max returns the largest label, see Min.
+func (idx *Index) Max() interface{} {
+	return idx.extreme(1)
+}
+
+func (idx *Index) extreme(sign int) interface{} {
+	if r := idx.rng; r != nil {
+		if r.size == 0 {
+			return nil
+		}
+		if r.step*sign > 0 {
+			return r.at(r.size - 1)
+		}
+		return r.start
+	}
+	var best interface{}
+	for _, l := range idx.values() {
+		if l == nil || IsNA(l) {
+			continue
+		}
+		if best == nil || compareValues(l, best)*sign > 0 {
+			best = l
+		}
+	}
+	return best
+}
+
+// Shift returns a new index with every time label moved by d. Nil labels
+// are kept; any other label that is not a time.Time is an error.
+func (idx *Index) Shift(d time.Duration) (*Index, error) {
+	labels := make([]interface{}, idx.Len())
+	for i, l := range idx.values() {
+		switch t := l.(type) {
+		case nil:
+		case time.Time:
+			labels[i] = t.Add(d)
+		default:
+			return nil, fmt.Errorf("cannot shift label %v of type %T at position %d, expected time.Time", l, l, i)
+		}
+	}
+	return NewIndex(labels, idx.name), nil
+}
+
+// IsMonotonicIncreasing reports whether every label is greater than or
+// equal to the one before it. Labels must all be numbers, all strings or all
+// times; an index with NA labels or labels of mixed kinds is not monotonic.
+// An empty index is monotonic.
+func (idx *Index) IsMonotonicIncreasing() bool {
+	return idx.isMonotonic(1)
+}
+
+// IsMonotonicDecreasing reports whether every label is less than or equal to
+// the one before it, see IsMonotonicIncreasing.
+func (idx *Index) IsMonotonicDecreasing() bool {
+	return idx.isMonotonic(-1)
+}
+
+func (idx *Index) isMonotonic(sign int) bool {
+	if r := idx.rng; r != nil {
+		return r.size <= 1 || r.step*sign > 0
+	}
+	labels := idx.labels
+	for i := 1; i < len(labels); i++ {
+		c, ok := compareOrdered(labels[i-1], labels[i])
+		if !ok || c*sign > 0 {
+			return false
+		}
+	}
+	if len(labels) == 1 {
+		_, ok := compareOrdered(labels[0], labels[0])
+		return ok
+	}
+	return true
+}
+
+var (
+	// ErrUnsortedIndex is returned by Asof when the index is not
+	// monotonically increasing.
+	ErrUnsortedIndex = errors.New("index is not sorted in increasing order")
+	// ErrAsofBeforeStart is returned by Asof when the label is less than the
+	// first index label, so no label is at or before it.
+	ErrAsofBeforeStart = errors.New("label is before the first index label")
+)
+
+// Asof returns the position of the last label that is less than or equal to
+// label, using a binary search. The index must be monotonically increasing,
+// which is checked unless opts sets AssumeSorted; labels are ordered like
+// SearchSorted orders values. A label before the first index label returns
+// ErrAsofBeforeStart, and one that cannot be ordered against the index
+// labels ErrIncomparableValue.
+func (idx *Index) Asof(label interface{}, opts ...SearchSortedOptions) (int, error) {
+	if (len(opts) == 0 || !opts[0].AssumeSorted) && !idx.IsMonotonicIncreasing() {
+		return -1, ErrUnsortedIndex
+	}
+	var cmpErr error
+	pos := sort.Search(idx.Len(), func(i int) bool {
+		l, _ := idx.Get(i)
+		c, ok := compareOrdered(l, label)
+		if !ok {
+			cmpErr = fmt.Errorf("%v (%T): %w", label, label, ErrIncomparableValue)
+			return true
+		}
+		return c > 0
+	})
+	if cmpErr != nil {
+		return -1, cmpErr
+	}
+	if pos == 0 {
+		return -1, fmt.Errorf("%v: %w", label, ErrAsofBeforeStart)
+	}
+	return pos - 1, nil
+}
+
+// IsUnique reports whether no label occurs more than once.
+func (idx *Index) IsUnique() bool {
+	if idx.rng != nil {
+		return true
+	}
+	seen := make(map[interface{}]struct{}, len(idx.labels))
+	for _, l := range idx.labels {
+		key := labelKey(l)
+		if _, ok := seen[key]; ok {
+			return false
+		}
+		seen[key] = struct{}{}
+	}
+	return true
+}
+
+// Duplicated marks the labels that repeat an earlier label. keep selects
+// which occurrence is left unmarked: "first" (default), "last", or "none" to
+// mark every occurrence of a repeated label. Labels are matched like GetLoc
+// matches them, and NA labels are duplicates of each other.
+func (idx *Index) Duplicated(keep string) []bool {
+	n := idx.Len()
+	dup := make([]bool, n)
+	if idx.rng != nil {
+		return dup
+	}
+	switch keep {
+	case "none":
+		counts := make(map[interface{}]int, n)
+		keys := make([]interface{}, n)
+		for i, l := range idx.labels {
+			keys[i] = labelKey(l)
+			counts[keys[i]]++
+		}
+		for i, key := range keys {
+			dup[i] = counts[key] > 1
+		}
+	case "last":
+		seen := make(map[interface{}]struct{}, n)
+		for i := n - 1; i >= 0; i-- {
+			key := labelKey(idx.labels[i])
+			_, dup[i] = seen[key]
+			seen[key] = struct{}{}
+		}
+	default:
+		seen := make(map[interface{}]struct{}, n)
+		for i, l := range idx.labels {
+			key := labelKey(l)
+			_, dup[i] = seen[key]
+			seen[key] = struct{}{}
+		}
+	}
+	return dup
+}
+
 // Reset resets the index to default integer range
 func (idx *Index) Reset() *Index {
-	return NewRangeIndex(len(idx.labels))
+	return NewRangeIndex(idx.Len())
 }
 
 // ToStringSlice converts index labels to string slice
 func (idx *Index) ToStringSlice() []string {
-	result := make([]string, len(idx.labels))
+	result := make([]string, idx.Len())
+	if idx.rng != nil {
+		for i := range result {
+			result[i] = strconv.Itoa(idx.rng.at(i))
+		}
+		return result
+	}
 	for i, label := range idx.labels {
 		result[i] = fmt.Sprintf("%v", label)
 	}
 	return result
 }
 
-// Equals checks if two indexes are equal
+// Equals checks if two indexes have equal labels in the same order. Labels
+// are compared like GetLoc compares them, so an index of int labels equals an
+// index of the same int64 labels.
 func (idx *Index) Equals(other *Index) bool {
 	if idx.Len() != other.Len() {
 		return false
 	}
-	for i, label := range idx.labels {
-		if label != other.labels[i] {
+	if a, b := idx.rng, other.rng; a != nil && b != nil {
+		return a.size == 0 || a.start == b.start && (a.size == 1 || a.step == b.step)
+	}
+	for i, label := range idx.values() {
+		if labelKey(label) != labelKey(other.values()[i]) {
 			return false
 		}
 	}
 	return true
 }
 
-// Union returns the union of two indexes
-func (idx *Index) Union(other *Index) *Index {
-	seen := make(map[interface{}]bool)
-	var labels []interface{}
+// IndexSetOptions defines options for the Index set operations.
+type IndexSetOptions struct {
+	Sort bool // sort the result labels like SortBy sorts values, NA labels last
+}
 
-	for _, label := range idx.labels {
-		if !seen[label] {
-			seen[label] = true
-			labels = append(labels, label)
+// Union returns the labels of idx followed by the labels of other that are
+// not in idx, each label once, in order of first appearance. Labels are
+// matched like GetLoc matches them; labels that cannot be map keys, such as
+// slices, never match and are all kept.
+func (idx *Index) Union(other *Index, opts ...IndexSetOptions) *Index {
+	seen := newLabelSet(nil)
+	var labels []interface{}
+	for _, src := range [][]interface{}{idx.values(), other.values()} {
+		for _, label := range src {
+			if !seen.has(label) {
+				seen.add(label)
+				labels = append(labels, label)
+			}
 		}
 	}
-	for _, label := range other.labels {
-		if !seen[label] {
-			seen[label] = true
+	return idx.setResult(labels, opts)
+}
+
+// Intersection returns the labels of idx that are also in other, each label
+// once, in the order of idx. Labels are matched as in Union.
+func (idx *Index) Intersection(other *Index, opts ...IndexSetOptions) *Index {
+	otherSet := newLabelSet(other.values())
+	seen := newLabelSet(nil)
+	var labels []interface{}
+	for _, label := range idx.values() {
+		if otherSet.has(label) && !seen.has(label) {
+			seen.add(label)
 			labels = append(labels, label)
 		}
 	}
-
-	return &Index{
-		labels: labels,
-		name:   idx.name,
-	}
+	return idx.setResult(labels, opts)
 }
 
-// Intersection returns the intersection of two indexes
-func (idx *Index) Intersection(other *Index) *Index {
-	otherSet := make(map[interface{}]bool)
-	for _, label := range other.labels {
-		otherSet[label] = true
+// Difference returns the labels of idx that are not in other, in the order
+// of idx. Repeated labels are kept. Labels are matched as in Union.
+func (idx *Index) Difference(other *Index, opts ...IndexSetOptions) *Index {
+	otherSet := newLabelSet(other.values())
+	var labels []interface{}
+	for _, label := range idx.values() {
+		if !otherSet.has(label) {
+			labels = append(labels, label)
+		}
 	}
+	return idx.setResult(labels, opts)
+}
 
+// SymmetricDifference returns the labels that are in exactly one of idx and
+// other, each label once: those of idx in its order, then those of other.
+// Labels are matched as in Union.
+func (idx *Index) SymmetricDifference(other *Index, opts ...IndexSetOptions) *Index {
+	left, right := newLabelSet(idx.values()), newLabelSet(other.values())
+	seen := newLabelSet(nil)
 	var labels []interface{}
-	seen := make(map[interface{}]bool)
-	for _, label := range idx.labels {
-		if otherSet[label] && !seen[label] {
-			seen[label] = true
-			labels = append(labels, label)
+	for _, side := range []struct {
+		labels []interface{}
+		other  *labelSet
+	}{{idx.values(), right}, {other.values(), left}} {
+		for _, label := range side.labels {
+			if !side.other.has(label) && !seen.has(label) {
+				seen.add(label)
+				labels = append(labels, label)
+			}
 		}
 	}
+	return idx.setResult(labels, opts)
+}
 
+// setResult returns the result of a set operation named like idx, sorted if
+// requested.
+func (idx *Index) setResult(labels []interface{}, opts []IndexSetOptions) *Index {
+	if len(opts) > 0 && opts[0].Sort {
+		sortLabels(labels)
+	}
 	return &Index{
 		labels: labels,
 		name:   idx.name,
 	}
 }
 
-// Difference returns the difference of two indexes (elements in idx but not in other)
-func (idx *Index) Difference(other *Index) *Index {
-	otherSet := make(map[interface{}]bool)
-	for _, label := range other.labels {
-		otherSet[label] = true
+// sortLabels sorts labels in place like SortBy sorts values, with NA labels
+// last.
+func sortLabels(labels []interface{}) {
+	sort.SliceStable(labels, func(i, j int) bool {
+		a, b := labels[i], labels[j]
+		naA, naB := a == nil || IsNA(a), b == nil || IsNA(b)
+		if naA || naB {
+			return !naA
+		}
+		return compareValues(a, b) < 0
+	})
+}
+
+// labelSet is a set of labels matched like GetLoc matches them. Labels that
+// cannot be map keys are never members.
+type labelSet struct {
+	keys map[interface{}]struct{}
+}
+
+func newLabelSet(labels []interface{}) *labelSet {
+	set := &labelSet{keys: make(map[interface{}]struct{}, len(labels))}
+	for _, l := range labels {
+		set.add(l)
 	}
+	return set
+}
 
-	var labels []interface{}
-	for _, label := range idx.labels {
-		if !otherSet[label] {
-			labels = append(labels, label)
-		}
+func (s *labelSet) add(label interface{}) {
+	if isComparable(label) {
+		s.keys[labelKey(label)] = struct{}{}
 	}
+}
 
-	return &Index{
-		labels: labels,
-		name:   idx.name,
+func (s *labelSet) has(label interface{}) bool {
+	if !isComparable(label) {
+		return false
 	}
+	_, ok := s.keys[labelKey(label)]
+	return ok
 }
@@ -0,0 +1,123 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// interfaceSize is the size of an interface{} slot in a Series: a type
+// pointer and a data pointer.
+const interfaceSize = 16
+
+// DTypes returns the dtype of every column.
+func (df *DataFrame) DTypes() map[string]DType {
+	dtypes := make(map[string]DType, len(df.columns))
+	for _, col := range df.columns {
+		dtypes[col] = df.data[col].dtype
+	}
+	return dtypes
+}
+
+// MemoryUsage returns the estimated memory used by the values of each column
+// in bytes. Every value is stored as an interface{}, so the estimate is the
+// 16-byte interface slot plus the size of the boxed value (8 bytes for an
+// int64 or float64, the 16-byte header for a string, nothing for nil).
+// Smaller values such as an int32 still take the smallest 8-byte allocation.
+// With deep, the bytes of string contents are added as well. The index is
+// not included.
+func (df *DataFrame) MemoryUsage(deep bool) map[string]int64 {
+	usage := make(map[string]int64, len(df.columns))
+	for _, col := range df.columns {
+		usage[col] = seriesMemory(df.data[col], deep)
+	}
+	return usage
+}
+
+// seriesMemory returns the estimated memory used by the values of s.
+func seriesMemory(s *Series, deep bool) int64 {
+	total := int64(len(s.data)) * interfaceSize
+	for _, v := range s.data {
+		if v == nil {
+			continue
+		}
+		total += boxedSize(v)
+		if str, ok := v.(string); ok && deep {
+			total += int64(len(str))
+		}
+	}
+	return total
+}
+
+// boxedSize returns the bytes allocated to box v in an interface{}. Values
+// of 2 to 7 bytes are rounded up to the smallest 8-byte allocation.
+func boxedSize(v interface{}) int64 {
+	size := int64(reflect.TypeOf(v).Size())
+	if size > 1 && size < 8 {
+		return 8
+	}
+	return size
+}
+
+// Info returns a summary of the DataFrame: the index range and, per column,
+// the non-null count, dtype and estimated memory (MemoryUsage with deep),
+// followed by dtype counts and the total memory including the share taken by
+// interface{} slots. The layout only depends on the data, so it is suitable
+// for golden-file tests.
+func (df *DataFrame) Info() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("DataFrame: rows=%d, cols=%d\n", df.shape[0], df.shape[1]))
+	if n := df.index.Len(); n > 0 {
+		first, _ := df.index.Get(0)
+		last, _ := df.index.Get(n - 1)
+		sb.WriteString(fmt.Sprintf("Index: %d entries, %v to %v\n", n, first, last))
+	} else {
+		sb.WriteString("Index: 0 entries\n")
+	}
+
+	usage := df.MemoryUsage(true)
+	dtypeCounts := make(map[string]int)
+	var total int64
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tColumn\tNon-Null\tDtype\tMemory")
+	for i, col := range df.columns {
+		s := df.data[col]
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\n", i, col, s.Count(), s.dtype, formatBytes(usage[col]))
+		dtypeCounts[s.dtype.String()]++
+		total += usage[col]
+	}
+	tw.Flush()
+
+	names := make([]string, 0, len(dtypeCounts))
+	for name := range dtypeCounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s(%d)", name, dtypeCounts[name])
+	}
+	sb.WriteString(fmt.Sprintf("dtypes: %s\n", strings.Join(parts, ", ")))
+
+	slots := int64(df.shape[0]) * int64(df.shape[1]) * interfaceSize
+	sb.WriteString(fmt.Sprintf("memory usage: %s (interface{} slots: %s)\n", formatBytes(total), formatBytes(slots)))
+	return sb.String()
+}
+
+// formatBytes formats a byte count with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
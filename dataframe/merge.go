@@ -2,6 +2,9 @@ package dataframe
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"time"
 )
 
 // JoinType defines the type of join operation
@@ -16,6 +19,12 @@ const (
 	RightJoin
 	// OuterJoin returns all rows from both DataFrames
 	OuterJoin
+	// CrossJoin returns the Cartesian product of both DataFrames
+	CrossJoin
+	// SemiJoin returns the left rows that have a match in the right DataFrame
+	SemiJoin
+	// AntiJoin returns the left rows that have no match in the right DataFrame
+	AntiJoin
 )
 
 // String returns the string representation of JoinType
@@ -29,6 +38,12 @@ func (j JoinType) String() string {
 		return "right"
 	case OuterJoin:
 		return "outer"
+	case CrossJoin:
+		return "cross"
+	case SemiJoin:
+		return "semi"
+	case AntiJoin:
+		return "anti"
 	default:
 		return "unknown"
 	}
@@ -42,6 +57,11 @@ type MergeOptions struct {
 	RightOn     []string // columns to join on from right DataFrame
 	Suffixes    [2]string // suffixes to use for overlapping columns
 	Indicator   bool      // add _merge column indicating source
+	Validate    string    // "1:1", "1:m", "m:1" or "m:m" checks key uniqueness ("" skips the check)
+	Strategy    string    // "hash" or "sort"; empty picks "sort" when both sides are sorted on the keys
+	KeepRightKeys bool    // keep RightOn columns with their own values instead of coalescing them into the LeftOn columns
+	KeepIndex   bool      // label result rows with the index labels of their left rows, or right rows on right-only rows, instead of a new RangeIndex
+	Parallel    *ParallelOptions // run a hash join in parallel with these options (nil: automatically for large frames)
 }
 
 // DefaultMergeOptions returns default merge options
@@ -52,18 +72,87 @@ func DefaultMergeOptions() MergeOptions {
 	}
 }
 
-// Merge merges two DataFrames based on common columns or specified keys
+// Merge merges two DataFrames based on common columns or specified keys.
+// Result rows combine rows of both sides, so the result has a new RangeIndex
+// unless KeepIndex is set. Semi and anti joins select left rows and always
+// keep their labels.
 func Merge(left, right *DataFrame, opts MergeOptions) (*DataFrame, error) {
 	if left == nil || right == nil {
 		return nil, fmt.Errorf("both DataFrames must be non-nil")
 	}
 
+	if opts.How == CrossJoin {
+		if len(opts.On) > 0 || len(opts.LeftOn) > 0 || len(opts.RightOn) > 0 {
+			return nil, fmt.Errorf("cross join does not accept join keys")
+		}
+		if opts.Validate != "" && opts.Validate != "m:m" {
+			return nil, fmt.Errorf("cross join cannot be validated as '%s'", opts.Validate)
+		}
+		return crossJoin(left, right, opts)
+	}
+	if (opts.How == SemiJoin || opts.How == AntiJoin) && opts.Indicator {
+		return nil, fmt.Errorf("indicator is not supported for %s join", opts.How)
+	}
+
 	// Determine join keys
 	leftKeys, rightKeys, err := resolveJoinKeys(left, right, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := validateMergeKeys(left, right, leftKeys, rightKeys, opts.Validate); err != nil {
+		return nil, err
+	}
+
+	if opts.How < InnerJoin || opts.How > AntiJoin {
+		return nil, fmt.Errorf("unknown join type: %v", opts.How)
+	}
+	result, err := joinOnKeys(left, right, leftKeys, rightKeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.How == RightJoin || opts.How == OuterJoin {
+		promoteKeyColumns(result, left, right, leftKeys, rightKeys)
+	}
+	return result, nil
+}
+
+// promoteKeyColumns gives the key columns of a right or outer join result,
+// which hold values of both left and right keys, the PromoteDTypes
+// promotion of the key dtypes.
+func promoteKeyColumns(result, left, right *DataFrame, leftKeys, rightKeys []string) {
+	for i, key := range leftKeys {
+		s, ok := result.data[key]
+		if !ok {
+			continue
+		}
+		promoted := combinedSeries(key, s.data, left.data[key], right.data[rightKeys[i]])
+		promoted.index = s.index
+		result.data[key] = promoted
+	}
+}
+
+// joinOnKeys joins left and right on the resolved keys with the strategy
+// and parallelism opts select.
+func joinOnKeys(left, right *DataFrame, leftKeys, rightKeys []string, opts MergeOptions) (*DataFrame, error) {
+	sortMerge, err := useSortMerge(left, right, leftKeys, rightKeys, opts.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	if sortMerge {
+		return sortMergeJoin(left, right, leftKeys, rightKeys, opts)
+	}
+
+	if opts.How != SemiJoin && opts.How != AntiJoin {
+		probeRows := left.shape[0]
+		if opts.How == RightJoin {
+			probeRows = right.shape[0]
+		}
+		if workers, ok := mergeWorkers(opts, probeRows); ok {
+			return parallelHashJoin(left, right, leftKeys, rightKeys, opts, workers)
+		}
+	}
+
 	// Build index for right DataFrame
 	rightIndex := buildJoinIndex(right, rightKeys)
 
@@ -77,6 +166,8 @@ func Merge(left, right *DataFrame, opts MergeOptions) (*DataFrame, error) {
 		return rightJoin(left, right, leftKeys, rightKeys, rightIndex, opts)
 	case OuterJoin:
 		return outerJoin(left, right, leftKeys, rightKeys, rightIndex, opts)
+	case SemiJoin, AntiJoin:
+		return filterJoin(left, leftKeys, rightIndex, opts.How == SemiJoin), nil
 	default:
 		return nil, fmt.Errorf("unknown join type: %v", opts.How)
 	}
@@ -90,10 +181,10 @@ func resolveJoinKeys(left, right *DataFrame, opts MergeOptions) ([]string, []str
 		// Same column names in both DataFrames
 		for _, col := range opts.On {
 			if _, ok := left.data[col]; !ok {
-				return nil, nil, fmt.Errorf("column '%s' not found in left DataFrame", col)
+				return nil, nil, fmt.Errorf("%w in left DataFrame", &ColumnNotFoundError{Column: col})
 			}
 			if _, ok := right.data[col]; !ok {
-				return nil, nil, fmt.Errorf("column '%s' not found in right DataFrame", col)
+				return nil, nil, fmt.Errorf("%w in right DataFrame", &ColumnNotFoundError{Column: col})
 			}
 		}
 		leftKeys = opts.On
@@ -101,16 +192,16 @@ func resolveJoinKeys(left, right *DataFrame, opts MergeOptions) ([]string, []str
 	} else if len(opts.LeftOn) > 0 && len(opts.RightOn) > 0 {
 		// Different column names
 		if len(opts.LeftOn) != len(opts.RightOn) {
-			return nil, nil, fmt.Errorf("LeftOn and RightOn must have same length")
+			return nil, nil, lengthMismatch(len(opts.LeftOn), len(opts.RightOn), "LeftOn and RightOn must have same length")
 		}
 		for _, col := range opts.LeftOn {
 			if _, ok := left.data[col]; !ok {
-				return nil, nil, fmt.Errorf("column '%s' not found in left DataFrame", col)
+				return nil, nil, fmt.Errorf("%w in left DataFrame", &ColumnNotFoundError{Column: col})
 			}
 		}
 		for _, col := range opts.RightOn {
 			if _, ok := right.data[col]; !ok {
-				return nil, nil, fmt.Errorf("column '%s' not found in right DataFrame", col)
+				return nil, nil, fmt.Errorf("%w in right DataFrame", &ColumnNotFoundError{Column: col})
 			}
 		}
 		leftKeys = opts.LeftOn
@@ -128,6 +219,78 @@ func resolveJoinKeys(left, right *DataFrame, opts MergeOptions) ([]string, []str
 	return leftKeys, rightKeys, nil
 }
 
+// maxDuplicateSample is the number of duplicated keys reported by merge validation
+const maxDuplicateSample = 5
+
+// validateMergeKeys checks the uniqueness of the join keys required by validate
+func validateMergeKeys(left, right *DataFrame, leftKeys, rightKeys []string, validate string) error {
+	var leftUnique, rightUnique bool
+	switch validate {
+	case "", "m:m":
+		return nil
+	case "1:1":
+		leftUnique, rightUnique = true, true
+	case "1:m":
+		leftUnique = true
+	case "m:1":
+		rightUnique = true
+	default:
+		return fmt.Errorf("invalid validate option '%s', expected \"1:1\", \"1:m\", \"m:1\" or \"m:m\"", validate)
+	}
+	if leftUnique {
+		if dups := duplicateKeys(left, leftKeys, maxDuplicateSample); len(dups) > 0 {
+			return fmt.Errorf("merge keys are not unique in left DataFrame (validate '%s'): duplicated %s", validate, strings.Join(dups, ", "))
+		}
+	}
+	if rightUnique {
+		if dups := duplicateKeys(right, rightKeys, maxDuplicateSample); len(dups) > 0 {
+			return fmt.Errorf("merge keys are not unique in right DataFrame (validate '%s'): duplicated %s", validate, strings.Join(dups, ", "))
+		}
+	}
+	return nil
+}
+
+// HasUniqueKey reports whether the given columns identify each row uniquely.
+// When they do not, it also returns the duplicated key values formatted as
+// strings, in order of first appearance. A column that does not exist
+// returns false and no keys.
+func (df *DataFrame) HasUniqueKey(columns ...string) (bool, []string) {
+	for _, col := range columns {
+		if _, ok := df.data[col]; !ok {
+			return false, nil
+		}
+	}
+	dups := duplicateKeys(df, columns, -1)
+	return len(dups) == 0, dups
+}
+
+// duplicateKeys returns up to limit (all if limit < 0) key values that occur
+// more than once in the given columns, formatted for error messages.
+func duplicateKeys(df *DataFrame, keys []string, limit int) []string {
+	ids, first := rowGroups(keySeries(df, keys), df.shape[0])
+	counts := make([]int, len(first))
+	var dups []string
+	for _, id := range ids {
+		counts[id]++
+		if counts[id] != 2 {
+			continue
+		}
+		keyVals := make([]interface{}, len(keys))
+		for j, col := range keys {
+			keyVals[j] = df.data[col].data[first[id]]
+		}
+		if len(keyVals) == 1 {
+			dups = append(dups, fmt.Sprintf("%v", keyVals[0]))
+		} else {
+			dups = append(dups, fmt.Sprintf("%v", keyVals))
+		}
+		if limit >= 0 && len(dups) >= limit {
+			break
+		}
+	}
+	return dups
+}
+
 // findCommonColumns finds columns present in both DataFrames
 func findCommonColumns(left, right *DataFrame) []string {
 	var common []string
@@ -139,41 +302,28 @@ func findCommonColumns(left, right *DataFrame) []string {
 	return common
 }
 
-// buildJoinIndex builds a hash index for join operations
-func buildJoinIndex(df *DataFrame, keys []string) map[string][]int {
-	index := make(map[string][]int)
-	for i := 0; i < df.shape[0]; i++ {
-		key := buildRowKey(df, keys, i)
-		index[key] = append(index[key], i)
-	}
-	return index
-}
-
-// buildRowKey creates a unique string key for a row based on specified columns
-func buildRowKey(df *DataFrame, keys []string, rowIdx int) string {
-	key := ""
-	for i, col := range keys {
-		s := df.data[col]
-		val, _ := s.Get(rowIdx)
-		if i > 0 {
-			key += "\x00"
-		}
-		key += fmt.Sprintf("%v", val)
+// joinKey returns the hash key of a join value. Integral floats share the
+// key of the equal integer.
+func joinKey(v interface{}) interface{} {
+	k := hashKey(v)
+	if f, ok := k.(float64); ok && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f)
 	}
-	return key
+	return k
 }
 
 // innerJoin performs an inner join
-func innerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex map[string][]int, opts MergeOptions) (*DataFrame, error) {
+func innerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex *joinIndex, opts MergeOptions) (*DataFrame, error) {
+	leftCols := keySeries(left, leftKeys)
 	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
 	resultData := initResultData(resultCols)
+	rows := &joinRows{}
 	var indicators []interface{}
 
 	for i := 0; i < left.shape[0]; i++ {
-		leftKey := buildRowKey(left, leftKeys, i)
-		if rightRows, ok := rightIndex[leftKey]; ok {
+		if rightRows, found := rightIndex.lookup(leftCols, i); found {
 			for _, rightRow := range rightRows {
-				appendJoinedRow(resultData, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
+				appendJoinedRow(resultData, rows, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
 				if opts.Indicator {
 					indicators = append(indicators, "both")
 				}
@@ -181,70 +331,74 @@ func innerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex
 		}
 	}
 
-	return buildJoinResult(resultCols, resultData, indicators, opts)
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
 }
 
 // leftJoin performs a left join
-func leftJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex map[string][]int, opts MergeOptions) (*DataFrame, error) {
+func leftJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex *joinIndex, opts MergeOptions) (*DataFrame, error) {
+	leftCols := keySeries(left, leftKeys)
 	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
 	resultData := initResultData(resultCols)
+	rows := &joinRows{}
 	var indicators []interface{}
 
 	for i := 0; i < left.shape[0]; i++ {
-		leftKey := buildRowKey(left, leftKeys, i)
-		if rightRows, ok := rightIndex[leftKey]; ok {
+		if rightRows, found := rightIndex.lookup(leftCols, i); found {
 			for _, rightRow := range rightRows {
-				appendJoinedRow(resultData, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
+				appendJoinedRow(resultData, rows, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
 				if opts.Indicator {
 					indicators = append(indicators, "both")
 				}
 			}
 		} else {
 			// No match - include left row with nulls for right
-			appendLeftOnlyRow(resultData, colMapping, left, right, i, leftKeys, rightKeys, opts)
+			appendLeftOnlyRow(resultData, rows, colMapping, left, right, i, leftKeys, rightKeys, opts)
 			if opts.Indicator {
 				indicators = append(indicators, "left_only")
 			}
 		}
 	}
 
-	return buildJoinResult(resultCols, resultData, indicators, opts)
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
 }
 
 // rightJoin performs a right join
-func rightJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex map[string][]int, opts MergeOptions) (*DataFrame, error) {
+func rightJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex *joinIndex, opts MergeOptions) (*DataFrame, error) {
+	rightCols := keySeries(right, rightKeys)
 	// Build left index
 	leftIndex := buildJoinIndex(left, leftKeys)
 
 	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
 	resultData := initResultData(resultCols)
+	rows := &joinRows{}
 	var indicators []interface{}
 
 	for i := 0; i < right.shape[0]; i++ {
-		rightKey := buildRowKey(right, rightKeys, i)
-		if leftRows, ok := leftIndex[rightKey]; ok {
+		if leftRows, found := leftIndex.lookup(rightCols, i); found {
 			for _, leftRow := range leftRows {
-				appendJoinedRow(resultData, colMapping, left, right, leftRow, i, leftKeys, rightKeys, opts)
+				appendJoinedRow(resultData, rows, colMapping, left, right, leftRow, i, leftKeys, rightKeys, opts)
 				if opts.Indicator {
 					indicators = append(indicators, "both")
 				}
 			}
 		} else {
 			// No match - include right row with nulls for left
-			appendRightOnlyRow(resultData, colMapping, left, right, i, leftKeys, rightKeys, opts)
+			appendRightOnlyRow(resultData, rows, colMapping, left, right, i, leftKeys, rightKeys, opts)
 			if opts.Indicator {
 				indicators = append(indicators, "right_only")
 			}
 		}
 	}
 
-	return buildJoinResult(resultCols, resultData, indicators, opts)
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
 }
 
 // outerJoin performs a full outer join
-func outerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex map[string][]int, opts MergeOptions) (*DataFrame, error) {
+func outerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex *joinIndex, opts MergeOptions) (*DataFrame, error) {
+	leftCols := keySeries(left, leftKeys)
 	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
 	resultData := initResultData(resultCols)
+	rows := &joinRows{}
 	var indicators []interface{}
 
 	// Track which right rows have been matched
@@ -252,17 +406,16 @@ func outerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex
 
 	// Process all left rows
 	for i := 0; i < left.shape[0]; i++ {
-		leftKey := buildRowKey(left, leftKeys, i)
-		if rightRows, ok := rightIndex[leftKey]; ok {
+		if rightRows, found := rightIndex.lookup(leftCols, i); found {
 			for _, rightRow := range rightRows {
-				appendJoinedRow(resultData, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
+				appendJoinedRow(resultData, rows, colMapping, left, right, i, rightRow, leftKeys, rightKeys, opts)
 				matchedRight[rightRow] = true
 				if opts.Indicator {
 					indicators = append(indicators, "both")
 				}
 			}
 		} else {
-			appendLeftOnlyRow(resultData, colMapping, left, right, i, leftKeys, rightKeys, opts)
+			appendLeftOnlyRow(resultData, rows, colMapping, left, right, i, leftKeys, rightKeys, opts)
 			if opts.Indicator {
 				indicators = append(indicators, "left_only")
 			}
@@ -272,14 +425,48 @@ func outerJoin(left, right *DataFrame, leftKeys, rightKeys []string, rightIndex
 	// Add unmatched right rows
 	for i := 0; i < right.shape[0]; i++ {
 		if !matchedRight[i] {
-			appendRightOnlyRow(resultData, colMapping, left, right, i, leftKeys, rightKeys, opts)
+			appendRightOnlyRow(resultData, rows, colMapping, left, right, i, leftKeys, rightKeys, opts)
 			if opts.Indicator {
 				indicators = append(indicators, "right_only")
 			}
 		}
 	}
 
-	return buildJoinResult(resultCols, resultData, indicators, opts)
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
+}
+
+// crossJoin returns every combination of a left row with a right row
+func crossJoin(left, right *DataFrame, opts MergeOptions) (*DataFrame, error) {
+	resultCols, colMapping := prepareResultColumns(left, right, nil, nil, opts)
+	resultData := initResultData(resultCols)
+	rows := &joinRows{}
+	var indicators []interface{}
+
+	for i := 0; i < left.shape[0]; i++ {
+		for j := 0; j < right.shape[0]; j++ {
+			appendJoinedRow(resultData, rows, colMapping, left, right, i, j, nil, nil, opts)
+			if opts.Indicator {
+				indicators = append(indicators, "both")
+			}
+		}
+	}
+
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
+}
+
+// filterJoin keeps the left rows that have (semi) or do not have (anti) a
+// match in rightIndex. Each left row appears at most once and only the left
+// columns are returned.
+func filterJoin(left *DataFrame, leftKeys []string, rightIndex *joinIndex, semi bool) *DataFrame {
+	leftCols := keySeries(left, leftKeys)
+	positions := make([]int, 0, left.shape[0])
+	for i := 0; i < left.shape[0]; i++ {
+		_, found := rightIndex.lookup(leftCols, i)
+		if found == semi {
+			positions = append(positions, i)
+		}
+	}
+	return left.takeRows(positions)
 }
 
 // columnMapping stores information about how to map columns in the result
@@ -290,85 +477,61 @@ type columnMapping struct {
 	keyIndex  int    // index in keys array (if isKey)
 }
 
-// prepareResultColumns determines the columns in the result DataFrame
+// prepareResultColumns determines the columns in the result DataFrame.
+// Join keys are coalesced into the left key columns, which take the right
+// key values on right-only rows. With KeepRightKeys, right key columns whose
+// names differ from their left counterparts are kept as well, each filled
+// from its own side.
 func prepareResultColumns(left, right *DataFrame, leftKeys, rightKeys []string, opts MergeOptions) ([]string, map[string]columnMapping) {
 	var resultCols []string
 	colMapping := make(map[string]columnMapping)
 
-	// Track right columns that are join keys
-	rightKeySet := make(map[string]bool)
-	for _, k := range rightKeys {
-		rightKeySet[k] = true
+	leftKeyPos := make(map[string]int)
+	for i, k := range leftKeys {
+		leftKeyPos[k] = i
+	}
+	rightKeyPos := make(map[string]int)
+	for i, k := range rightKeys {
+		rightKeyPos[k] = i
 	}
 
-	// Track left columns that are join keys
-	leftKeySet := make(map[string]bool)
-	for _, k := range leftKeys {
-		leftKeySet[k] = true
+	// Right columns that appear in the result
+	var rightCols []string
+	rightEmitted := make(map[string]bool)
+	for _, col := range right.columns {
+		if i, isKey := rightKeyPos[col]; isKey && (!opts.KeepRightKeys || leftKeys[i] == col) {
+			continue
+		}
+		rightCols = append(rightCols, col)
+		rightEmitted[col] = true
 	}
 
 	// Add left columns
 	for _, col := range left.columns {
+		if i, isKey := leftKeyPos[col]; isKey {
+			resultCols = append(resultCols, col)
+			if opts.KeepRightKeys && rightKeys[i] != col {
+				colMapping[col] = columnMapping{source: "left", srcCol: col}
+			} else {
+				colMapping[col] = columnMapping{source: "key", srcCol: col, isKey: true, keyIndex: i}
+			}
+			continue
+		}
 		resultCol := col
-		_, inRight := right.data[col]
-		isLeftKey := leftKeySet[col]
-		
-		if inRight && !isLeftKey {
+		if rightEmitted[col] {
 			// Overlapping column, not a key - add suffix
 			resultCol = col + opts.Suffixes[0]
 		}
-		
 		resultCols = append(resultCols, resultCol)
-		if isLeftKey {
-			for i, k := range leftKeys {
-				if k == col {
-					colMapping[resultCol] = columnMapping{source: "key", srcCol: col, isKey: true, keyIndex: i}
-					break
-				}
-			}
-		} else {
-			colMapping[resultCol] = columnMapping{source: "left", srcCol: col}
-		}
+		colMapping[resultCol] = columnMapping{source: "left", srcCol: col}
 	}
 
-	// Add right columns (excluding join keys with same name)
-	for _, col := range right.columns {
-		// Skip if it's a key column with same name as left key
-		isRightKey := rightKeySet[col]
-		if isRightKey {
-			// Check if there's a corresponding left key with same name
-			for i, rk := range rightKeys {
-				if rk == col && leftKeys[i] == col {
-					// Same column name used as key on both sides - already included
-					continue
-				}
-			}
-		}
-
+	// Add right columns
+	for _, col := range rightCols {
 		resultCol := col
-		_, inLeft := left.data[col]
-		
-		if inLeft {
-			if isRightKey {
-				// It's a key but with different name - skip as we use left key
-				continue
-			}
-			// Overlapping column - add suffix
+		if _, inLeft := left.data[col]; inLeft {
 			resultCol = col + opts.Suffixes[1]
 		}
-
-		// Check if already added (for key columns)
-		alreadyAdded := false
-		for _, rc := range resultCols {
-			if rc == resultCol {
-				alreadyAdded = true
-				break
-			}
-		}
-		if alreadyAdded {
-			continue
-		}
-
 		resultCols = append(resultCols, resultCol)
 		colMapping[resultCol] = columnMapping{source: "right", srcCol: col}
 	}
@@ -386,9 +549,10 @@ func initResultData(cols []string) map[string][]interface{} {
 }
 
 // appendJoinedRow adds a row from both DataFrames
-func appendJoinedRow(resultData map[string][]interface{}, colMapping map[string]columnMapping, 
+func appendJoinedRow(resultData map[string][]interface{}, rows *joinRows, colMapping map[string]columnMapping, 
 	left, right *DataFrame, leftRow, rightRow int, leftKeys, rightKeys []string, opts MergeOptions) {
 	
+	rows.add(leftRow, rightRow)
 	for resultCol, mapping := range colMapping {
 		var val interface{}
 		if mapping.isKey {
@@ -407,9 +571,10 @@ func appendJoinedRow(resultData map[string][]interface{}, colMapping map[string]
 }
 
 // appendLeftOnlyRow adds a row from left DataFrame with nulls for right
-func appendLeftOnlyRow(resultData map[string][]interface{}, colMapping map[string]columnMapping,
+func appendLeftOnlyRow(resultData map[string][]interface{}, rows *joinRows, colMapping map[string]columnMapping,
 	left, right *DataFrame, leftRow int, leftKeys, rightKeys []string, opts MergeOptions) {
 	
+	rows.add(leftRow, -1)
 	for resultCol, mapping := range colMapping {
 		var val interface{}
 		if mapping.isKey {
@@ -426,9 +591,10 @@ func appendLeftOnlyRow(resultData map[string][]interface{}, colMapping map[strin
 }
 
 // appendRightOnlyRow adds a row from right DataFrame with nulls for left
-func appendRightOnlyRow(resultData map[string][]interface{}, colMapping map[string]columnMapping,
+func appendRightOnlyRow(resultData map[string][]interface{}, rows *joinRows, colMapping map[string]columnMapping,
 	left, right *DataFrame, rightRow int, leftKeys, rightKeys []string, opts MergeOptions) {
 	
+	rows.add(-1, rightRow)
 	for resultCol, mapping := range colMapping {
 		var val interface{}
 		if mapping.isKey {
@@ -445,31 +611,58 @@ func appendRightOnlyRow(resultData map[string][]interface{}, colMapping map[stri
 	}
 }
 
+// joinRows records the left and right row of every result row, -1 for a
+// side without one.
+type joinRows struct {
+	left, right []int
+}
+
+func (r *joinRows) add(left, right int) {
+	r.left = append(r.left, left)
+	r.right = append(r.right, right)
+}
+
+// joinResultIndex returns the index of a join result with the given row
+// pairs: a new RangeIndex, or with keep the label of each result row's left
+// row, or of its right row on right-only rows, named like the left index.
+func joinResultIndex(left, right *DataFrame, leftRows, rightRows []int, keep bool) *Index {
+	if !keep {
+		return NewRangeIndex(len(leftRows))
+	}
+	leftLabels, rightLabels := left.index.values(), right.index.values()
+	labels := make([]interface{}, len(leftRows))
+	for i, l := range leftRows {
+		if l >= 0 {
+			labels[i] = leftLabels[l]
+		} else {
+			labels[i] = rightLabels[rightRows[i]]
+		}
+	}
+	return NewIndex(labels, left.index.name)
+}
+
 // buildJoinResult builds the final DataFrame from join results
-func buildJoinResult(cols []string, data map[string][]interface{}, indicators []interface{}, opts MergeOptions) (*DataFrame, error) {
+func buildJoinResult(cols []string, data map[string][]interface{}, indicators []interface{}, index *Index, opts MergeOptions) (*DataFrame, error) {
 	if opts.Indicator {
 		cols = append(cols, "_merge")
 		data["_merge"] = indicators
 	}
 
 	seriesMap := make(map[string]*Series)
-	rowCount := 0
 	for _, col := range cols {
-		seriesMap[col] = NewSeries(data[col], col)
-		if rowCount == 0 {
-			rowCount = len(data[col])
-		}
+		seriesMap[col] = NewSeriesWithIndex(data[col], col, index.Copy())
 	}
 
 	return &DataFrame{
 		columns: cols,
 		data:    seriesMap,
-		index:   NewRangeIndex(rowCount),
-		shape:   [2]int{rowCount, len(cols)},
+		index:   index,
+		shape:   [2]int{index.Len(), len(cols)},
 	}, nil
 }
 
-// Join is a convenience method for joining DataFrames
+// Join is a convenience method for joining DataFrames. Any JoinType is
+// accepted; on must be empty for CrossJoin.
 func (df *DataFrame) Join(other *DataFrame, on []string, how JoinType) (*DataFrame, error) {
 	opts := DefaultMergeOptions()
 	opts.On = on
@@ -485,3 +678,244 @@ func (df *DataFrame) MergeOn(other *DataFrame, leftOn, rightOn []string, how Joi
 	opts.How = how
 	return Merge(df, other, opts)
 }
+
+// AsofOptions defines options for MergeAsof
+type AsofOptions struct {
+	On        string      // ordered numeric or datetime column present in both DataFrames
+	By        []string    // columns that must match exactly before the asof search
+	Direction string      // "backward" (default), "forward" or "nearest"
+	Tolerance interface{} // maximum distance to a match: a number, or a time.Duration for datetime columns
+	Suffixes  [2]string   // suffixes for overlapping columns (default "_x", "_y")
+}
+
+// MergeAsof performs an ordered left join that matches each left row with
+// the nearest right row on the On column instead of an equal key. With the
+// "backward" direction the last right row whose On value is less than or
+// equal to the left value is taken, with "forward" the first right row whose
+// value is greater than or equal, and with "nearest" the closer of the two.
+// Rows with no match within Tolerance get nil for the right columns.
+// Both DataFrames must be sorted ascending on On within each By group.
+func MergeAsof(left, right *DataFrame, opts AsofOptions) (*DataFrame, error) {
+	if left == nil || right == nil {
+		return nil, fmt.Errorf("both DataFrames must be non-nil")
+	}
+	if opts.Direction == "" {
+		opts.Direction = "backward"
+	}
+	switch opts.Direction {
+	case "backward", "forward", "nearest":
+	default:
+		return nil, fmt.Errorf("invalid asof direction '%s'", opts.Direction)
+	}
+	if opts.Suffixes == [2]string{} {
+		opts.Suffixes = [2]string{"_x", "_y"}
+	}
+	for _, col := range append([]string{opts.On}, opts.By...) {
+		if _, ok := left.data[col]; !ok {
+			return nil, fmt.Errorf("%w in left DataFrame", &ColumnNotFoundError{Column: col})
+		}
+		if _, ok := right.data[col]; !ok {
+			return nil, fmt.Errorf("%w in right DataFrame", &ColumnNotFoundError{Column: col})
+		}
+	}
+
+	leftOn, rightOn := left.data[opts.On], right.data[opts.On]
+	isTime := leftOn.dtype == DTypeDateTime
+	for _, side := range []string{"left", "right"} {
+		s := leftOn
+		if side == "right" {
+			s = rightOn
+		}
+		switch s.dtype {
+		case DTypeInt64, DTypeFloat64, DTypeDateTime:
+		default:
+			return nil, fmt.Errorf("asof column '%s' in %s DataFrame must be numeric or datetime, got %s", opts.On, side, s.dtype)
+		}
+		if (s.dtype == DTypeDateTime) != isTime {
+			return nil, fmt.Errorf("asof column '%s' has incompatible types %s and %s", opts.On, leftOn.dtype, rightOn.dtype)
+		}
+		for i, v := range s.data {
+			if s.isNA(v) {
+				return nil, fmt.Errorf("asof column '%s' in %s DataFrame has NA at row %d", opts.On, side, i)
+			}
+		}
+	}
+
+	tolerance := math.Inf(1)
+	if opts.Tolerance != nil {
+		var err error
+		tolerance, err = asofTolerance(opts.Tolerance, isTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	leftGroups, leftOrder, err := asofGroups(left, opts.On, opts.By, "left")
+	if err != nil {
+		return nil, err
+	}
+	rightGroups, _, err := asofGroups(right, opts.On, opts.By, "right")
+	if err != nil {
+		return nil, err
+	}
+
+	// Linear two-pointer scan per group
+	matches := make([]int, left.shape[0])
+	for _, key := range leftOrder {
+		lrows := leftGroups[key]
+		rrows := rightGroups[key]
+		// rrows[:j] are <= the left value and rrows[:k] are < it, so the
+		// backward candidate is rrows[j-1] and the forward one rrows[k].
+		j, k := 0, 0
+		for _, l := range lrows {
+			lv := leftOn.data[l]
+			for j < len(rrows) && compareValues(rightOn.data[rrows[j]], lv) <= 0 {
+				j++
+			}
+			for k < len(rrows) && compareValues(rightOn.data[rrows[k]], lv) < 0 {
+				k++
+			}
+			back, fwd := -1, -1
+			if j > 0 {
+				back = rrows[j-1]
+			}
+			if k < len(rrows) {
+				fwd = rrows[k]
+			}
+
+			match := -1
+			switch opts.Direction {
+			case "backward":
+				match = back
+			case "forward":
+				match = fwd
+			case "nearest":
+				match = back
+				if fwd >= 0 && (back < 0 || asofDistance(rightOn.data[fwd], lv) < asofDistance(rightOn.data[back], lv)) {
+					match = fwd
+				}
+			}
+			if match >= 0 && asofDistance(rightOn.data[match], lv) > tolerance {
+				match = -1
+			}
+			matches[l] = match
+		}
+	}
+
+	// Build result columns: all left columns, then right columns except keys
+	keySet := map[string]bool{opts.On: true}
+	for _, col := range opts.By {
+		keySet[col] = true
+	}
+	var resultCols []string
+	var sources []*Series
+	var fromRight []bool
+	for _, col := range left.columns {
+		name := col
+		if _, ok := right.data[col]; ok && !keySet[col] {
+			name = col + opts.Suffixes[0]
+		}
+		resultCols = append(resultCols, name)
+		sources = append(sources, left.data[col])
+		fromRight = append(fromRight, false)
+	}
+	for _, col := range right.columns {
+		if keySet[col] {
+			continue
+		}
+		name := col
+		if _, ok := left.data[col]; ok {
+			name = col + opts.Suffixes[1]
+		}
+		resultCols = append(resultCols, name)
+		sources = append(sources, right.data[col])
+		fromRight = append(fromRight, true)
+	}
+
+	n := left.shape[0]
+	seriesMap := make(map[string]*Series, len(resultCols))
+	for c, name := range resultCols {
+		data := make([]interface{}, n)
+		for i := 0; i < n; i++ {
+			switch {
+			case !fromRight[c]:
+				data[i] = sources[c].data[i]
+			case matches[i] >= 0:
+				data[i] = sources[c].data[matches[i]]
+			}
+		}
+		seriesMap[name] = NewSeries(data, name)
+	}
+
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   NewRangeIndex(n),
+		shape:   [2]int{n, len(resultCols)},
+	}, nil
+}
+
+// asofGroups groups row positions by the By columns and validates that the
+// On column is sorted ascending within each group. It also returns the group
+// keys in order of first appearance.
+func asofGroups(df *DataFrame, on string, by []string, side string) (map[string][]int, []string, error) {
+	groups := make(map[string][]int)
+	var order []string
+	keyVals := make([]interface{}, len(by))
+	onSeries := df.data[on]
+	for i := 0; i < df.shape[0]; i++ {
+		for j, col := range by {
+			keyVals[j] = df.data[col].data[i]
+		}
+		key := compositeKey(keyVals)
+		rows, ok := groups[key]
+		if !ok {
+			order = append(order, key)
+		}
+		if len(rows) > 0 && compareValues(onSeries.data[rows[len(rows)-1]], onSeries.data[i]) > 0 {
+			if len(by) == 0 {
+				return nil, nil, fmt.Errorf("%s DataFrame is not sorted on '%s' at row %d", side, on, i)
+			}
+			return nil, nil, fmt.Errorf("%s DataFrame is not sorted on '%s' within group %v at row %d", side, on, keyVals, i)
+		}
+		groups[key] = append(rows, i)
+	}
+	return groups, order, nil
+}
+
+// asofTolerance converts a tolerance option to the unit used by asofDistance.
+func asofTolerance(tol interface{}, isTime bool) (float64, error) {
+	var result float64
+	if d, ok := tol.(time.Duration); ok {
+		if !isTime {
+			return 0, fmt.Errorf("duration tolerance requires a datetime asof column")
+		}
+		result = float64(d)
+	} else {
+		if isTime {
+			return 0, fmt.Errorf("tolerance for a datetime asof column must be a time.Duration, got %T", tol)
+		}
+		f, err := toFloat64(tol)
+		if err != nil || !isNumber(tol) {
+			return 0, fmt.Errorf("tolerance must be a number, got %T", tol)
+		}
+		result = f
+	}
+	if result < 0 {
+		return 0, fmt.Errorf("tolerance must not be negative")
+	}
+	return result, nil
+}
+
+// asofDistance returns the absolute distance between two asof values,
+// in nanoseconds for times.
+func asofDistance(a, b interface{}) float64 {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return math.Abs(float64(ta.Sub(tb)))
+		}
+	}
+	fa, _ := toFloat64(a)
+	fb, _ := toFloat64(b)
+	return math.Abs(fa - fb)
+}
@@ -0,0 +1,303 @@
+package dataframe
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// naConfig is a set of strings that are NA.
+type naConfig struct {
+	strings  []string // NA strings other than ""
+	empty    bool     // whether "" is NA
+	zeroTime bool     // whether the zero time.Time is NA
+}
+
+// globalNA holds the NA strings set for the package.
+var globalNA atomic.Pointer[naConfig]
+
+func init() {
+	globalNA.Store(&naConfig{strings: []string{"NA", "NaN", "null"}, empty: true, zeroTime: true})
+}
+
+func (c *naConfig) isNA(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		if val == "" {
+			return c.empty
+		}
+		for _, na := range c.strings {
+			if val == na {
+				return true
+			}
+		}
+	case float64:
+		return val != val // NaN check
+	case float32:
+		return val != val // NaN check
+	case time.Time:
+		return c.zeroTime && val.IsZero()
+	}
+	return false
+}
+
+// withStrings returns a copy of c with the NA strings values. The empty
+// string in values is ignored; it is set with withEmpty.
+func (c *naConfig) withStrings(values []string) *naConfig {
+	strings := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" && !slices.Contains(strings, v) {
+			strings = append(strings, v)
+		}
+	}
+	return &naConfig{strings: strings, empty: c.empty, zeroTime: c.zeroTime}
+}
+
+// withEmpty returns a copy of c where "" is NA if empty is set.
+func (c *naConfig) withEmpty(empty bool) *naConfig {
+	return &naConfig{strings: c.strings, empty: empty, zeroTime: c.zeroTime}
+}
+
+// withZeroTime returns a copy of c where the zero time.Time is NA if
+// zeroTime is set.
+func (c *naConfig) withZeroTime(zeroTime bool) *naConfig {
+	return &naConfig{strings: c.strings, empty: c.empty, zeroTime: zeroTime}
+}
+
+// SetNAStrings sets the strings IsNA and every operation skipping, counting
+// or filling NA values treat as NA, replacing "NA", "NaN" and "null". Pass
+// nil so that no string other than "" is NA. The empty string is controlled
+// by SetEmptyStringNA and ignored in values. Series with their own NA
+// strings, see Series.SetNAStrings, are not affected. It is safe for
+// concurrent use, but operations running meanwhile may see either setting.
+func SetNAStrings(values []string) {
+	for {
+		old := globalNA.Load()
+		if globalNA.CompareAndSwap(old, old.withStrings(values)) {
+			return
+		}
+	}
+}
+
+// SetEmptyStringNA sets whether the empty string is NA, which it is by
+// default.
+func SetEmptyStringNA(na bool) {
+	for {
+		old := globalNA.Load()
+		if globalNA.CompareAndSwap(old, old.withEmpty(na)) {
+			return
+		}
+	}
+}
+
+// SetZeroTimeNA sets whether the zero time.Time, which failed parses and
+// blank date cells often leave behind, is NA. It is by default; turn it off
+// when the zero time is genuine data.
+func SetZeroTimeNA(na bool) {
+	for {
+		old := globalNA.Load()
+		if globalNA.CompareAndSwap(old, old.withZeroTime(na)) {
+			return
+		}
+	}
+}
+
+// NAStrings returns the strings other than "" set as NA with SetNAStrings.
+func NAStrings() []string {
+	return slices.Clone(globalNA.Load().strings)
+}
+
+// SetNAStrings gives the Series its own NA strings in place of those set
+// with the package-level SetNAStrings, for the Series and the Series
+// derived from it. The empty string keeps its setting, see
+// SetEmptyStringNA.
+func (s *Series) SetNAStrings(values []string) *Series {
+	s.na = s.naConfig().withStrings(values)
+	return s
+}
+
+// SetEmptyStringNA sets whether the empty string is NA in the Series, in
+// place of the package-level setting.
+func (s *Series) SetEmptyStringNA(na bool) *Series {
+	s.na = s.naConfig().withEmpty(na)
+	return s
+}
+
+// SetZeroTimeNA sets whether the zero time.Time is NA in the Series, in
+// place of the package-level setting.
+func (s *Series) SetZeroTimeNA(na bool) *Series {
+	s.na = s.naConfig().withZeroTime(na)
+	return s
+}
+
+// naConfig returns the NA strings of the Series.
+func (s *Series) naConfig() *naConfig {
+	if s.na != nil {
+		return s.na
+	}
+	return globalNA.Load()
+}
+
+// isNA reports whether v is NA in the Series.
+func (s *Series) isNA(v interface{}) bool {
+	return s.naConfig().isNA(v)
+}
+
+// DropNAOptions defines options for DataFrame.DropNA.
+type DropNAOptions struct {
+	How    string   // "any" (default) drops if any value is NA, "all" only if all are
+	Thresh int      // if > 0, keep only entries with at least Thresh non-NA values; overrides How
+	Subset []string // columns to examine when dropping rows (default all); not allowed with Axis 1
+	Axis   int      // 0 drops rows (default), 1 drops columns
+}
+
+// DropNA removes rows (or columns with Axis 1) containing NA values.
+// Surviving rows keep their index labels.
+func (df *DataFrame) DropNA(opts DropNAOptions) (*DataFrame, error) {
+	how := opts.How
+	if how == "" {
+		how = "any"
+	}
+	if how != "any" && how != "all" {
+		return nil, fmt.Errorf("invalid how %q: must be \"any\" or \"all\"", how)
+	}
+
+	keep := func(nonNA, total int) bool {
+		if opts.Thresh > 0 {
+			return nonNA >= opts.Thresh
+		}
+		if how == "all" {
+			return nonNA > 0 || total == 0
+		}
+		return nonNA == total
+	}
+
+	switch opts.Axis {
+	case 0:
+		cols := opts.Subset
+		if len(cols) == 0 {
+			cols = df.columns
+		}
+		for _, col := range cols {
+			if _, ok := df.data[col]; !ok {
+				return nil, &ColumnNotFoundError{Column: col}
+			}
+		}
+		var rows []int
+		for i := 0; i < df.shape[0]; i++ {
+			nonNA := 0
+			for _, col := range cols {
+				s := df.data[col]
+				if !s.isNA(s.data[i]) {
+					nonNA++
+				}
+			}
+			if keep(nonNA, len(cols)) {
+				rows = append(rows, i)
+			}
+		}
+		return df.takeRows(rows), nil
+	case 1:
+		if len(opts.Subset) > 0 {
+			return nil, fmt.Errorf("subset selects columns and cannot be used with axis 1")
+		}
+		var cols []string
+		for _, col := range df.columns {
+			if keep(df.data[col].Count(), df.shape[0]) {
+				cols = append(cols, col)
+			}
+		}
+		return df.Select(cols...), nil
+	default:
+		return nil, fmt.Errorf("invalid axis %d: must be 0 or 1", opts.Axis)
+	}
+}
+
+// IsNA returns a DataFrame of the same shape holding true where the value
+// is NA.
+func (df *DataFrame) IsNA() *DataFrame {
+	seriesMap := make(map[string]*Series)
+	for _, col := range df.columns {
+		mask := df.data[col].IsNA()
+		mask.SetName(col)
+		seriesMap[col] = mask
+	}
+	cols := make([]string, len(df.columns))
+	copy(cols, df.columns)
+	return &DataFrame{columns: cols, data: seriesMap, index: df.index.Copy(), shape: df.shape}
+}
+
+// takeRows returns a new DataFrame with the rows at the given positions,
+// keeping their index labels.
+func (df *DataFrame) takeRows(positions []int) *DataFrame {
+	newIndex := NewIndex(extractLabels(df.index, positions), df.index.Name())
+	seriesMap := make(map[string]*Series)
+	for _, col := range df.columns {
+		src := df.data[col]
+		newData := make([]interface{}, len(positions))
+		for i, pos := range positions {
+			newData[i] = src.data[pos]
+		}
+		seriesMap[col] = &Series{name: col, data: newData, dtype: src.dtype, index: newIndex.Copy(), na: src.na}
+	}
+	cols := make([]string, len(df.columns))
+	copy(cols, df.columns)
+	return &DataFrame{
+		columns: cols,
+		data:    seriesMap,
+		index:   newIndex,
+		shape:   [2]int{len(positions), len(cols)},
+	}
+}
+
+// FillOptions defines a method-based fill for DataFrame.FillNA.
+type FillOptions struct {
+	Method  string   // "ffill" or "bfill"
+	Limit   int      // maximum number of consecutive NA values to fill (0 = no limit)
+	Columns []string // columns to fill (default all)
+}
+
+// FillNA returns a copy of the DataFrame with NA values filled. value is
+// either a scalar used for every column, a map[string]interface{} of
+// per-column fill values (unlisted columns are left untouched), or a
+// FillOptions for forward/backward filling. The receiver is not modified.
+func (df *DataFrame) FillNA(value interface{}) (*DataFrame, error) {
+	newDF := df.Copy()
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for col, fill := range v {
+			s, ok := newDF.data[col]
+			if !ok {
+				return nil, &ColumnNotFoundError{Column: col}
+			}
+			newDF.data[col] = s.FillNA(fill)
+		}
+	case FillOptions:
+		cols := v.Columns
+		if len(cols) == 0 {
+			cols = newDF.columns
+		}
+		for _, col := range cols {
+			s, ok := newDF.data[col]
+			if !ok {
+				return nil, &ColumnNotFoundError{Column: col}
+			}
+			switch v.Method {
+			case "ffill":
+				newDF.data[col] = s.FFill(v.Limit)
+			case "bfill":
+				newDF.data[col] = s.BFill(v.Limit)
+			default:
+				return nil, fmt.Errorf("invalid fill method %q: must be \"ffill\" or \"bfill\"", v.Method)
+			}
+		}
+	default:
+		for _, col := range newDF.columns {
+			newDF.data[col] = newDF.data[col].FillNA(value)
+		}
+	}
+	return newDF, nil
+}
@@ -18,20 +18,13 @@ const (
 	Descending
 )
 
-// ILoc selects rows and columns by integer position.
+// ILoc selects rows and columns by integer position, with end positions
+// exclusive. Negative bounds count from the end (-1 is the last position),
+// and bounds outside the DataFrame are clamped, so an empty range gives an
+// empty DataFrame rather than an error.
 func (df *DataFrame) ILoc(rowStart, rowEnd, colStart, colEnd int) *DataFrame {
-	if rowStart < 0 {
-		rowStart = 0
-	}
-	if rowEnd > df.shape[0] {
-		rowEnd = df.shape[0]
-	}
-	if colStart < 0 {
-		colStart = 0
-	}
-	if colEnd > df.shape[1] {
-		colEnd = df.shape[1]
-	}
+	rowStart, rowEnd = clampRange(rowStart, rowEnd, df.shape[0])
+	colStart, colEnd = clampRange(colStart, colEnd, df.shape[1])
 
 	cols := df.columns[colStart:colEnd]
 	seriesMap := make(map[string]*Series)
@@ -44,7 +37,56 @@ func (df *DataFrame) ILoc(rowStart, rowEnd, colStart, colEnd int) *DataFrame {
 	return &DataFrame{columns: append([]string{}, cols...), data: seriesMap, index: newIndex, shape: [2]int{rowEnd - rowStart, colEnd - colStart}}
 }
 
+// clampRange resolves negative bounds relative to n and clamps the range
+// to [0, n] with start <= end.
+func clampRange(start, end, n int) (int, int) {
+	if start < 0 {
+		start += n
+	}
+	if end < 0 {
+		end += n
+	}
+	start = min(max(start, 0), n)
+	end = min(max(end, start), n)
+	return start, end
+}
+
+// resolvePosition resolves a possibly negative position against n items.
+func resolvePosition(pos, n int) (int, error) {
+	p := pos
+	if p < 0 {
+		p += n
+	}
+	if p < 0 || p >= n {
+		return 0, &IndexOutOfRangeError{Pos: pos, Len: n, msg: fmt.Sprintf("position %d out of range for length %d", pos, n)}
+	}
+	return p, nil
+}
+
+// ILocRow returns the row at position i. Negative positions count from the
+// end; out-of-range positions are an error.
+func (df *DataFrame) ILocRow(i int) (Row, error) {
+	pos, err := resolvePosition(i, df.shape[0])
+	if err != nil {
+		return Row{}, err
+	}
+	return df.Row(pos)
+}
+
+// ILocCol returns a copy of the column at position i. Negative positions
+// count from the end; out-of-range positions are an error.
+func (df *DataFrame) ILocCol(i int) (*Series, error) {
+	pos, err := resolvePosition(i, df.shape[1])
+	if err != nil {
+		return nil, err
+	}
+	return df.data[df.columns[pos]].Copy(), nil
+}
+
 // Loc selects rows and columns by labels.
+// Loc is lenient: row labels and columns that do not exist are skipped
+// instead of reported. Use SelectRowsByLabels to get an error for a missing
+// row label.
 func (df *DataFrame) Loc(rowLabels interface{}, colLabels interface{}) *DataFrame {
 	// For simplicity: rowLabels can be []interface{} or nil; colLabels can be []string or nil
 	var rowPositions []int
@@ -79,11 +121,13 @@ func (df *DataFrame) Loc(rowLabels interface{}, colLabels interface{}) *DataFram
 	}
 
 	seriesMap := make(map[string]*Series)
+	kept := make([]string, 0, len(cols))
 	for _, col := range cols {
 		s, ok := df.data[col]
 		if !ok {
 			continue
 		}
+		kept = append(kept, col)
 		newData := make([]interface{}, len(rowPositions))
 		newLabels := make([]interface{}, len(rowPositions))
 		for i, pos := range rowPositions {
@@ -95,10 +139,10 @@ func (df *DataFrame) Loc(rowLabels interface{}, colLabels interface{}) *DataFram
 	}
 
 	return &DataFrame{
-		columns: cols,
+		columns: kept,
 		data:    seriesMap,
 		index:   NewIndex(extractLabels(df.index, rowPositions), df.index.Name()),
-		shape:   [2]int{len(rowPositions), len(cols)},
+		shape:   [2]int{len(rowPositions), len(kept)},
 	}
 }
 
@@ -112,30 +156,309 @@ func extractLabels(idx *Index, positions []int) []interface{} {
 }
 
 // Filter filters rows using the provided function.
+// The Row passed to fn is a view that reads values on demand, and the result
+// keeps the index labels and column dtypes of the matching rows.
 func (df *DataFrame) Filter(fn FilterFunc) *DataFrame {
 	var rows []int
 	for i := 0; i < df.shape[0]; i++ {
-		row, _ := df.Row(i)
-		if fn(row) {
+		if fn(Row{df: df, pos: i}) {
 			rows = append(rows, i)
 		}
 	}
-	return df.Loc(extractLabels(df.index, rows), nil)
+	return df.takeRows(rows)
+}
+
+// SelectRowsByMask returns the rows where mask is true. The mask must be a
+// bool Series with one value per row and no NA values.
+func (df *DataFrame) SelectRowsByMask(mask *Series) (*DataFrame, error) {
+	if mask == nil {
+		return nil, fmt.Errorf("mask is nil")
+	}
+	if mask.Len() != df.shape[0] {
+		return nil, lengthMismatch(df.shape[0], mask.Len(), "mask length %d does not match dataframe rows %d", mask.Len(), df.shape[0])
+	}
+	return df.selectByMask(mask.data)
+}
+
+// selectByMask returns the rows where mask holds true. Every mask value must
+// be a bool.
+func (df *DataFrame) selectByMask(mask []interface{}) (*DataFrame, error) {
+	positions := make([]int, 0, len(mask))
+	for i, v := range mask {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("mask value at position %d is %T, expected bool", i, v)
+		}
+		if b {
+			positions = append(positions, i)
+		}
+	}
+	return df.takeRows(positions), nil
+}
+
+// SelectRowsAt returns the rows at the given positions, in the given order.
+// Negative positions count from the end, so -1 is the last row.
+func (df *DataFrame) SelectRowsAt(positions []int) (*DataFrame, error) {
+	resolved := make([]int, len(positions))
+	for i, pos := range positions {
+		p, err := resolvePosition(pos, df.shape[0])
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = p
+	}
+	return df.takeRows(resolved), nil
+}
+
+// SelectRowsByLabels returns the rows with the given index labels, in the
+// given order. Unlike Loc, a label that is not in the index is an error.
+func (df *DataFrame) SelectRowsByLabels(labels []interface{}) (*DataFrame, error) {
+	positions := make([]int, len(labels))
+	for i, label := range labels {
+		pos, err := df.index.GetLoc(label)
+		if err != nil {
+			return nil, err
+		}
+		positions[i] = pos
+	}
+	return df.takeRows(positions), nil
+}
+
+// dupGroupColumn is the column DuplicatedRows adds with the group id.
+const dupGroupColumn = "_dup_group"
+
+// DuplicatedRows returns every row whose values in subset (all columns when
+// empty) also occur in another row, with an int64 "_dup_group" column that
+// numbers the duplicate groups from 0 in order of first appearance. Rows
+// keep their order and index labels. Values are compared like GroupBy
+// compares keys, so nil only matches nil.
+func (df *DataFrame) DuplicatedRows(subset []string) (*DataFrame, error) {
+	if len(subset) == 0 {
+		subset = df.columns
+	}
+	for _, col := range subset {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+	}
+	if _, ok := df.data[dupGroupColumn]; ok {
+		return nil, fmt.Errorf("column '%s' already exists", dupGroupColumn)
+	}
+
+	ids, first := rowGroups(keySeries(df, subset), df.shape[0])
+	counts := make([]int, len(first))
+	for _, id := range ids {
+		counts[id]++
+	}
+	dupIDs := make([]int64, len(first))
+	next := int64(0)
+	for id := range first {
+		dupIDs[id] = -1
+		if counts[id] > 1 {
+			dupIDs[id] = next
+			next++
+		}
+	}
+
+	positions := []int{}
+	groups := []interface{}{}
+	for i, id := range ids {
+		if dupIDs[id] >= 0 {
+			positions = append(positions, i)
+			groups = append(groups, dupIDs[id])
+		}
+	}
+	result := df.takeRows(positions)
+	result.columns = append(result.columns, dupGroupColumn)
+	result.data[dupGroupColumn] = &Series{name: dupGroupColumn, data: groups, dtype: DTypeInt64, index: result.index.Copy()}
+	result.shape[1] = len(result.columns)
+	return result, nil
 }
 
-// AddColumn adds a new column to the DataFrame.
-func (df *DataFrame) AddColumn(name string, series *Series) *DataFrame {
+// Explode expands a column of slices into one row per item. The other
+// columns and the index labels are repeated alongside, so duplicated labels
+// show which row each item came from.
+func (df *DataFrame) Explode(column string) (*DataFrame, error) {
+	s, ok := df.data[column]
+	if !ok {
+		return nil, &ColumnNotFoundError{Column: column}
+	}
+
+	exploded, positions := explodeValues(s.data)
+	newIndex := NewIndex(extractLabels(df.index, positions), df.index.Name())
+	seriesMap := make(map[string]*Series)
+	for _, col := range df.columns {
+		var newData []interface{}
+		if col == column {
+			newData = exploded
+		} else {
+			src := df.data[col]
+			newData = make([]interface{}, len(positions))
+			for i, pos := range positions {
+				newData[i] = src.data[pos]
+			}
+		}
+		seriesMap[col] = NewSeriesWithIndex(newData, col, newIndex.Copy())
+	}
+
+	cols := make([]string, len(df.columns))
+	copy(cols, df.columns)
+	return &DataFrame{
+		columns: cols,
+		data:    seriesMap,
+		index:   newIndex,
+		shape:   [2]int{len(positions), len(cols)},
+	}, nil
+}
+
+// ApplyRows evaluates fn for each row and returns a copy of the DataFrame
+// with the results stored in newColumn. An existing column of that name is
+// replaced in place. The dtype of the new column is inferred from the
+// results.
+func (df *DataFrame) ApplyRows(fn func(Row) interface{}, newColumn string) (*DataFrame, error) {
+	values := make([]interface{}, df.shape[0])
+	for i := range values {
+		values[i] = fn(Row{df: df, pos: i})
+	}
+	return df.withRowValues(values, newColumn)
+}
+
+// withRowValues returns a copy of the DataFrame with values, one per row,
+// stored in newColumn as ApplyRows stores them.
+func (df *DataFrame) withRowValues(values []interface{}, newColumn string) (*DataFrame, error) {
+	newDF := df.Copy()
+	if err := newDF.SetColumn(newColumn, NewSeriesWithIndex(values, newColumn, df.index.Copy())); err != nil {
+		return nil, err
+	}
+	return newDF, nil
+}
+
+// ApplyColumns applies fn to each column in order and returns a DataFrame of
+// the results. It is the serial counterpart of ParallelTransform and reports
+// an error if fn returns nil or a Series of the wrong length.
+func (df *DataFrame) ApplyColumns(fn func(*Series) *Series) (*DataFrame, error) {
+	seriesMap := make(map[string]*Series)
+	for _, col := range df.columns {
+		result := fn(df.data[col])
+		if result == nil {
+			return nil, fmt.Errorf("column '%s': function returned nil", col)
+		}
+		if result.Len() != df.shape[0] {
+			return nil, lengthMismatch(df.shape[0], result.Len(), "column '%s': result length %d does not match dataframe rows %d", col, result.Len(), df.shape[0])
+		}
+		seriesMap[col] = result
+	}
+	cols := make([]string, len(df.columns))
+	copy(cols, df.columns)
+	return &DataFrame{columns: cols, data: seriesMap, index: df.index.Copy(), shape: df.shape}, nil
+}
+
+// WithColumn returns a copy of the DataFrame with a column computed from
+// each row. An existing column of that name is replaced in place.
+func (df *DataFrame) WithColumn(name string, fn func(Row) interface{}) *DataFrame {
+	// ApplyRows can only fail on a length mismatch, which cannot happen here
+	newDF, _ := df.ApplyRows(fn, name)
+	return newDF
+}
+
+// WithColumnSeries returns a copy of the DataFrame with the given Series as
+// a column. An existing column of that name is replaced in place.
+func (df *DataFrame) WithColumnSeries(name string, series *Series) (*DataFrame, error) {
+	if series == nil {
+		return nil, fmt.Errorf("series is nil")
+	}
+	newDF := df.Copy()
+	if err := newDF.SetColumn(name, series.Copy()); err != nil {
+		return nil, err
+	}
+	return newDF, nil
+}
+
+// Pipe passes the DataFrame to fn and returns its result, so that
+// transformations can be chained without intermediate variables.
+func (df *DataFrame) Pipe(fn func(*DataFrame) (*DataFrame, error)) (*DataFrame, error) {
+	return fn(df)
+}
+
+// AddColumn returns a new DataFrame with series appended as column name.
+// It returns an error if the column already exists or the series length does
+// not match the number of rows; use SetColumn to replace a column.
+func (df *DataFrame) AddColumn(name string, series *Series) (*DataFrame, error) {
+	if _, ok := df.data[name]; ok {
+		return nil, fmt.Errorf("column '%s' already exists", name)
+	}
 	if series.Len() != df.shape[0] {
-		return df
+		return nil, lengthMismatch(df.shape[0], series.Len(), "series length %d for column '%s' does not match dataframe rows %d", series.Len(), name, df.shape[0])
 	}
 	newDF := df.Copy()
 	newDF.columns = append(newDF.columns, name)
 	newDF.data[name] = series.Copy()
+	newDF.data[name].SetName(name)
 	newDF.shape[1] = len(newDF.columns)
-	return newDF
+	return newDF, nil
+}
+
+// InsertColumn inserts a new column at position pos, shifting the columns at
+// and after pos to the right. It modifies the DataFrame in place and returns
+// an error if series is nil, the column already exists, pos is out of range
+// or the series length does not match the number of rows.
+func (df *DataFrame) InsertColumn(pos int, name string, series *Series) error {
+	if series == nil {
+		return fmt.Errorf("series is nil")
+	}
+	if _, ok := df.data[name]; ok {
+		return fmt.Errorf("column '%s' already exists", name)
+	}
+	if pos < 0 || pos > len(df.columns) {
+		return &IndexOutOfRangeError{Pos: pos, Len: len(df.columns) + 1, msg: fmt.Sprintf("insert position %d out of range [0, %d]", pos, len(df.columns))}
+	}
+	if series.Len() != df.shape[0] {
+		return lengthMismatch(df.shape[0], series.Len(), "series length %d does not match dataframe rows %d", series.Len(), df.shape[0])
+	}
+	df.columns = append(df.columns, "")
+	copy(df.columns[pos+1:], df.columns[pos:])
+	df.columns[pos] = name
+	series.SetName(name)
+	df.data[name] = series
+	df.shape[1] = len(df.columns)
+	return nil
 }
 
-// Drop removes columns from the DataFrame.
+// PopColumn removes a column and returns it together with the remaining
+// DataFrame. The receiver is not modified.
+func (df *DataFrame) PopColumn(name string) (*Series, *DataFrame, error) {
+	series, ok := df.data[name]
+	if !ok {
+		return nil, nil, &ColumnNotFoundError{Column: name}
+	}
+	return series.Copy(), df.Drop(name), nil
+}
+
+// ReorderColumns returns a new DataFrame with the columns in the given order.
+// names must contain every existing column exactly once.
+func (df *DataFrame) ReorderColumns(names []string) (*DataFrame, error) {
+	if len(names) != len(df.columns) {
+		return nil, lengthMismatch(len(df.columns), len(names), "expected %d columns, got %d", len(df.columns), len(names))
+	}
+	seen := make(map[string]bool, len(names))
+	newData := make(map[string]*Series, len(names))
+	for _, col := range names {
+		series, ok := df.data[col]
+		if !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+		if seen[col] {
+			return nil, fmt.Errorf("column '%s' listed more than once", col)
+		}
+		seen[col] = true
+		newData[col] = series.Copy()
+	}
+	newCols := append([]string(nil), names...)
+	return &DataFrame{columns: newCols, data: newData, index: df.index.Copy(), shape: [2]int{df.shape[0], len(newCols)}}, nil
+}
+
+// Drop removes columns from the DataFrame. Columns that do not exist are
+// ignored.
 func (df *DataFrame) Drop(columns ...string) *DataFrame {
 	toDrop := make(map[string]bool)
 	for _, col := range columns {
@@ -152,7 +475,19 @@ func (df *DataFrame) Drop(columns ...string) *DataFrame {
 	return &DataFrame{columns: newCols, data: newData, index: df.index.Copy(), shape: [2]int{df.shape[0], len(newCols)}}
 }
 
-// Rename renames columns according to the mapping.
+// DropRows returns a copy of the DataFrame without the rows whose index
+// label is one of labels; every row of a repeated label is dropped. A label
+// that is not in the index is an error.
+func (df *DataFrame) DropRows(labels ...interface{}) (*DataFrame, error) {
+	keep, err := df.index.keepPositions(labels)
+	if err != nil {
+		return nil, err
+	}
+	return df.takeRows(keep), nil
+}
+
+// Rename renames columns according to the mapping. Mapping entries for
+// columns that do not exist are ignored.
 func (df *DataFrame) Rename(mapping map[string]string) *DataFrame {
 	newCols := make([]string, len(df.columns))
 	newData := make(map[string]*Series)
@@ -168,90 +503,289 @@ func (df *DataFrame) Rename(mapping map[string]string) *DataFrame {
 	return &DataFrame{columns: newCols, data: newData, index: df.index.Copy(), shape: [2]int{df.shape[0], len(newCols)}}
 }
 
+// NullsPosition defines where NA values are placed when sorting.
+type NullsPosition int
+
+const (
+	// NullsLast places NA values after all other values
+	NullsLast NullsPosition = iota
+	// NullsFirst places NA values before all other values
+	NullsFirst
+)
+
+// SortOptions defines options for sorting a DataFrame.
+type SortOptions struct {
+	Nulls NullsPosition // position of NA values regardless of sort order
+}
+
 // SortBy sorts the DataFrame by a column.
-func (df *DataFrame) SortBy(column string, order SortOrder) *DataFrame {
-	s, ok := df.data[column]
-	if !ok {
-		return df
-	}
+func (df *DataFrame) SortBy(column string, order SortOrder, opts ...SortOptions) (*DataFrame, error) {
+	return df.SortByColumns([]string{column}, []SortOrder{order}, opts...)
+}
 
-	type indexedValue struct {
-		index int
-		value interface{}
+// SortByColumns sorts the DataFrame by several columns in turn, each with its
+// own order. An empty orders slice sorts every column ascending. The sort is
+// stable, so rows with equal keys keep their relative order, and index
+// labels stay attached to their rows.
+func (df *DataFrame) SortByColumns(columns []string, orders []SortOrder, opts ...SortOptions) (*DataFrame, error) {
+	var opt SortOptions
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
-	indexed := make([]indexedValue, df.shape[0])
-	for i := 0; i < df.shape[0]; i++ {
-		indexed[i] = indexedValue{i, s.data[i]}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no sort columns specified")
 	}
-
-	sort.Slice(indexed, func(i, j int) bool {
-		vi, vj := indexed[i].value, indexed[j].value
-		if vi == nil && vj == nil {
-			return false
-		}
-		if vi == nil {
-			return order == Descending
-		}
-		if vj == nil {
-			return order == Ascending
+	if len(orders) != 0 && len(orders) != len(columns) {
+		return nil, lengthMismatch(len(columns), len(orders), "orders length %d does not match columns length %d", len(orders), len(columns))
+	}
+	keys := make([]*Series, len(columns))
+	for i, col := range columns {
+		s, ok := df.data[col]
+		if !ok {
+			return nil, &ColumnNotFoundError{Column: col}
 		}
-		fi, erri := toFloat64(vi)
-		fj, errj := toFloat64(vj)
-		if erri == nil && errj == nil {
-			if order == Ascending {
-				return fi < fj
+		keys[i] = s
+	}
+
+	positions := make([]int, df.shape[0])
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		pi, pj := positions[i], positions[j]
+		for k, s := range keys {
+			vi, vj := s.data[pi], s.data[pj]
+			naI := s.isNA(vi)
+			naJ := s.isNA(vj)
+			if naI || naJ {
+				if naI && naJ {
+					continue
+				}
+				return naI == (opt.Nulls == NullsFirst)
 			}
-			return fi > fj
-		}
-		if order == Ascending {
-			return fmt.Sprintf("%v", vi) < fmt.Sprintf("%v", vj)
+			c := compareValues(vi, vj)
+			if c == 0 {
+				continue
+			}
+			if len(orders) > 0 && orders[k] == Descending {
+				return c > 0
+			}
+			return c < 0
 		}
-		return fmt.Sprintf("%v", vi) > fmt.Sprintf("%v", vj)
+		return false
 	})
 
-	newDF := df.Copy()
-	newIndexLabels := make([]interface{}, df.shape[0])
-	for i, iv := range indexed {
-		label, _ := df.index.Get(iv.index)
-		newIndexLabels[i] = label
-	}
-	newDF.index = NewIndex(newIndexLabels, df.index.Name())
-	for _, col := range df.columns {
-		newData := make([]interface{}, df.shape[0])
-		for i, iv := range indexed {
-			newData[i] = df.data[col].data[iv.index]
-		}
-		newDF.data[col] = NewSeriesWithIndex(newData, col, newDF.index)
+	return df.takeRows(positions), nil
+}
+
+// Round rounds the selected columns to the given number of decimals, see
+// Series.Round. Without columns all numeric columns are rounded; other
+// columns are passed through unchanged.
+func (df *DataFrame) Round(decimals int, columns ...string) (*DataFrame, error) {
+	return df.transformColumns(columns, func(s *Series) *Series { return s.Round(decimals) })
+}
+
+// Abs takes the absolute value of the selected columns, see Series.Abs.
+// Without columns all numeric columns are transformed; other columns are
+// passed through unchanged.
+func (df *DataFrame) Abs(columns ...string) (*DataFrame, error) {
+	return df.transformColumns(columns, (*Series).Abs)
+}
+
+// Clip limits the selected columns to [lower, upper], see Series.Clip.
+// Without columns all numeric columns are clipped; other columns are passed
+// through unchanged.
+func (df *DataFrame) Clip(lower, upper interface{}, columns ...string) (*DataFrame, error) {
+	if _, _, err := clipBounds(lower, upper); err != nil {
+		return nil, err
 	}
-	return newDF
+	return df.transformColumns(columns, func(s *Series) *Series {
+		clipped, _ := s.Clip(lower, upper)
+		return clipped
+	})
 }
 
 // Describe returns a statistical summary of numeric columns.
+// Columns without any numeric values are skipped.
 func (df *DataFrame) Describe() *DataFrame {
+	summaries := make([]SeriesSummary, len(df.columns))
+	for i, col := range df.columns {
+		summaries[i] = df.data[col].Describe()
+	}
+	return describeFrame(summaries)
+}
+
+// describeFrame lays out the summaries of numeric columns as Describe
+// returns them: one row per column, indexed by column name, with the
+// columns count, mean, std, min and max.
+func describeFrame(summaries []SeriesSummary) *DataFrame {
 	stats := []string{"count", "mean", "std", "min", "max"}
 	colData := make(map[string][]interface{})
 	for _, stat := range stats {
-		colData[stat] = make([]interface{}, 0, len(df.columns))
+		colData[stat] = make([]interface{}, 0, len(summaries))
 	}
 
 	var statIndex []interface{}
+	for _, summary := range summaries {
+		if !summary.Numeric {
+			continue
+		}
+
+		colData["count"] = append(colData["count"], float64(summary.Count))
+		colData["mean"] = append(colData["mean"], summary.Mean)
+		colData["std"] = append(colData["std"], summary.Std)
+		colData["min"] = append(colData["min"], summary.Min)
+		colData["max"] = append(colData["max"], summary.Max)
+		statIndex = append(statIndex, summary.Name)
+	}
+
+	index := NewIndex(statIndex, "column")
+	seriesMap := make(map[string]*Series, len(stats))
+	for _, stat := range stats {
+		seriesMap[stat] = NewSeries(colData[stat], stat)
+		seriesMap[stat].index = index.Copy()
+	}
+	return &DataFrame{
+		columns: stats,
+		data:    seriesMap,
+		index:   index,
+		shape:   [2]int{len(statIndex), len(stats)},
+	}
+}
+
+// Any reduces the DataFrame with Series.Any. With axis 0 the result has one
+// value per column, indexed by column name; with axis 1 it has one value
+// per row, indexed by the row labels. Columns holding values that cannot be
+// converted to bool are skipped.
+func (df *DataFrame) Any(axis int) *Series {
+	return df.reduceBool(axis, "any", (*Series).Any)
+}
+
+// All reduces the DataFrame with Series.All. With axis 0 the result has one
+// value per column, indexed by column name; with axis 1 it has one value
+// per row, indexed by the row labels. Columns holding values that cannot be
+// converted to bool are skipped.
+func (df *DataFrame) All(axis int) *Series {
+	return df.reduceBool(axis, "all", (*Series).All)
+}
+
+// reduceBool applies reduce to each bool-convertible column (axis 0) or to
+// each row of those columns (axis 1).
+func (df *DataFrame) reduceBool(axis int, name string, reduce func(*Series) bool) *Series {
+	var cols []*Series
 	for _, col := range df.columns {
 		s := df.data[col]
-		count := float64(s.Count())
-		mean := s.Mean()
-		std := s.Std()
-		min := s.Min()
-		max := s.Max()
-
-		colData["count"] = append(colData["count"], count)
-		colData["mean"] = append(colData["mean"], mean)
-		colData["std"] = append(colData["std"], std)
-		colData["min"] = append(colData["min"], min)
-		colData["max"] = append(colData["max"], max)
-		statIndex = append(statIndex, col)
-	}
-
-	dfStats, _ := New(colData)
-	dfStats.index = NewIndex(statIndex, "column")
-	return dfStats
+		convertible := true
+		for _, v := range s.data {
+			if _, ok := truthValue(v); !ok && v != nil {
+				convertible = false
+				break
+			}
+		}
+		if convertible {
+			cols = append(cols, s)
+		}
+	}
+
+	if axis == 1 {
+		data := make([]interface{}, df.shape[0])
+		row := &Series{data: make([]interface{}, len(cols))}
+		for i := range data {
+			for j, s := range cols {
+				row.data[j] = s.data[i]
+			}
+			data[i] = reduce(row)
+		}
+		return &Series{name: name, data: data, dtype: DTypeBool, index: df.index.Copy()}
+	}
+
+	data := make([]interface{}, len(cols))
+	labels := make([]interface{}, len(cols))
+	for i, s := range cols {
+		data[i] = reduce(s)
+		labels[i] = s.name
+	}
+	return &Series{name: name, data: data, dtype: DTypeBool, index: NewIndex(labels, "")}
+}
+
+// WhereDF keeps the values of df where cond is true and replaces the others
+// with other: a scalar, a func(interface{}) interface{} called with the
+// original value as in Series.Where, or a DataFrame. cond, and other when it
+// is a DataFrame, must have the columns and the index of df; columns are
+// matched by name. NA values in cond count as false.
+func (df *DataFrame) WhereDF(cond *DataFrame, other interface{}) (*DataFrame, error) {
+	return df.whereDF(cond, other, false)
+}
+
+// MaskDF is the inverse of WhereDF: it replaces the values where cond is
+// true. NA values in cond count as false, so unlike Series.Mask the values
+// they mark are kept.
+func (df *DataFrame) MaskDF(cond *DataFrame, other interface{}) (*DataFrame, error) {
+	return df.whereDF(cond, other, true)
+}
+
+func (df *DataFrame) whereDF(cond *DataFrame, other interface{}, invert bool) (*DataFrame, error) {
+	if cond == nil {
+		return nil, fmt.Errorf("condition DataFrame is nil")
+	}
+	if err := df.checkAligned(cond, "condition"); err != nil {
+		return nil, err
+	}
+	otherDF, isDF := other.(*DataFrame)
+	if isDF {
+		if err := df.checkAligned(otherDF, "other"); err != nil {
+			return nil, err
+		}
+	} else if _, ok := other.(*Series); ok {
+		return nil, fmt.Errorf("other must be a scalar, a function or a DataFrame, got *Series")
+	}
+
+	seriesMap := make(map[string]*Series, len(df.columns))
+	for _, col := range df.columns {
+		c := cond.data[col]
+		keep := make([]interface{}, len(c.data))
+		for i, v := range c.data {
+			if c.isNA(v) {
+				keep[i] = invert
+				continue
+			}
+			b, err := toBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("condition column '%s' element %d: %w", col, i, err)
+			}
+			keep[i] = b != invert
+		}
+		replacement := other
+		if isDF {
+			replacement = otherDF.data[col]
+		}
+		result, err := df.data[col].where(&Series{data: keep, dtype: DTypeBool}, replacement, false)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", col, err)
+		}
+		seriesMap[col] = result
+	}
+	cols := make([]string, len(df.columns))
+	copy(cols, df.columns)
+	return &DataFrame{columns: cols, data: seriesMap, index: df.index.Copy(), shape: df.shape}, nil
+}
+
+// checkAligned reports an error unless other has the rows, columns and index
+// of df. what names other in the message.
+func (df *DataFrame) checkAligned(other *DataFrame, what string) error {
+	if other.shape[0] != df.shape[0] {
+		return lengthMismatch(df.shape[0], other.shape[0], "%s rows %d do not match dataframe rows %d", what, other.shape[0], df.shape[0])
+	}
+	if other.shape[1] != df.shape[1] {
+		return fmt.Errorf("%s has %d columns, dataframe has %d", what, other.shape[1], df.shape[1])
+	}
+	for _, col := range df.columns {
+		if _, ok := other.data[col]; !ok {
+			return fmt.Errorf("%s: %w", what, &ColumnNotFoundError{Column: col})
+		}
+	}
+	if !other.index.Equals(df.index) {
+		return fmt.Errorf("%s index does not match dataframe index", what)
+	}
+	return nil
 }
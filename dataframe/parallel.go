@@ -1,14 +1,32 @@
 package dataframe
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"runtime"
+	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
 )
 
 // ParallelOptions defines options for parallel operations
 type ParallelOptions struct {
-	NumWorkers int  // number of goroutines to use (0 = auto)
-	ChunkSize  int  // minimum chunk size per worker
+	NumWorkers   int   // number of goroutines to use (0 = auto)
+	ChunkSize    int   // minimum chunk size per worker
+	Pool         *Pool // run the tasks on these workers instead of new goroutines
+	AllErrors    bool  // Try operations: report every failure instead of the first
+	AllowPartial bool  // ParallelReadCSV: return the frames read alongside the errors
+	SkipNA       bool  // ParallelReduce: do not pass NA values to fn
+
+	// Hooks for the parallel operations except GroupByParallel, called one
+	// at a time from a single goroutine: OnProgress with the number of
+	// values, columns or files done after each chunk completes,
+	// OnWorkerError with the error a chunk's worker stopped on.
+	OnProgress    func(done, total int)
+	OnWorkerError func(worker int, err error)
 }
 
 // DefaultParallelOptions returns default parallel options
@@ -19,13 +37,128 @@ func DefaultParallelOptions() ParallelOptions {
 	}
 }
 
+// PanicError reports a panic in a function run by a parallel operation.
+// Try operations return it as an error; the other operations panic with it
+// in the calling goroutine, where it can be recovered, rather than letting
+// the panic crash the program from a worker goroutine.
+type PanicError struct {
+	Position int         // element, row or column being processed, -1 if unknown
+	Value    interface{} // value passed to panic
+	Stack    []byte      // stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("panic in parallel worker: %v", e.Value)
+	}
+	return fmt.Sprintf("panic at position %d: %v", e.Position, e.Value)
+}
+
+// workerPanic keeps the first panic of the workers of a parallel operation.
+type workerPanic struct {
+	once sync.Once
+	err  *PanicError
+}
+
+// catch records a panic of the calling worker. It must be deferred directly
+// by the worker; pos points to the position the worker is processing.
+func (p *workerPanic) catch(pos *int) {
+	if r := recover(); r != nil {
+		p.once.Do(func() {
+			p.err = &PanicError{Position: *pos, Value: r, Stack: debug.Stack()}
+		})
+	}
+}
+
+// repanic raises the recorded panic, if any. It is called once all workers
+// have returned.
+func (p *workerPanic) repanic() {
+	if p.err != nil {
+		panic(p.err)
+	}
+}
+
+// safeCall calls fn(pos), returning a panic in fn as a *PanicError.
+func safeCall(pos int, fn func(int) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Position: pos, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(pos)
+}
+
+// positionError is an error of a Try operation at a position.
+type positionError struct {
+	pos int
+	err error
+}
+
+// tryChunked calls fn for every position in [0, n), splitting the positions
+// among workers like runChunks. Panics in fn are returned as *PanicError.
+// Unless opts.AllErrors is set, each range stops at its first error and only
+// the error at the lowest position is returned; otherwise every error is
+// returned. Errors are ordered by position.
+func tryChunked(opts ParallelOptions, n, workers int, fn func(i int) error) []positionError {
+	var mu sync.Mutex
+	var errs []positionError
+	runChunks(opts, n, workers, func(_, start, end int) error {
+		var found []error
+		for i := start; i < end; i++ {
+			if err := safeCall(i, fn); err != nil {
+				mu.Lock()
+				errs = append(errs, positionError{pos: i, err: err})
+				mu.Unlock()
+				found = append(found, err)
+				if !opts.AllErrors {
+					break
+				}
+			}
+		}
+		if len(found) == 0 {
+			return nil
+		}
+		return joinErrors(found)
+	})
+	sort.Slice(errs, func(a, b int) bool { return errs[a].pos < errs[b].pos })
+	if !opts.AllErrors && len(errs) > 1 {
+		errs = errs[:1]
+	}
+	return errs
+}
+
+// joinPositionErrors wraps the errors of a Try operation with describe and
+// joins them. A *PanicError already names its position and is kept as is.
+func joinPositionErrors(errs []positionError, describe func(pos int, err error) error) error {
+	wrapped := make([]error, len(errs))
+	for i, e := range errs {
+		if pe, ok := e.err.(*PanicError); ok {
+			wrapped[i] = pe
+		} else {
+			wrapped[i] = describe(e.pos, e.err)
+		}
+	}
+	return joinErrors(wrapped)
+}
+
+// joinErrors joins errs, returning a single error unchanged.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
 // getNumWorkers returns the number of workers to use
 func getNumWorkers(opts ParallelOptions, dataSize int) int {
 	if opts.NumWorkers > 0 {
 		return opts.NumWorkers
 	}
-	// Use number of CPUs, but limit based on data size
+	// Use number of CPUs, or of pool workers, but limit based on data size
 	numCPU := runtime.NumCPU()
+	if opts.Pool != nil {
+		numCPU = opts.Pool.Size()
+	}
 	minChunk := opts.ChunkSize
 	if minChunk <= 0 {
 		minChunk = 1000
@@ -40,7 +173,19 @@ func getNumWorkers(opts ParallelOptions, dataSize int) int {
 	return maxWorkers
 }
 
-// ParallelApply applies a function to each element of a Series in parallel
+// applyWorkers returns the number of workers for an element-wise operation
+// over n values. When ChunkSize is set it is reduced as needed so that no
+// worker gets fewer than ChunkSize values, also with NumWorkers given.
+func applyWorkers(opts ParallelOptions, n int) int {
+	workers := getNumWorkers(opts, n)
+	if opts.ChunkSize > 0 {
+		workers = min(workers, max(n/opts.ChunkSize, 1))
+	}
+	return workers
+}
+
+// ParallelApply applies a function to each element of a Series in parallel.
+// Each worker gets a contiguous chunk of at least ChunkSize values.
 func (s *Series) ParallelApply(fn func(interface{}) interface{}, opts ...ParallelOptions) *Series {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
@@ -52,135 +197,344 @@ func (s *Series) ParallelApply(fn func(interface{}) interface{}, opts ...Paralle
 		return NewSeries([]interface{}{}, s.name)
 	}
 
-	numWorkers := getNumWorkers(opt, n)
-	if numWorkers <= 1 {
-		return s.Apply(fn)
+	result := make([]interface{}, n)
+	var wp workerPanic
+	runChunks(opt, n, applyWorkers(opt, n), func(_, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		for i = start; i < end; i++ {
+			result[i] = fn(s.data[i])
+		}
+		return nil
+	})
+	wp.repanic()
+
+	return &Series{
+		name:  s.name,
+		data:  result,
+		dtype: InferDTypeFromSlice(result),
+		index: s.index.Copy(),
+		na:    s.na,
 	}
+}
 
-	result := make([]interface{}, n)
-	chunkSize := (n + numWorkers - 1) / numWorkers
+// ParallelApplyStats is ParallelApply that also returns how it ran.
+func (s *Series) ParallelApplyStats(fn func(interface{}) interface{}, opts ...ParallelOptions) (*Series, Stats) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
+	start := time.Now()
+	result := s.ParallelApply(fn, opt)
+	workers := applyWorkers(opt, s.Len())
+	return result, Stats{
+		Elapsed: time.Since(start),
+		Workers: workers,
+		Chunks:  chunkCount(s.Len(), workers),
+	}
+}
 
-	for w := 0; w < numWorkers; w++ {
-		start := w * chunkSize
-		end := start + chunkSize
-		if end > n {
-			end = n
-		}
-		if start >= n {
-			wg.Done()
-			continue
-		}
+// canceled reports whether ctx is done, without blocking. Workers call it
+// before every element, so that a slow callback delays a cancellation by at
+// most one call.
+func canceled(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
 
-		go func(start, end int) {
-			defer wg.Done()
-			for i := start; i < end; i++ {
-				result[i] = fn(s.data[i])
-			}
-		}(start, end)
+// ParallelApplyCtx is ParallelApply that stops once ctx is done. Workers
+// check ctx while they run; on cancellation all of them have returned by the
+// time ctx.Err() is returned.
+func (s *Series) ParallelApplyCtx(ctx context.Context, fn func(interface{}) interface{}, opts ...ParallelOptions) (*Series, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
 
-	wg.Wait()
+	n := s.Len()
+	result := make([]interface{}, n)
+	err := runChunkedCtx(ctx, opt, n, applyWorkers(opt, n), func(start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+			result[i] = fn(s.data[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &Series{
 		name:  s.name,
 		data:  result,
-		dtype: DTypeObject,
+		dtype: InferDTypeFromSlice(result),
 		index: s.index.Copy(),
+		na:    s.na,
+	}, nil
+}
+
+// ParallelTryApply applies a function that may fail to each element of a
+// Series in parallel. Each worker stops at its first error; the error with
+// the lowest position is returned. With AllErrors set, every element is
+// processed and all errors are returned, joined in position order. A panic
+// in fn is returned as a *PanicError.
+func (s *Series) ParallelTryApply(fn func(interface{}) (interface{}, error), opts ...ParallelOptions) (*Series, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
+
+	n := s.Len()
+	if n == 0 {
+		return NewSeries([]interface{}{}, s.name), nil
+	}
+
+	result := make([]interface{}, n)
+	errs := tryChunked(opt, n, applyWorkers(opt, n), func(i int) error {
+		v, err := fn(s.data[i])
+		result[i] = v
+		return err
+	})
+	if len(errs) > 0 {
+		return nil, joinPositionErrors(errs, func(pos int, err error) error {
+			return fmt.Errorf("apply failed at position %d: %w", pos, err)
+		})
+	}
+
+	return &Series{
+		name:  s.name,
+		data:  result,
+		dtype: InferDTypeFromSlice(result),
+		index: s.index.Copy(),
+		na:    s.na,
+	}, nil
 }
 
-// ParallelFilter filters the DataFrame using parallel processing
-func (df *DataFrame) ParallelFilter(fn FilterFunc, opts ...ParallelOptions) *DataFrame {
+// ParallelReduce is Reduce with the Series split into chunks that are reduced
+// concurrently, each starting from init, and whose results are merged in
+// order with combine. It returns the same as Reduce only when fn and combine
+// are associative and init is an identity for combine, such as 0 for a sum.
+// init is shared by all chunks, so a mutable accumulator like a map should be
+// created by fn on the first value rather than passed as init. With SkipNA,
+// NA values are not passed to fn.
+func (s *Series) ParallelReduce(init interface{}, fn func(acc, v interface{}) interface{}, combine func(a, b interface{}) interface{}, opts ...ParallelOptions) interface{} {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
 
-	n := df.shape[0]
+	n := s.Len()
 	if n == 0 {
-		return df.Copy()
+		return init
 	}
 
 	numWorkers := getNumWorkers(opt, n)
-	if numWorkers <= 1 {
-		return df.Filter(fn)
-	}
+	partials := make([]interface{}, chunkCount(n, numWorkers))
+	var wp workerPanic
+	runChunks(opt, n, numWorkers, func(chunk, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		acc := init
+		for i = start; i < end; i++ {
+			if v := s.data[i]; !opt.SkipNA || !s.isNA(v) {
+				acc = fn(acc, v)
+			}
+		}
+		partials[chunk] = acc
+		return nil
+	})
+	wp.repanic()
 
-	// Each worker collects matching indices
-	type result struct {
-		indices []int
+	result := partials[0]
+	for _, p := range partials[1:] {
+		result = combine(result, p)
 	}
+	return result
+}
 
-	chunkSize := (n + numWorkers - 1) / numWorkers
-	results := make([]result, numWorkers)
+// ParallelValueCounts is ValueCounts with the values counted concurrently in
+// chunks whose counts are then merged. The result is the same as that of
+// ValueCounts, which is used for Series of up to 131072 values.
+func (s *Series) ParallelValueCounts(opts ...ParallelOptions) *Series {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	workers, ok := countWorkers(opt, s.Len())
+	if !ok {
+		return s.ValueCounts()
+	}
+	return tallyValuesParallel(opt, s.data, workers).countsSeries(s.name)
+}
 
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
+// ParallelUnique is Unique with the values deduplicated concurrently in
+// chunks that are then merged in order. The result is the same as that of
+// Unique, which is used for Series of up to 131072 values.
+func (s *Series) ParallelUnique(opts ...ParallelOptions) *Series {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	workers, ok := countWorkers(opt, s.Len())
+	if !ok {
+		return s.Unique()
+	}
+	return tallyValuesParallel(opt, s.data, workers).uniqueSeries(s.name)
+}
 
-	for w := 0; w < numWorkers; w++ {
-		start := w * chunkSize
-		end := start + chunkSize
-		if end > n {
-			end = n
-		}
-		if start >= n {
-			wg.Done()
-			continue
-		}
+// ParallelFilter filters the DataFrame using parallel processing.
+// The result is the same as Filter, including index labels and dtypes.
+func (df *DataFrame) ParallelFilter(fn FilterFunc, opts ...ParallelOptions) *DataFrame {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
 
-		go func(w, start, end int) {
-			defer wg.Done()
-			var indices []int
-			for i := start; i < end; i++ {
-				row, _ := df.Row(i)
-				if fn(row) {
-					indices = append(indices, i)
-				}
-			}
-			results[w].indices = indices
-		}(w, start, end)
+	n := df.shape[0]
+	if n == 0 {
+		return df.Copy()
 	}
 
-	wg.Wait()
+	// Each chunk collects its matching indices
+	numWorkers := getNumWorkers(opt, n)
+	results := make([][]int, chunkCount(n, numWorkers))
+	var wp workerPanic
+	runChunks(opt, n, numWorkers, func(chunk, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		var indices []int
+		for i = start; i < end; i++ {
+			if fn(Row{df: df, pos: i}) {
+				indices = append(indices, i)
+			}
+		}
+		results[chunk] = indices
+		return nil
+	})
+	wp.repanic()
 
 	// Collect all matching indices
 	var allIndices []int
-	for _, r := range results {
-		allIndices = append(allIndices, r.indices...)
+	for _, indices := range results {
+		allIndices = append(allIndices, indices...)
+	}
+	return df.takeRows(allIndices)
+}
+
+// ParallelFilterCtx is ParallelFilter that stops once ctx is done, see
+// ParallelApplyCtx.
+func (df *DataFrame) ParallelFilterCtx(ctx context.Context, fn FilterFunc, opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
 
-	if len(allIndices) == 0 {
-		return &DataFrame{
-			columns: df.columns,
-			data:    map[string]*Series{},
-			index:   NewRangeIndex(0),
-			shape:   [2]int{0, len(df.columns)},
+	n := df.shape[0]
+	matches := make([]bool, n)
+	err := runChunkedCtx(ctx, opt, n, getNumWorkers(opt, n), func(start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+			matches[i] = fn(Row{df: df, pos: i})
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Build result DataFrame
-	seriesMap := make(map[string]*Series)
-	for _, col := range df.columns {
-		s := df.data[col]
-		newData := make([]interface{}, len(allIndices))
-		for i, idx := range allIndices {
-			newData[i], _ = s.Get(idx)
+	var rows []int
+	for i, ok := range matches {
+		if ok {
+			rows = append(rows, i)
 		}
-		seriesMap[col] = NewSeries(newData, col)
 	}
+	return df.takeRows(rows), nil
+}
 
-	cols := make([]string, len(df.columns))
-	copy(cols, df.columns)
+// ParallelTryFilter is ParallelFilter for a predicate that may fail. Errors
+// are reported with their row positions as in ParallelTryApply.
+func (df *DataFrame) ParallelTryFilter(fn func(Row) (bool, error), opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
 
-	return &DataFrame{
-		columns: cols,
-		data:    seriesMap,
-		index:   NewRangeIndex(len(allIndices)),
-		shape:   [2]int{len(allIndices), len(cols)},
+	n := df.shape[0]
+	matches := make([]bool, n)
+	errs := tryChunked(opt, n, getNumWorkers(opt, n), func(i int) error {
+		ok, err := fn(Row{df: df, pos: i})
+		matches[i] = ok
+		return err
+	})
+	if len(errs) > 0 {
+		return nil, joinPositionErrors(errs, func(pos int, err error) error {
+			return fmt.Errorf("filter failed at position %d: %w", pos, err)
+		})
+	}
+
+	var rows []int
+	for i, ok := range matches {
+		if ok {
+			rows = append(rows, i)
+		}
 	}
+	return df.takeRows(rows), nil
+}
+
+// ParallelApplyRows is ApplyRows with the rows split among workers, each
+// writing the results of its rows into a shared slice. fn must be safe for
+// concurrent use; the result is the same as that of ApplyRows.
+func (df *DataFrame) ParallelApplyRows(fn func(Row) interface{}, newColumn string, opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	n := df.shape[0]
+	values := make([]interface{}, n)
+	var wp workerPanic
+	runChunks(opt, n, getNumWorkers(opt, n), func(_, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		for i = start; i < end; i++ {
+			values[i] = fn(Row{df: df, pos: i})
+		}
+		return nil
+	})
+	wp.repanic()
+	return df.withRowValues(values, newColumn)
+}
+
+// ParallelTryApplyRows is ParallelApplyRows for a function that may fail.
+// Errors are reported with their row positions as in ParallelTryApply.
+func (df *DataFrame) ParallelTryApplyRows(fn func(Row) (interface{}, error), newColumn string, opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	n := df.shape[0]
+	values := make([]interface{}, n)
+	errs := tryChunked(opt, n, getNumWorkers(opt, n), func(i int) error {
+		v, err := fn(Row{df: df, pos: i})
+		values[i] = v
+		return err
+	})
+	if len(errs) > 0 {
+		return nil, joinPositionErrors(errs, func(pos int, err error) error {
+			return fmt.Errorf("apply failed at position %d: %w", pos, err)
+		})
+	}
+	return df.withRowValues(values, newColumn)
 }
 
 // ParallelTransform applies a transformation function to each column in parallel
@@ -195,47 +549,105 @@ func (df *DataFrame) ParallelTransform(fn func(*Series) *Series, opts ...Paralle
 		return df.Copy()
 	}
 
-	numWorkers := getNumWorkers(opt, numCols)
-	if numWorkers > numCols {
-		numWorkers = numCols
+	transformed := make([]*Series, numCols)
+	var wp workerPanic
+	runChunks(opt, numCols, min(getNumWorkers(opt, numCols), numCols), func(_, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		for i = start; i < end; i++ {
+			transformed[i] = fn(df.data[df.columns[i]])
+		}
+		return nil
+	})
+	wp.repanic()
+
+	cols := make([]string, numCols)
+	copy(cols, df.columns)
+	resultSeries := make(map[string]*Series, numCols)
+	for i, col := range cols {
+		resultSeries[col] = transformed[i]
+	}
+	return &DataFrame{
+		columns: cols,
+		data:    resultSeries,
+		index:   df.index.Copy(),
+		shape:   df.shape,
 	}
+}
 
-	resultSeries := make(map[string]*Series)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	// Create channel for columns to process
-	colChan := make(chan string, numCols)
-	for _, col := range df.columns {
-		colChan <- col
-	}
-	close(colChan)
-
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for col := range colChan {
-				s := df.data[col]
-				transformed := fn(s)
-				mu.Lock()
-				resultSeries[col] = transformed
-				mu.Unlock()
-			}
-		}()
+// ParallelTransformCtx is ParallelTransform that stops once ctx is done.
+// ctx is checked before each column is transformed.
+func (df *DataFrame) ParallelTransformCtx(ctx context.Context, fn func(*Series) *Series, opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
 
-	wg.Wait()
+	numCols := len(df.columns)
+	transformed := make([]*Series, numCols)
+	err := runChunkedCtx(ctx, opt, numCols, min(getNumWorkers(opt, numCols), numCols), func(start, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			transformed[i] = fn(df.data[df.columns[i]])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	cols := make([]string, len(df.columns))
+	cols := make([]string, numCols)
 	copy(cols, df.columns)
-
+	resultSeries := make(map[string]*Series, numCols)
+	for i, col := range cols {
+		resultSeries[col] = transformed[i]
+	}
 	return &DataFrame{
 		columns: cols,
 		data:    resultSeries,
 		index:   df.index.Copy(),
 		shape:   df.shape,
+	}, nil
+}
+
+// ParallelTryTransform is ParallelTransform for a transformation that may
+// fail. Errors name the failing column; see ParallelTryApply for AllErrors
+// and panics.
+func (df *DataFrame) ParallelTryTransform(fn func(*Series) (*Series, error), opts ...ParallelOptions) (*DataFrame, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	numCols := len(df.columns)
+	transformed := make([]*Series, numCols)
+	errs := tryChunked(opt, numCols, min(getNumWorkers(opt, numCols), numCols), func(i int) error {
+		s, err := fn(df.data[df.columns[i]])
+		transformed[i] = s
+		return err
+	})
+	if len(errs) > 0 {
+		wrapped := make([]error, len(errs))
+		for i, e := range errs {
+			wrapped[i] = fmt.Errorf("transform failed for column '%s': %w", df.columns[e.pos], e.err)
+		}
+		return nil, joinErrors(wrapped)
 	}
+
+	cols := make([]string, numCols)
+	copy(cols, df.columns)
+	resultSeries := make(map[string]*Series, numCols)
+	for i, col := range cols {
+		resultSeries[col] = transformed[i]
+	}
+	return &DataFrame{
+		columns: cols,
+		data:    resultSeries,
+		index:   df.index.Copy(),
+		shape:   df.shape,
+	}, nil
 }
 
 // ParallelSum computes sum for all numeric columns in parallel
@@ -248,6 +660,28 @@ func (df *DataFrame) ParallelMean(opts ...ParallelOptions) map[string]float64 {
 	return df.parallelAggFloat64(func(s *Series) float64 { return s.Mean() }, opts...)
 }
 
+// ParallelSumOpt is ParallelSum with NA handling controlled by aggOpts.
+// Columns that do not satisfy aggOpts sum to NaN.
+func (df *DataFrame) ParallelSumOpt(aggOpts AggOptions, opts ...ParallelOptions) map[string]float64 {
+	return df.parallelAggFloat64(func(s *Series) float64 {
+		if aggOpts.naResult(s) {
+			return math.NaN()
+		}
+		return s.Sum()
+	}, opts...)
+}
+
+// ParallelMeanOpt is ParallelMean with NA handling controlled by aggOpts.
+// Columns that do not satisfy aggOpts average to NaN.
+func (df *DataFrame) ParallelMeanOpt(aggOpts AggOptions, opts ...ParallelOptions) map[string]float64 {
+	return df.parallelAggFloat64(func(s *Series) float64 {
+		if aggOpts.naResult(s) {
+			return math.NaN()
+		}
+		return s.Mean()
+	}, opts...)
+}
+
 // ParallelMin computes minimum for all numeric columns in parallel
 func (df *DataFrame) ParallelMin(opts ...ParallelOptions) map[string]interface{} {
 	return df.parallelAggInterface(func(s *Series) interface{} { return s.Min() }, opts...)
@@ -258,209 +692,173 @@ func (df *DataFrame) ParallelMax(opts ...ParallelOptions) map[string]interface{}
 	return df.parallelAggInterface(func(s *Series) interface{} { return s.Max() }, opts...)
 }
 
-// parallelAggFloat64 applies an aggregation function to all columns in parallel
-func (df *DataFrame) parallelAggFloat64(fn func(*Series) float64, opts ...ParallelOptions) map[string]float64 {
+// ParallelDescribe is Describe with the columns summarized concurrently,
+// whole columns per worker, each in a single pass over its values. It has
+// the same layout and skips the same columns as Describe; the statistics
+// may differ from those of Describe in the last digits. It is faster than
+// Describe on a single worker as well, since it does not sort the values,
+// and is the better choice for frames above about a million rows.
+func (df *DataFrame) ParallelDescribe(opts ...ParallelOptions) *DataFrame {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
 
 	numCols := len(df.columns)
-	if numCols == 0 {
-		return make(map[string]float64)
-	}
+	summaries := make([]SeriesSummary, numCols)
+	runChunks(opt, numCols, df.columnWorkers(opt), func(_, start, end int) error {
+		for c := start; c < end; c++ {
+			summaries[c] = df.data[df.columns[c]].describeMoments()
+		}
+		return nil
+	})
+	return describeFrame(summaries)
+}
 
-	numWorkers := getNumWorkers(opt, numCols)
-	if numWorkers > numCols {
-		numWorkers = numCols
-	}
+// columnWorkers returns the number of workers for an operation that gives
+// each worker whole columns, sized for the number of cells of the frame.
+func (df *DataFrame) columnWorkers(opt ParallelOptions) int {
+	numCols := len(df.columns)
+	return min(getNumWorkers(opt, df.shape[0]*numCols), max(numCols, 1))
+}
 
-	result := make(map[string]float64)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
+// ParallelSumSeries is ParallelSum returning a float64 Series indexed by
+// column name in column order, with nil for columns without numeric values,
+// which ParallelSum reports as summing to 0.
+func (df *DataFrame) ParallelSumSeries(opts ...ParallelOptions) *Series {
+	return df.parallelAggSeries("sum", func(s *Series) interface{} {
+		values, _ := s.numericValues(false)
+		if len(values) == 0 {
+			return nil
+		}
+		return sumFloat64s(values)
+	}, opts...)
+}
 
-	colChan := make(chan string, numCols)
-	for _, col := range df.columns {
-		colChan <- col
-	}
-	close(colChan)
+// ParallelMeanSeries is ParallelMean returning a Series like
+// ParallelSumSeries, with nil for columns without numeric values.
+func (df *DataFrame) ParallelMeanSeries(opts ...ParallelOptions) *Series {
+	return df.parallelAggSeries("mean", func(s *Series) interface{} {
+		values, _ := s.numericValues(false)
+		if len(values) == 0 {
+			return nil
+		}
+		return meanFloat64s(values)
+	}, opts...)
+}
 
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for col := range colChan {
-				s := df.data[col]
-				val := fn(s)
-				mu.Lock()
-				result[col] = val
-				mu.Unlock()
-			}
-		}()
+// ParallelMinSeries is ParallelMin returning a Series like
+// ParallelSumSeries, with nil for columns without numeric values.
+func (df *DataFrame) ParallelMinSeries(opts ...ParallelOptions) *Series {
+	return df.parallelAggSeries("min", (*Series).Min, opts...)
+}
+
+// ParallelMaxSeries is ParallelMax returning a Series like
+// ParallelSumSeries, with nil for columns without numeric values.
+func (df *DataFrame) ParallelMaxSeries(opts ...ParallelOptions) *Series {
+	return df.parallelAggSeries("max", (*Series).Max, opts...)
+}
+
+// parallelAggSeries applies fn to all columns in parallel and returns the
+// results as a Series named name, indexed by column name in column order.
+func (df *DataFrame) parallelAggSeries(name string, fn func(*Series) interface{}, opts ...ParallelOptions) *Series {
+	labels := make([]interface{}, len(df.columns))
+	for c, col := range df.columns {
+		labels[c] = col
 	}
+	data := df.parallelAggColumns(fn, opts...)
+	return &Series{name: name, data: data, dtype: DTypeFloat64, index: NewIndex(labels, "")}
+}
 
-	wg.Wait()
+// parallelAggFloat64 applies an aggregation function to all columns in parallel
+func (df *DataFrame) parallelAggFloat64(fn func(*Series) float64, opts ...ParallelOptions) map[string]float64 {
+	data := df.parallelAggColumns(func(s *Series) interface{} { return fn(s) }, opts...)
+	result := make(map[string]float64, len(data))
+	for c, col := range df.columns {
+		result[col] = data[c].(float64)
+	}
 	return result
 }
 
 // parallelAggInterface applies an aggregation function returning interface{} to all columns in parallel
 func (df *DataFrame) parallelAggInterface(fn func(*Series) interface{}, opts ...ParallelOptions) map[string]interface{} {
+	data := df.parallelAggColumns(fn, opts...)
+	result := make(map[string]interface{}, len(data))
+	for c, col := range df.columns {
+		result[col] = data[c]
+	}
+	return result
+}
+
+// parallelAggColumns applies fn to all columns in parallel, running the
+// columns with runChunks, and returns the results in column order.
+func (df *DataFrame) parallelAggColumns(fn func(*Series) interface{}, opts ...ParallelOptions) []interface{} {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
 
 	numCols := len(df.columns)
+	data := make([]interface{}, numCols)
 	if numCols == 0 {
-		return make(map[string]interface{})
-	}
-
-	numWorkers := getNumWorkers(opt, numCols)
-	if numWorkers > numCols {
-		numWorkers = numCols
-	}
-
-	result := make(map[string]interface{})
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	colChan := make(chan string, numCols)
-	for _, col := range df.columns {
-		colChan <- col
-	}
-	close(colChan)
-
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for col := range colChan {
-				s := df.data[col]
-				val := fn(s)
-				mu.Lock()
-				result[col] = val
-				mu.Unlock()
-			}
-		}()
-	}
-
-	wg.Wait()
-	return result
+		return data
+	}
+	var wp workerPanic
+	runChunks(opt, numCols, df.columnWorkers(opt), func(_, start, end int) error {
+		var c int
+		defer wp.catch(&c)
+		for c = start; c < end; c++ {
+			data[c] = fn(df.data[df.columns[c]])
+		}
+		return nil
+	})
+	wp.repanic()
+	return data
 }
 
-// ParallelGroupByAgg performs parallel aggregation on grouped data
+// ParallelAgg is Agg with the groups aggregated in parallel. The result is
+// identical to Agg: columns are named and ordered the same way, rows follow
+// the group order whichever worker aggregates a group, and a missing column
+// is reported as an error.
 func (gb *GroupBy) ParallelAgg(aggFuncs map[string][]AggFunc, opts ...ParallelOptions) (*DataFrame, error) {
+	return gb.ParallelAggCtx(context.Background(), aggFuncs, opts...)
+}
+
+// ParallelAggCtx is ParallelAgg that stops once ctx is done, see
+// ParallelApplyCtx.
+func (gb *GroupBy) ParallelAggCtx(ctx context.Context, aggFuncs map[string][]AggFunc, opts ...ParallelOptions) (*DataFrame, error) {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
-
-	// Validate columns
-	for col := range aggFuncs {
-		if _, ok := gb.df.data[col]; !ok {
-			return nil, nil
-		}
-	}
-
-	numGroups := len(gb.keyOrder)
-	if numGroups == 0 {
-		return New(map[string][]interface{}{})
-	}
-
-	numWorkers := getNumWorkers(opt, numGroups)
-	if numWorkers > numGroups {
-		numWorkers = numGroups
-	}
-
-	// Process groups in parallel
-	type groupResult struct {
-		keyVals []interface{}
-		aggVals map[string][]interface{}
-	}
-
-	results := make([]groupResult, numGroups)
-	chunkSize := (numGroups + numWorkers - 1) / numWorkers
-
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
-
-	for w := 0; w < numWorkers; w++ {
-		start := w * chunkSize
-		end := start + chunkSize
-		if end > numGroups {
-			end = numGroups
-		}
-		if start >= numGroups {
-			wg.Done()
-			continue
-		}
-
-		go func(start, end int) {
-			defer wg.Done()
-			for i := start; i < end; i++ {
-				groupKey := gb.keyOrder[i]
-				indices := gb.groups[groupKey]
-				if len(indices) == 0 {
-					continue
-				}
-
-				keyVals := gb.getGroupKeyValues(indices[0])
-				aggVals := make(map[string][]interface{})
-
-				for col, funcs := range aggFuncs {
-					groupSeries := gb.getGroupSeries(col, indices)
-					aggVals[col] = make([]interface{}, len(funcs))
-					for j, fn := range funcs {
-						aggVals[col][j] = fn(groupSeries)
-					}
-				}
-
-				results[i] = groupResult{keyVals: keyVals, aggVals: aggVals}
-			}
-		}(start, end)
-	}
-
-	wg.Wait()
-
-	// Collect results
-	keyData := make(map[string][]interface{})
-	for _, col := range gb.byKeys {
-		keyData[col] = make([]interface{}, 0, numGroups)
-	}
-
-	aggData := make(map[string][]interface{})
-	for col, funcs := range aggFuncs {
-		for i := range funcs {
-			aggCol := col + "_" + string(rune('0'+i))
-			aggData[aggCol] = make([]interface{}, 0, numGroups)
-		}
+	specs, err := gb.aggSpecs(aggFuncs)
+	if err != nil {
+		return nil, err
 	}
+	return gb.aggregateCtx(ctx, specs, gb.aggWorkers(opt), opt)
+}
 
-	for _, r := range results {
-		if r.keyVals == nil {
-			continue
-		}
-		for i, col := range gb.byKeys {
-			keyData[col] = append(keyData[col], r.keyVals[i])
-		}
-		for col, vals := range r.aggVals {
-			for i, val := range vals {
-				aggCol := col + "_" + string(rune('0'+i))
-				aggData[aggCol] = append(aggData[aggCol], val)
-			}
-		}
+// ParallelAggStats is ParallelAgg that also returns how it ran.
+func (gb *GroupBy) ParallelAggStats(aggFuncs map[string][]AggFunc, opts ...ParallelOptions) (*DataFrame, Stats, error) {
+	opt := DefaultParallelOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
 
-	// Build result DataFrame
-	data := make(map[string][]interface{})
-	for col, vals := range keyData {
-		data[col] = vals
-	}
-	for col, vals := range aggData {
-		data[col] = vals
-	}
+	start := time.Now()
+	result, err := gb.ParallelAgg(aggFuncs, opt)
+	workers := gb.aggWorkers(opt)
+	return result, Stats{
+		Elapsed: time.Since(start),
+		Workers: workers,
+		Chunks:  chunkCount(len(gb.keyOrder), workers),
+	}, err
+}
 
-	return New(data)
+// aggWorkers returns the number of workers ParallelAgg splits the groups
+// among.
+func (gb *GroupBy) aggWorkers(opt ParallelOptions) int {
+	return min(getNumWorkers(opt, len(gb.keyOrder)), max(len(gb.keyOrder), 1))
 }
 
 // ParallelMap applies a mapping function to multiple Series in parallel
@@ -475,36 +873,35 @@ func ParallelMapSeries(series []*Series, fn func(*Series) *Series, opts ...Paral
 		return series
 	}
 
-	numWorkers := getNumWorkers(opt, n)
-	if numWorkers > n {
-		numWorkers = n
-	}
-
 	results := make([]*Series, n)
-	var wg sync.WaitGroup
-
-	seriesChan := make(chan int, n)
-	for i := range series {
-		seriesChan <- i
-	}
-	close(seriesChan)
-
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for i := range seriesChan {
-				results[i] = fn(series[i])
-			}
-		}()
-	}
-
-	wg.Wait()
+	var wp workerPanic
+	runChunks(opt, n, min(getNumWorkers(opt, n), n), func(_, start, end int) error {
+		var i int
+		defer wp.catch(&i)
+		for i = start; i < end; i++ {
+			results[i] = fn(series[i])
+		}
+		return nil
+	})
+	wp.repanic()
 	return results
 }
 
 // ParallelReadCSV reads multiple CSV files in parallel and concatenates them
+// in the order of paths. Failures are reported for every file, each naming
+// its path, joined with errors.Join. By default any failure discards all
+// frames; with AllowPartial the Concat of the files read successfully is
+// returned together with the error.
 func ParallelReadCSV(paths []string, readFunc func(string) (*DataFrame, error), opts ...ParallelOptions) (*DataFrame, error) {
+	return ParallelReadCSVCtx(context.Background(), paths, func(_ context.Context, path string) (*DataFrame, error) {
+		return readFunc(path)
+	}, opts...)
+}
+
+// ParallelReadCSVCtx is ParallelReadCSV with a context that is passed to
+// readFunc. Once ctx is done no further files are read, and ctx.Err() is
+// returned after the reads in progress have returned.
+func ParallelReadCSVCtx(ctx context.Context, paths []string, readFunc func(context.Context, string) (*DataFrame, error), opts ...ParallelOptions) (*DataFrame, error) {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
 		opt = opts[0]
@@ -515,42 +912,45 @@ func ParallelReadCSV(paths []string, readFunc func(string) (*DataFrame, error),
 		return New(map[string][]interface{}{})
 	}
 
-	numWorkers := getNumWorkers(opt, n)
-	if numWorkers > n {
-		numWorkers = n
-	}
-
 	results := make([]*DataFrame, n)
-	errors := make([]error, n)
-	var wg sync.WaitGroup
-
-	pathChan := make(chan int, n)
-	for i := range paths {
-		pathChan <- i
-	}
-	close(pathChan)
-
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for i := range pathChan {
-				df, err := readFunc(paths[i])
+	errs := make([]error, n)
+	runChunks(opt, n, min(getNumWorkers(opt, n), n), func(_, start, end int) error {
+		var failures []error
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			errs[i] = safeCall(i, func(i int) error {
+				df, err := readFunc(ctx, paths[i])
+				if err != nil {
+					// A failed read may still return a partial frame
+					return err
+				}
 				results[i] = df
-				errors[i] = err
+				return nil
+			})
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("failed to read '%s': %w", paths[i], errs[i])
+				failures = append(failures, errs[i])
 			}
-		}()
+		}
+		return joinErrors(failures)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
-
-	// Check for errors
-	for i, err := range errors {
+	var failures []error
+	for _, err := range errs {
 		if err != nil {
-			return nil, err
+			failures = append(failures, err)
 		}
-		if results[i] == nil {
-			continue
+	}
+	var readErr error
+	if len(failures) > 0 {
+		readErr = errors.Join(failures...)
+		if !opt.AllowPartial {
+			return nil, readErr
 		}
 	}
 
@@ -563,13 +963,19 @@ func ParallelReadCSV(paths []string, readFunc func(string) (*DataFrame, error),
 	}
 
 	if len(validResults) == 0 {
-		return New(map[string][]interface{}{})
+		df, err := New(map[string][]interface{}{})
+		if err != nil {
+			return nil, err
+		}
+		return df, readErr
 	}
 
-	return Concat(validResults...), nil
+	return Concat(validResults...), readErr
 }
 
-// ChunkedApply applies a function to a Series in chunks for memory efficiency
+// ChunkedApply applies a function to a Series in chunks for memory efficiency.
+// The result keeps the index when it has as many values as s,
+// and gets a RangeIndex otherwise.
 func (s *Series) ChunkedApply(fn func([]interface{}) []interface{}, chunkSize int) *Series {
 	if chunkSize <= 0 {
 		chunkSize = 10000
@@ -595,12 +1001,24 @@ func (s *Series) ChunkedApply(fn func([]interface{}) []interface{}, chunkSize in
 	return &Series{
 		name:  s.name,
 		data:  result,
-		dtype: DTypeObject,
-		index: s.index.Copy(),
+		dtype: InferDTypeFromSlice(result),
+		index: chunkedIndex(s.index, len(result)),
+		na:    s.na,
+	}
+}
+
+// chunkedIndex returns the index for n values computed chunk by chunk from a
+// Series with index idx: a copy of idx if the length is unchanged, since the
+// values then line up with the labels, and a RangeIndex otherwise.
+func chunkedIndex(idx *Index, n int) *Index {
+	if idx.Len() == n {
+		return idx.Copy()
 	}
+	return NewRangeIndex(n)
 }
 
-// ParallelChunkedApply applies a function to chunks in parallel
+// ParallelChunkedApply applies a function to chunks in parallel, see
+// ChunkedApply.
 func (s *Series) ParallelChunkedApply(fn func([]interface{}) []interface{}, chunkSize int, opts ...ParallelOptions) *Series {
 	opt := DefaultParallelOptions()
 	if len(opts) > 0 {
@@ -617,55 +1035,20 @@ func (s *Series) ParallelChunkedApply(fn func([]interface{}) []interface{}, chun
 	}
 
 	numChunks := (n + chunkSize - 1) / chunkSize
-	numWorkers := getNumWorkers(opt, numChunks)
-	if numWorkers > numChunks {
-		numWorkers = numChunks
-	}
-
-	// Process chunks
-	type chunkResult struct {
-		index int
-		data  []interface{}
-	}
-
-	resultChan := make(chan chunkResult, numChunks)
-	chunkChan := make(chan int, numChunks)
-
-	for i := 0; i < numChunks; i++ {
-		chunkChan <- i
-	}
-	close(chunkChan)
-
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
-
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for chunkIdx := range chunkChan {
-				start := chunkIdx * chunkSize
-				end := start + chunkSize
-				if end > n {
-					end = n
-				}
-				chunk := s.data[start:end]
-				processed := fn(chunk)
-				resultChan <- chunkResult{index: chunkIdx, data: processed}
-			}
-		}()
-	}
-
-	// Collect results in a separate goroutine
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Assemble results in order
 	chunkResults := make([][]interface{}, numChunks)
-	for r := range resultChan {
-		chunkResults[r.index] = r.data
-	}
+	var wp workerPanic
+	runChunks(opt, numChunks, min(getNumWorkers(opt, numChunks), numChunks), func(_, first, last int) error {
+		pos := -1
+		defer wp.catch(&pos)
+		for c := first; c < last; c++ {
+			start := c * chunkSize
+			pos = start
+			end := min(start+chunkSize, n)
+			chunkResults[c] = fn(s.data[start:end])
+		}
+		return nil
+	})
+	wp.repanic()
 
 	// Concatenate
 	result := make([]interface{}, 0, n)
@@ -676,7 +1059,8 @@ func (s *Series) ParallelChunkedApply(fn func([]interface{}) []interface{}, chun
 	return &Series{
 		name:  s.name,
 		data:  result,
-		dtype: DTypeObject,
-		index: s.index.Copy(),
+		dtype: InferDTypeFromSlice(result),
+		index: chunkedIndex(s.index, len(result)),
+		na:    s.na,
 	}
 }
@@ -0,0 +1,189 @@
+package dataframe
+
+// parallelMergeRows is the number of probe rows above which Merge runs a
+// hash join in parallel when MergeOptions.Parallel is nil.
+const parallelMergeRows = 1 << 17
+
+// mergeWorkers returns the number of workers for a hash join that probes n
+// rows, and false when the serial join should be used.
+func mergeWorkers(opts MergeOptions, n int) (int, bool) {
+	if opts.Parallel != nil {
+		return getNumWorkers(*opts.Parallel, n), true
+	}
+	if n <= parallelMergeRows {
+		return 1, false
+	}
+	workers := getNumWorkers(DefaultParallelOptions(), n)
+	return workers, workers > 1
+}
+
+// parallelHashJoin is the hash join of Merge for inner, left, right and
+// outer joins with the probe rows split among workers. Each worker collects
+// the matching (left row, right row) pairs of its range; the ranges are
+// concatenated in order, so the rows come out exactly as the serial join
+// emits them. The result columns are then filled concurrently, one column
+// per task.
+func parallelHashJoin(left, right *DataFrame, leftKeys, rightKeys []string, opts MergeOptions, workers int) (*DataFrame, error) {
+	probe, build := left, right
+	probeKeys, buildKeys := leftKeys, rightKeys
+	if opts.How == RightJoin {
+		probe, build = right, left
+		probeKeys, buildKeys = rightKeys, leftKeys
+	}
+	index := buildJoinIndex(build, buildKeys)
+	probeCols := keySeries(probe, probeKeys)
+	keepUnmatched := opts.How != InnerJoin
+	var parallel ParallelOptions
+	if opts.Parallel != nil {
+		parallel = *opts.Parallel
+	}
+
+	// Probe rows and their matches per range; -1 marks a row without match
+	type pairs struct {
+		probeRows []int
+		buildRows []int
+	}
+	n := probe.shape[0]
+	chunks := make([]pairs, chunkCount(n, workers))
+	if n > 0 {
+		runChunks(parallel, n, workers, func(chunk, start, end int) error {
+			var p pairs
+			for i := start; i < end; i++ {
+				if rows, found := index.lookup(probeCols, i); found {
+					for _, row := range rows {
+						p.probeRows = append(p.probeRows, i)
+						p.buildRows = append(p.buildRows, row)
+					}
+				} else if keepUnmatched {
+					p.probeRows = append(p.probeRows, i)
+					p.buildRows = append(p.buildRows, -1)
+				}
+			}
+			chunks[chunk] = p
+			return nil
+		})
+	}
+
+	total := 0
+	for _, p := range chunks {
+		total += len(p.probeRows)
+	}
+	var unmatchedRight []int
+	if opts.How == OuterJoin {
+		matched := make([]bool, right.shape[0])
+		for _, p := range chunks {
+			for _, row := range p.buildRows {
+				if row >= 0 {
+					matched[row] = true
+				}
+			}
+		}
+		for i, ok := range matched {
+			if !ok {
+				unmatchedRight = append(unmatchedRight, i)
+			}
+		}
+		total += len(unmatchedRight)
+	}
+
+	leftRows := make([]int, 0, total)
+	rightRows := make([]int, 0, total)
+	for c := range chunks {
+		p := &chunks[c]
+		if opts.How == RightJoin {
+			leftRows = append(leftRows, p.buildRows...)
+			rightRows = append(rightRows, p.probeRows...)
+		} else {
+			leftRows = append(leftRows, p.probeRows...)
+			rightRows = append(rightRows, p.buildRows...)
+		}
+		*p = pairs{}
+	}
+	for _, row := range unmatchedRight {
+		leftRows = append(leftRows, -1)
+		rightRows = append(rightRows, row)
+	}
+
+	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
+	if opts.Indicator {
+		resultCols = append(resultCols, "_merge")
+	}
+	resultIndex := joinResultIndex(left, right, leftRows, rightRows, opts.KeepIndex)
+	columns := make([]*Series, len(resultCols))
+	// Progress is reported for the probe rows only
+	assemble := ParallelOptions{Pool: parallel.Pool}
+	runChunks(assemble, len(resultCols), min(workers, len(resultCols)), func(_, start, end int) error {
+		for c := start; c < end; c++ {
+			col := resultCols[c]
+			if opts.Indicator && c == len(resultCols)-1 {
+				columns[c] = NewSeriesWithIndex(joinIndicators(leftRows, rightRows), col, resultIndex.Copy())
+				continue
+			}
+			columns[c] = NewSeriesWithIndex(joinedColumn(left, right, leftKeys, rightKeys, colMapping[col], leftRows, rightRows), col, resultIndex.Copy())
+		}
+		return nil
+	})
+
+	seriesMap := make(map[string]*Series, len(resultCols))
+	for c, col := range resultCols {
+		seriesMap[col] = columns[c]
+	}
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   resultIndex,
+		shape:   [2]int{total, len(resultCols)},
+	}, nil
+}
+
+// joinedColumn returns the values of a result column for the given row
+// pairs, filled like appendJoinedRow, appendLeftOnlyRow and
+// appendRightOnlyRow fill it: key columns take the left key value, or the
+// right one on right-only rows, and the other columns are nil for rows
+// missing on their side.
+func joinedColumn(left, right *DataFrame, leftKeys, rightKeys []string, mapping columnMapping, leftRows, rightRows []int) []interface{} {
+	values := make([]interface{}, len(leftRows))
+	switch {
+	case mapping.isKey:
+		ls := left.data[leftKeys[mapping.keyIndex]].data
+		rs := right.data[rightKeys[mapping.keyIndex]].data
+		for i, l := range leftRows {
+			if l >= 0 {
+				values[i] = ls[l]
+			} else {
+				values[i] = rs[rightRows[i]]
+			}
+		}
+	case mapping.source == "left":
+		ls := left.data[mapping.srcCol].data
+		for i, l := range leftRows {
+			if l >= 0 {
+				values[i] = ls[l]
+			}
+		}
+	default:
+		rs := right.data[mapping.srcCol].data
+		for i, r := range rightRows {
+			if r >= 0 {
+				values[i] = rs[r]
+			}
+		}
+	}
+	return values
+}
+
+// joinIndicators returns the _merge values for the given row pairs.
+func joinIndicators(leftRows, rightRows []int) []interface{} {
+	values := make([]interface{}, len(leftRows))
+	for i := range leftRows {
+		switch {
+		case leftRows[i] < 0:
+			values[i] = "right_only"
+		case rightRows[i] < 0:
+			values[i] = "left_only"
+		default:
+			values[i] = "both"
+		}
+	}
+	return values
+}
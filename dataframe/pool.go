@@ -0,0 +1,75 @@
+package dataframe
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Pool is a fixed set of long-lived worker goroutines. Parallel operations
+// given a Pool through ParallelOptions.Pool run their tasks on its workers
+// instead of starting goroutines on every call, which saves the start-up
+// cost when many small operations are run.
+//
+// A task submitted while no worker is idle runs on the submitting goroutine,
+// so operations sharing a Pool, or nested in one another, never wait for a
+// free worker. A Pool is safe for concurrent use and must not be used after
+// Close.
+type Pool struct {
+	tasks     chan func()
+	size      int
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewPool starts a Pool of numWorkers workers, or of one worker per CPU if
+// numWorkers is not positive.
+func NewPool(numWorkers int) *Pool {
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	p := &Pool{tasks: make(chan func()), size: numWorkers}
+	p.wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// Size returns the number of workers of the Pool.
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// Close stops the workers once they have finished their current tasks.
+// Calling Close more than once has no effect.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.tasks)
+	})
+	p.wg.Wait()
+}
+
+// submit hands task to an idle worker, or runs it on the calling goroutine
+// if there is none.
+func (p *Pool) submit(task func()) {
+	select {
+	case p.tasks <- task:
+	default:
+		task()
+	}
+}
+
+// goTask starts task on a new goroutine, or submits it to pool when pool is
+// not nil. task must not let panics escape.
+func goTask(pool *Pool, task func()) {
+	if pool == nil {
+		go task()
+		return
+	}
+	pool.submit(task)
+}
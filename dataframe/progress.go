@@ -0,0 +1,86 @@
+package dataframe
+
+import "time"
+
+// Stats describes how a parallel operation ran.
+type Stats struct {
+	Elapsed time.Duration // wall time of the operation
+	Workers int           // number of workers the input was split for
+	Chunks  int           // number of chunks the input was split into
+}
+
+// progressEvent is the completion of a chunk of done values, or the error
+// chunk worker stopped on.
+type progressEvent struct {
+	worker int
+	done   int
+	err    error
+}
+
+// progress calls the OnProgress and OnWorkerError hooks of ParallelOptions
+// for the chunks of an operation over total values. The hooks run on a
+// single reporting goroutine, one call at a time, and all of them have
+// returned once close returns. A nil *progress reports nothing, so that
+// operations without hooks pay nothing for them.
+type progress struct {
+	onProgress    func(done, total int)
+	onWorkerError func(worker int, err error)
+	total         int
+	events        chan progressEvent
+	finished      chan struct{}
+}
+
+// newProgress starts the reporting goroutine for opts, or returns nil if
+// opts has no hooks.
+func newProgress(opts ParallelOptions, total int) *progress {
+	if opts.OnProgress == nil && opts.OnWorkerError == nil {
+		return nil
+	}
+	p := &progress{
+		onProgress:    opts.OnProgress,
+		onWorkerError: opts.OnWorkerError,
+		total:         total,
+		events:        make(chan progressEvent, 64),
+		finished:      make(chan struct{}),
+	}
+	go p.report()
+	return p
+}
+
+func (p *progress) report() {
+	defer close(p.finished)
+	done := 0
+	for e := range p.events {
+		if e.err != nil {
+			if p.onWorkerError != nil {
+				p.onWorkerError(e.worker, e.err)
+			}
+			continue
+		}
+		done += e.done
+		if p.onProgress != nil {
+			p.onProgress(done, p.total)
+		}
+	}
+}
+
+// chunkFinished reports that chunk, of n values, completed, or the error its
+// worker stopped on if err is not nil.
+func (p *progress) chunkFinished(chunk, n int, err error) {
+	if p == nil {
+		return
+	}
+	if err != nil {
+		p.events <- progressEvent{worker: chunk, err: err}
+		return
+	}
+	p.events <- progressEvent{done: n}
+}
+
+// close waits for the hooks of all reported events to return.
+func (p *progress) close() {
+	if p != nil {
+		close(p.events)
+		<-p.finished
+	}
+}
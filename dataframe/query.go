@@ -0,0 +1,627 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query returns the rows for which the boolean expression is true.
+//
+// Expressions support column identifiers (use backticks for names with
+// spaces, e.g. `unit price`), numeric, string ('...' or "...") and bool
+// literals, nil, the comparison operators == != < <= > >=, the logical
+// operators && || !, arithmetic + - * / %, parentheses and membership tests
+// such as city in ['Berlin', 'Paris']. Values are compared like SortValues
+// orders them: numerically when both sides are numbers, otherwise by their
+// string form. Ordering comparisons involving NA are false; x == nil matches
+// NA values and != is always the negation of ==.
+//
+// The expression is parsed once and evaluated column-wise. Matching rows
+// keep their index labels.
+func (df *DataFrame) Query(expr string) (*DataFrame, error) {
+	result, err := df.evalExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	var rows []int
+	for i := 0; i < df.shape[0]; i++ {
+		if result.truthyAt(i) {
+			rows = append(rows, i)
+		}
+	}
+	return df.takeRows(rows), nil
+}
+
+// Eval evaluates an expression such as "price * qty" for every row and
+// returns the result as a Series named after the expression. It accepts the
+// same syntax as Query.
+func (df *DataFrame) Eval(expr string) (*Series, error) {
+	result, err := df.evalExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, df.shape[0])
+	for i := range values {
+		values[i] = result.at(i)
+	}
+	return NewSeriesWithIndex(values, expr, df.index.Copy()), nil
+}
+
+func (df *DataFrame) evalExpr(expr string) (exprValue, error) {
+	tokens, err := tokenizeExpr(expr)
+	if err != nil {
+		return exprValue{}, err
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return exprValue{}, err
+	}
+	if p.peek().kind != tokEOF {
+		return exprValue{}, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	if err := node.check(df); err != nil {
+		return exprValue{}, err
+	}
+	return node.eval(df), nil
+}
+
+// ============ Tokenizer ============
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type exprToken struct {
+	kind   tokenKind
+	text   string
+	pos    int
+	quoted bool // backtick-quoted identifier, always a column name
+}
+
+func tokenizeExpr(expr string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote at position %d", start)
+			}
+			i++
+			kind := tokString
+			if r == '`' {
+				kind = tokIdent
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: sb.String(), pos: start, quoted: r == '`'})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '+' || runes[i] == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+			switch two {
+			case "==", "!=", "<=", ">=", "&&", "||":
+				tokens = append(tokens, exprToken{kind: tokOp, text: two, pos: start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("<>!+-*/%()[],", r) {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, start)
+			}
+			tokens = append(tokens, exprToken{kind: tokOp, text: string(r), pos: start})
+			i++
+		}
+	}
+	tokens = append(tokens, exprToken{kind: tokEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+// ============ Parser ============
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) acceptOp(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expectOp(op string) error {
+	if _, ok := p.acceptOp(op); !ok {
+		t := p.peek()
+		return fmt.Errorf("expected %q at position %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok := p.acceptOp("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "!", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokIdent && !t.quoted && t.text == "in" {
+		p.next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{operand: left, list: list}, nil
+	}
+	op, ok := p.acceptOp("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseList() ([]interface{}, error) {
+	if err := p.expectOp("["); err != nil {
+		return nil, err
+	}
+	var list []interface{}
+	if _, ok := p.acceptOp("]"); ok {
+		return list, nil
+	}
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		lit, ok := node.(*literalNode)
+		if !ok {
+			return nil, fmt.Errorf("in list may only contain literals")
+		}
+		list = append(list, lit.value)
+		if _, ok := p.acceptOp(","); ok {
+			continue
+		}
+		if err := p.expectOp("]"); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if _, ok := p.acceptOp("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if lit, ok := operand.(*literalNode); ok {
+			switch v := lit.value.(type) {
+			case int64:
+				return &literalNode{value: -v}, nil
+			case float64:
+				return &literalNode{value: -v}, nil
+			}
+		}
+		return &unaryNode{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literalNode{value: n}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literalNode{value: f}, nil
+	case tokString:
+		return &literalNode{value: t.text}, nil
+	case tokIdent:
+		if t.quoted {
+			return &columnNode{name: t.text}, nil
+		}
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "nil":
+			return &literalNode{value: nil}, nil
+		}
+		return &columnNode{name: t.text}, nil
+	case tokOp:
+		if t.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+// ============ Evaluation ============
+
+// exprValue is the result of evaluating a node: a whole column, or a scalar
+// broadcast to every row.
+type exprValue struct {
+	values   []interface{}
+	scalar   interface{}
+	isScalar bool
+	na       *naConfig // NA settings of the column the values come from
+}
+
+func (v exprValue) at(i int) interface{} {
+	if v.isScalar {
+		return v.scalar
+	}
+	return v.values[i]
+}
+
+// isNA reports whether x is NA, using the settings of the source column
+// for column values. Literals and computed values are NA only when they are
+// nil or NaN, so that a literal such as 'NA' compares as a plain string.
+func (v exprValue) isNA(x interface{}) bool {
+	if v.na != nil {
+		return v.na.isNA(x)
+	}
+	return x == nil || isNaNValue(x)
+}
+
+func (v exprValue) truthyAt(i int) bool {
+	x := v.at(i)
+	return truthy(x, v.isNA(x))
+}
+
+type exprNode interface {
+	check(df *DataFrame) error
+	eval(df *DataFrame) exprValue
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) check(df *DataFrame) error { return nil }
+
+func (n *literalNode) eval(df *DataFrame) exprValue {
+	return exprValue{scalar: n.value, isScalar: true}
+}
+
+type columnNode struct {
+	name string
+}
+
+func (n *columnNode) check(df *DataFrame) error {
+	if _, ok := df.data[n.name]; !ok {
+		return fmt.Errorf("unknown column '%s' in expression", n.name)
+	}
+	return nil
+}
+
+func (n *columnNode) eval(df *DataFrame) exprValue {
+	s := df.data[n.name]
+	return exprValue{values: s.data, na: s.naConfig()}
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) check(df *DataFrame) error { return n.operand.check(df) }
+
+func (n *unaryNode) eval(df *DataFrame) exprValue {
+	operand := n.operand.eval(df)
+	apply := func(v interface{}) interface{} {
+		if n.op == "!" {
+			return !truthy(v, operand.isNA(v))
+		}
+		if operand.isNA(v) {
+			return nil
+		}
+		if i, ok := v.(int64); ok {
+			return -i
+		}
+		f, err := toFloat64(v)
+		if err != nil {
+			return nil
+		}
+		return -f
+	}
+	return mapExprValue(df, operand, apply)
+}
+
+type inNode struct {
+	operand exprNode
+	list    []interface{}
+}
+
+func (n *inNode) check(df *DataFrame) error { return n.operand.check(df) }
+
+func (n *inNode) eval(df *DataFrame) exprValue {
+	operand := n.operand.eval(df)
+	return mapExprValue(df, operand, func(v interface{}) interface{} {
+		if operand.isNA(v) {
+			return false
+		}
+		for _, item := range n.list {
+			if item != nil && compareExprValues(v, item) == 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *binaryNode) check(df *DataFrame) error {
+	if err := n.left.check(df); err != nil {
+		return err
+	}
+	return n.right.check(df)
+}
+
+func (n *binaryNode) eval(df *DataFrame) exprValue {
+	left := n.left.eval(df)
+	right := n.right.eval(df)
+	if left.isScalar && right.isScalar {
+		return exprValue{scalar: n.apply(left.scalar, right.scalar, left.isNA(left.scalar), right.isNA(right.scalar)), isScalar: true}
+	}
+	values := make([]interface{}, df.shape[0])
+	for i := range values {
+		a, b := left.at(i), right.at(i)
+		values[i] = n.apply(a, b, left.isNA(a), right.isNA(b))
+	}
+	return exprValue{values: values}
+}
+
+// apply combines a and b, which are NA as given by aNA and bNA.
+func (n *binaryNode) apply(a, b interface{}, aNA, bNA bool) interface{} {
+	switch n.op {
+	case "&&":
+		return truthy(a, aNA) && truthy(b, bNA)
+	case "||":
+		return truthy(a, aNA) || truthy(b, bNA)
+	}
+
+	switch n.op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if aNA || bNA {
+			switch n.op {
+			case "==":
+				return aNA && bNA
+			case "!=":
+				return !(aNA && bNA)
+			}
+			return false
+		}
+		c := compareExprValues(a, b)
+		switch n.op {
+		case "==":
+			return c == 0
+		case "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		default:
+			return c >= 0
+		}
+	}
+
+	if aNA || bNA {
+		return nil
+	}
+	if n.op == "+" {
+		if sa, ok := a.(string); ok {
+			if sb, ok := b.(string); ok {
+				return sa + sb
+			}
+		}
+	}
+	fa, erra := toFloat64(a)
+	fb, errb := toFloat64(b)
+	if erra != nil || errb != nil {
+		return nil
+	}
+	switch n.op {
+	case "+":
+		return opAdd(fa, fb)
+	case "-":
+		return opSub(fa, fb)
+	case "*":
+		return opMul(fa, fb)
+	case "/":
+		return opDiv(fa, fb)
+	default:
+		return math.Mod(fa, fb)
+	}
+}
+
+// compareExprValues compares two non-NA values using compareOrdered when
+// the types match and compareValues otherwise.
+func compareExprValues(a, b interface{}) int {
+	if c, ok := compareOrdered(a, b); ok {
+		return c
+	}
+	return compareValues(a, b)
+}
+
+// truthy reports whether v, which is NA as given by na, counts as true; NA
+// and unconvertible values are false.
+func truthy(v interface{}, na bool) bool {
+	if na {
+		return false
+	}
+	b, err := toBool(v)
+	return err == nil && b
+}
+
+func mapExprValue(df *DataFrame, v exprValue, fn func(interface{}) interface{}) exprValue {
+	if v.isScalar {
+		return exprValue{scalar: fn(v.scalar), isScalar: true}
+	}
+	values := make([]interface{}, df.shape[0])
+	for i := range values {
+		values[i] = fn(v.values[i])
+	}
+	return exprValue{values: values}
+}
@@ -0,0 +1,529 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PivotOptions defines options for PivotTable.
+type PivotOptions struct {
+	Index     []string    // columns whose values become the rows
+	Columns   string      // column whose distinct values become the result columns
+	Values    string      // column holding the values to aggregate
+	AggFunc   AggFunc     // aggregation applied to duplicates (default AggMean)
+	FillValue interface{} // value for missing combinations (default nil)
+}
+
+// Pivot reshapes the DataFrame from long to wide format. Each distinct value
+// of the columns field becomes a result column named by its string form, in
+// sorted order, holding the matching values. The index field is kept as a
+// regular leading column. Duplicate (index, columns) pairs are an error; use
+// PivotTable to aggregate them. So are values with the same string form,
+// such as 1 and "1", or one named like the index column.
+func (df *DataFrame) Pivot(index, columns, values string) (*DataFrame, error) {
+	return df.pivot(PivotOptions{Index: []string{index}, Columns: columns, Values: values}, false)
+}
+
+// PivotTable reshapes the DataFrame from long to wide format like Pivot, but
+// aggregates the values of duplicate (index, columns) pairs with AggFunc.
+func (df *DataFrame) PivotTable(opts PivotOptions) (*DataFrame, error) {
+	if opts.AggFunc == nil {
+		opts.AggFunc = AggMean
+	}
+	return df.pivot(opts, true)
+}
+
+func (df *DataFrame) pivot(opts PivotOptions, aggregate bool) (*DataFrame, error) {
+	if len(opts.Index) == 0 {
+		return nil, fmt.Errorf("pivot index cannot be empty")
+	}
+	for _, col := range append(append([]string{}, opts.Index...), opts.Columns, opts.Values) {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+	}
+
+	colSeries := df.data[opts.Columns]
+	valSeries := df.data[opts.Values]
+
+	// Collect distinct row keys in order of first appearance
+	var rowKeys []string
+	rowFirst := make(map[string]int)
+	rowOf := make([]string, df.shape[0])
+	keyVals := make([]interface{}, len(opts.Index))
+	for i := 0; i < df.shape[0]; i++ {
+		for j, col := range opts.Index {
+			keyVals[j] = df.data[col].data[i]
+		}
+		key := compositeKey(keyVals)
+		if _, ok := rowFirst[key]; !ok {
+			rowFirst[key] = i
+			rowKeys = append(rowKeys, key)
+		}
+		rowOf[i] = key
+	}
+
+	// Collect distinct column values in sorted order
+	colValues := colSeries.Unique().data
+	sort.SliceStable(colValues, func(i, j int) bool {
+		a, b := colValues[i], colValues[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return compareValues(a, b) < 0
+	})
+	colNames := make([]string, len(colValues))
+	colPos := make(map[interface{}]int)
+	used := make(map[string]bool, len(opts.Index)+len(colValues))
+	for _, col := range opts.Index {
+		used[col] = true
+	}
+	for i, v := range colValues {
+		name := fmt.Sprintf("%v", v)
+		if used[name] {
+			// Values such as 1 and "1", or a value equal to an index
+			// column name, would overwrite each other
+			return nil, fmt.Errorf("duplicate column '%s' in pivot result", name)
+		}
+		used[name] = true
+		colNames[i] = name
+		colPos[hashKey(v)] = i
+	}
+
+	// Group value positions per cell
+	rowPos := make(map[string]int)
+	for i, key := range rowKeys {
+		rowPos[key] = i
+	}
+	cells := make([][][]int, len(rowKeys))
+	for i := range cells {
+		cells[i] = make([][]int, len(colValues))
+	}
+	for i := 0; i < df.shape[0]; i++ {
+		r := rowPos[rowOf[i]]
+		c := colPos[hashKey(colSeries.data[i])]
+		if !aggregate && len(cells[r][c]) > 0 {
+			return nil, fmt.Errorf("duplicate entry for index %v and column %v", df.data[opts.Index[0]].data[i], colSeries.data[i])
+		}
+		cells[r][c] = append(cells[r][c], i)
+	}
+
+	resultCols := append(append([]string{}, opts.Index...), colNames...)
+	seriesMap := make(map[string]*Series)
+	for _, col := range opts.Index {
+		src := df.data[col]
+		data := make([]interface{}, len(rowKeys))
+		for r, key := range rowKeys {
+			data[r] = src.data[rowFirst[key]]
+		}
+		seriesMap[col] = NewSeries(data, col)
+	}
+	for c, name := range colNames {
+		data := make([]interface{}, len(rowKeys))
+		for r := range rowKeys {
+			positions := cells[r][c]
+			switch {
+			case len(positions) == 0:
+				data[r] = opts.FillValue
+			case aggregate:
+				cellData := make([]interface{}, len(positions))
+				for k, pos := range positions {
+					cellData[k] = valSeries.data[pos]
+				}
+				data[r] = opts.AggFunc(NewSeries(cellData, opts.Values))
+			default:
+				data[r] = valSeries.data[positions[0]]
+			}
+		}
+		seriesMap[name] = NewSeries(data, name)
+	}
+
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   NewRangeIndex(len(rowKeys)),
+		shape:   [2]int{len(rowKeys), len(resultCols)},
+	}, nil
+}
+
+// Melt reshapes the DataFrame from wide to long format. Each value column
+// produces one row per original row holding the id columns, the column name
+// under varName and the cell under valueName. Empty valueVars means all
+// columns that are not id columns; empty names default to "variable" and
+// "value".
+func (df *DataFrame) Melt(idVars, valueVars []string, varName, valueName string) (*DataFrame, error) {
+	if varName == "" {
+		varName = "variable"
+	}
+	if valueName == "" {
+		valueName = "value"
+	}
+	isID := make(map[string]bool)
+	for _, col := range idVars {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+		isID[col] = true
+	}
+	if len(valueVars) == 0 {
+		for _, col := range df.columns {
+			if !isID[col] {
+				valueVars = append(valueVars, col)
+			}
+		}
+	}
+	for _, col := range valueVars {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+	}
+
+	n := df.shape[0] * len(valueVars)
+	colData := make(map[string][]interface{})
+	for _, col := range idVars {
+		colData[col] = make([]interface{}, 0, n)
+	}
+	variables := make([]interface{}, 0, n)
+	values := make([]interface{}, 0, n)
+	for _, vcol := range valueVars {
+		src := df.data[vcol]
+		for i := 0; i < df.shape[0]; i++ {
+			for _, col := range idVars {
+				colData[col] = append(colData[col], df.data[col].data[i])
+			}
+			variables = append(variables, vcol)
+			values = append(values, src.data[i])
+		}
+	}
+
+	resultCols := append(append([]string{}, idVars...), varName, valueName)
+	seriesMap := make(map[string]*Series)
+	for _, col := range idVars {
+		seriesMap[col] = NewSeries(colData[col], col)
+	}
+	seriesMap[varName] = NewSeries(variables, varName)
+	seriesMap[valueName] = NewSeries(values, valueName)
+
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   NewRangeIndex(n),
+		shape:   [2]int{n, len(resultCols)},
+	}, nil
+}
+
+// DummiesOptions defines options for GetDummies.
+type DummiesOptions struct {
+	Columns   []string          // columns to encode (default: all string columns)
+	Prefix    map[string]string // prefix per column (default: the column name)
+	Separator string            // separator between prefix and value (default "_")
+	DropFirst bool              // drop the first dummy column of each encoded column
+	DummyNA   bool              // add a "<prefix><sep>nan" column flagging NA values
+}
+
+// GetDummies one-hot encodes columns of df. Each encoded column is replaced by
+// int64 0/1 columns named prefix + separator + value, one per distinct
+// non-NA value in sorted order. The columns that are not encoded keep their
+// order and come first, followed by the dummy columns of each encoded column.
+func GetDummies(df *DataFrame, opts DummiesOptions) (*DataFrame, error) {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		for _, col := range df.columns {
+			if df.data[col].dtype == DTypeString {
+				columns = append(columns, col)
+			}
+		}
+	}
+	sep := opts.Separator
+	if sep == "" {
+		sep = "_"
+	}
+
+	encoded := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		if _, ok := df.data[col]; !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+		encoded[col] = true
+	}
+
+	n := df.shape[0]
+	resultCols := make([]string, 0, len(df.columns))
+	seriesMap := make(map[string]*Series)
+	for _, col := range df.columns {
+		if !encoded[col] {
+			resultCols = append(resultCols, col)
+			seriesMap[col] = df.data[col].Copy()
+		}
+	}
+
+	addColumn := func(name string, data []interface{}) error {
+		if _, ok := seriesMap[name]; ok {
+			return fmt.Errorf("dummy column '%s' already exists", name)
+		}
+		resultCols = append(resultCols, name)
+		seriesMap[name] = &Series{name: name, data: data, dtype: DTypeInt64, index: df.index.Copy()}
+		return nil
+	}
+
+	for _, col := range columns {
+		src := df.data[col]
+		prefix, ok := opts.Prefix[col]
+		if !ok {
+			prefix = col
+		}
+
+		levels, codes := sortedLevels(src)
+		dummies := make([][]interface{}, len(levels))
+		for i := range dummies {
+			dummies[i] = make([]interface{}, n)
+		}
+		var naDummy []interface{}
+		if opts.DummyNA {
+			naDummy = make([]interface{}, n)
+		}
+		for r, v := range src.data {
+			code := -1
+			if !src.isNA(v) {
+				code = codes[hashKey(v)]
+			}
+			for i := range dummies {
+				dummies[i][r] = boolToInt64(i == code)
+			}
+			if naDummy != nil {
+				naDummy[r] = boolToInt64(code == -1)
+			}
+		}
+
+		names := make([]string, len(levels))
+		for i, v := range levels {
+			names[i] = fmt.Sprintf("%s%s%v", prefix, sep, v)
+		}
+		if naDummy != nil {
+			names = append(names, prefix+sep+"nan")
+			dummies = append(dummies, naDummy)
+		}
+		if opts.DropFirst && len(names) > 0 {
+			names = names[1:]
+			dummies = dummies[1:]
+		}
+		for i, name := range names {
+			if err := addColumn(name, dummies[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   df.index.Copy(),
+		shape:   [2]int{n, len(resultCols)},
+	}, nil
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// CrosstabOptions defines options for Crosstab.
+type CrosstabOptions struct {
+	Values    *Series // values to aggregate instead of counting (requires AggFunc)
+	AggFunc   AggFunc // aggregation applied to Values
+	Normalize string  // "", "all", "index" or "columns"
+	Margins   bool    // add an "All" row and column holding the totals
+}
+
+// crosstabMargin is the label of the margin row and column.
+const crosstabMargin = "All"
+
+// Crosstab computes a contingency table of two Series. The first result
+// column holds the distinct values of index and is named after it; the
+// remaining columns are the distinct values of columns. Rows and columns are
+// sorted and NA values are ignored. Cells count co-occurrences unless
+// Values and AggFunc are set, in which case the matching values are
+// aggregated (nil for empty cells). Normalize divides every cell by the
+// grand total ("all"), its row total ("index") or its column total
+// ("columns"), giving float64 cells.
+func Crosstab(index, columns *Series, opts CrosstabOptions) (*DataFrame, error) {
+	n := index.Len()
+	if columns.Len() != n {
+		return nil, lengthMismatch(n, columns.Len(), "columns length %d does not match index length %d", columns.Len(), n)
+	}
+	if (opts.Values == nil) != (opts.AggFunc == nil) {
+		return nil, fmt.Errorf("crosstab values and aggfunc must be given together")
+	}
+	if opts.Values != nil && opts.Values.Len() != n {
+		return nil, lengthMismatch(n, opts.Values.Len(), "values length %d does not match index length %d", opts.Values.Len(), n)
+	}
+	switch opts.Normalize {
+	case "", "all", "index", "columns":
+	default:
+		return nil, fmt.Errorf("invalid normalize option '%s'", opts.Normalize)
+	}
+
+	rowLevels, rowPos := sortedLevels(index)
+	colLevels, colPos := sortedLevels(columns)
+
+	// Group positions per cell; margins collect positions per row, per
+	// column and overall in the extra last slot.
+	nr, nc := len(rowLevels), len(colLevels)
+	cells := make([][][]int, nr+1)
+	for r := range cells {
+		cells[r] = make([][]int, nc+1)
+	}
+	for i := 0; i < n; i++ {
+		if index.isNA(index.data[i]) || columns.isNA(columns.data[i]) {
+			continue
+		}
+		r := rowPos[hashKey(index.data[i])]
+		c := colPos[hashKey(columns.data[i])]
+		cells[r][c] = append(cells[r][c], i)
+		cells[r][nc] = append(cells[r][nc], i)
+		cells[nr][c] = append(cells[nr][c], i)
+		cells[nr][nc] = append(cells[nr][nc], i)
+	}
+
+	outRows, outCols := nr, nc
+	if opts.Margins {
+		outRows, outCols = nr+1, nc+1
+	}
+	table := make([][]interface{}, outRows)
+	for r := range table {
+		table[r] = make([]interface{}, outCols)
+		for c := range table[r] {
+			positions := cells[r][c]
+			switch {
+			case opts.AggFunc == nil:
+				table[r][c] = int64(len(positions))
+			case len(positions) == 0:
+				table[r][c] = nil
+			default:
+				cellData := make([]interface{}, len(positions))
+				for k, pos := range positions {
+					cellData[k] = opts.Values.data[pos]
+				}
+				table[r][c] = opts.AggFunc(NewSeries(cellData, opts.Values.name))
+			}
+		}
+	}
+
+	if opts.Normalize != "" {
+		normalizeTable(table, nr, nc, opts.Normalize)
+	}
+
+	indexName := index.name
+	if indexName == "" {
+		indexName = "index"
+	}
+	resultCols := make([]string, 0, outCols+1)
+	resultCols = append(resultCols, indexName)
+	for _, v := range colLevels {
+		resultCols = append(resultCols, fmt.Sprintf("%v", v))
+	}
+	if opts.Margins {
+		resultCols = append(resultCols, crosstabMargin)
+		rowLevels = append(rowLevels, crosstabMargin)
+	}
+
+	seriesMap := make(map[string]*Series, len(resultCols))
+	seriesMap[indexName] = NewSeries(rowLevels, indexName)
+	for c, name := range resultCols[1:] {
+		if _, ok := seriesMap[name]; ok {
+			return nil, fmt.Errorf("duplicate column '%s' in crosstab result", name)
+		}
+		data := make([]interface{}, outRows)
+		for r := range table {
+			data[r] = table[r][c]
+		}
+		seriesMap[name] = NewSeries(data, name)
+	}
+
+	return &DataFrame{
+		columns: resultCols,
+		data:    seriesMap,
+		index:   NewRangeIndex(outRows),
+		shape:   [2]int{outRows, len(resultCols)},
+	}, nil
+}
+
+// sortedLevels returns the distinct non-NA values of s in sorted order and
+// their positions keyed by hashKey.
+func sortedLevels(s *Series) ([]interface{}, map[interface{}]int) {
+	var levels []interface{}
+	seen := make(map[interface{}]bool)
+	for _, v := range s.data {
+		if s.isNA(v) {
+			continue
+		}
+		if key := hashKey(v); !seen[key] {
+			seen[key] = true
+			levels = append(levels, v)
+		}
+	}
+	sort.SliceStable(levels, func(i, j int) bool {
+		return compareValues(levels[i], levels[j]) < 0
+	})
+	pos := make(map[interface{}]int, len(levels))
+	for i, v := range levels {
+		pos[hashKey(v)] = i
+	}
+	return levels, pos
+}
+
+// normalizeTable divides the cells of a crosstab in place, skipping nil and
+// NaN cells; the cells are computed numbers, so no NA strings apply. Totals
+// are taken over the first nr rows and nc columns so margin cells are
+// normalized with the same denominators as the body.
+func normalizeTable(table [][]interface{}, nr, nc int, how string) {
+	rowTotals := make([]float64, len(table))
+	colTotals := make([]float64, nc+1)
+	var total float64
+	for r, row := range table {
+		for c, v := range row {
+			if v == nil || isNaNValue(v) {
+				continue
+			}
+			f, err := toFloat64(v)
+			if err != nil {
+				continue
+			}
+			if c < nc {
+				rowTotals[r] += f
+			}
+			if r < nr {
+				colTotals[c] += f
+				if c < nc {
+					total += f
+				}
+			}
+		}
+	}
+	for r, row := range table {
+		for c, v := range row {
+			if v == nil || isNaNValue(v) {
+				continue
+			}
+			f, err := toFloat64(v)
+			if err != nil {
+				continue
+			}
+			denom := total
+			switch how {
+			case "index":
+				denom = rowTotals[r]
+			case "columns":
+				denom = colTotals[c]
+			}
+			if denom == 0 {
+				row[c] = math.NaN()
+			} else {
+				row[c] = f / denom
+			}
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
+	"iter"
 	"math"
+	"reflect"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Series represents a one-dimensional labeled array
@@ -13,6 +17,7 @@ type Series struct {
 	data  []interface{} // Data values
 	dtype DType         // Data type
 	index *Index        // Row index
+	na    *naConfig     // NA strings of the Series, nil for the package ones
 }
 
 // NewSeries creates a new Series from data
@@ -110,6 +115,52 @@ func NewSeriesFromBools(data []bool, name string) *Series {
 	}
 }
 
+// NewSeriesRepeat creates a Series holding value n times.
+// The dtype follows value.
+func NewSeriesRepeat(value interface{}, n int, name string) *Series {
+	if n < 0 {
+		n = 0
+	}
+	values := make([]interface{}, n)
+	for i := range values {
+		values[i] = value
+	}
+	return &Series{
+		name:  name,
+		data:  values,
+		dtype: InferDType(value),
+		index: NewRangeIndex(n),
+	}
+}
+
+// NewSeriesRange creates an int64 Series of the values from start up to but
+// excluding stop, spaced by step. A negative step counts down; a zero step
+// is an error.
+func NewSeriesRange(start, stop, step int64, name string) (*Series, error) {
+	if step == 0 {
+		return nil, fmt.Errorf("range step cannot be zero")
+	}
+	var values []interface{}
+	if step > 0 {
+		for v := start; v < stop; v += step {
+			values = append(values, v)
+		}
+	} else {
+		for v := start; v > stop; v += step {
+			values = append(values, v)
+		}
+	}
+	if values == nil {
+		values = []interface{}{}
+	}
+	return &Series{
+		name:  name,
+		data:  values,
+		dtype: DTypeInt64,
+		index: NewRangeIndex(len(values)),
+	}, nil
+}
+
 // Name returns the name of the Series
 func (s *Series) Name() string {
 	return s.name
@@ -147,10 +198,33 @@ func (s *Series) Values() []interface{} {
 	return s.data
 }
 
+// Items returns an iterator over (label, value) pairs in positional order.
+func (s *Series) Items() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		for i, v := range s.data {
+			label, _ := s.index.Get(i)
+			if !yield(label, v) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls fn for each element with its position and label.
+// Iteration stops early when fn returns false.
+func (s *Series) ForEach(fn func(i int, label, value interface{}) bool) {
+	for i, v := range s.data {
+		label, _ := s.index.Get(i)
+		if !fn(i, label, v) {
+			return
+		}
+	}
+}
+
 // Get returns the value at the specified position
 func (s *Series) Get(pos int) (interface{}, error) {
 	if pos < 0 || pos >= len(s.data) {
-		return nil, fmt.Errorf("index %d out of range [0, %d)", pos, len(s.data))
+		return nil, &IndexOutOfRangeError{Pos: pos, Len: len(s.data)}
 	}
 	return s.data[pos], nil
 }
@@ -164,16 +238,27 @@ func (s *Series) At(label interface{}) (interface{}, error) {
 	return s.data[pos], nil
 }
 
+// Asof returns the value at the last label that is less than or equal to
+// label, see Index.Asof. The value is returned as stored, even if it is NA.
+func (s *Series) Asof(label interface{}, opts ...SearchSortedOptions) (interface{}, error) {
+	pos, err := s.index.Asof(label, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.data[pos], nil
+}
+
 // Set sets the value at the specified position
 func (s *Series) Set(pos int, value interface{}) error {
 	if pos < 0 || pos >= len(s.data) {
-		return fmt.Errorf("index %d out of range [0, %d)", pos, len(s.data))
+		return &IndexOutOfRangeError{Pos: pos, Len: len(s.data)}
 	}
 	s.data[pos] = value
 	return nil
 }
 
-// Copy creates a copy of the Series
+// Copy creates a copy of the Series.
+// The returned Series owns its data, so mutating it never affects s.
 func (s *Series) Copy() *Series {
 	newData := make([]interface{}, len(s.data))
 	copy(newData, s.data)
@@ -182,140 +267,158 @@ func (s *Series) Copy() *Series {
 		data:  newData,
 		dtype: s.dtype,
 		index: s.index.Copy(),
+		na:    s.na,
 	}
 }
 
-// Head returns the first n elements
+// Head returns a copy of the first n elements
 func (s *Series) Head(n int) *Series {
 	if n > len(s.data) {
 		n = len(s.data)
 	}
-	return &Series{
-		name:  s.name,
-		data:  s.data[:n],
-		dtype: s.dtype,
-		index: s.index.Slice(0, n),
-	}
+	return s.Slice(0, n)
 }
 
-// Tail returns the last n elements
+// Tail returns a copy of the last n elements
 func (s *Series) Tail(n int) *Series {
 	if n > len(s.data) {
 		n = len(s.data)
 	}
-	start := len(s.data) - n
-	return &Series{
-		name:  s.name,
-		data:  s.data[start:],
-		dtype: s.dtype,
-		index: s.index.Slice(start, len(s.data)),
-	}
+	return s.Slice(len(s.data)-n, len(s.data))
 }
 
-// Slice returns a slice of the Series
+// Slice returns a copy of the elements from start to end.
+// Use SliceView to share the underlying data instead.
 func (s *Series) Slice(start, end int) *Series {
+	view := s.SliceView(start, end)
+	newData := make([]interface{}, len(view.data))
+	copy(newData, view.data)
+	view.data = newData
+	return view
+}
+
+// SliceView returns the elements from start to end without copying them.
+// The result shares its data with s: Set on either Series is visible
+// through the other. The index is always copied.
+func (s *Series) SliceView(start, end int) *Series {
 	if start < 0 {
 		start = 0
 	}
+	if start > len(s.data) {
+		start = len(s.data)
+	}
 	if end > len(s.data) {
 		end = len(s.data)
 	}
+	if end < start {
+		end = start
+	}
 	return &Series{
 		name:  s.name,
-		data:  s.data[start:end],
+		data:  s.data[start:end:end],
 		dtype: s.dtype,
 		index: s.index.Slice(start, end),
+		na:    s.na,
 	}
 }
 
 // ============ Statistical Methods ============
 
-// Sum returns the sum of all numeric values
+// Sum returns the sum of all numeric values.
+// Values that cannot be converted to float64 are skipped; use SumE to
+// detect them.
 func (s *Series) Sum() float64 {
-	var sum float64
-	for _, v := range s.data {
-		if v == nil || IsNA(v) {
-			continue
-		}
-		f, err := toFloat64(v)
-		if err == nil {
-			sum += f
-		}
+	values, _ := s.numericValues(false)
+	return sumFloat64s(values)
+}
+
+// SumE returns the sum of all numeric values, or an error if any non-NA
+// value cannot be converted to float64.
+func (s *Series) SumE() (float64, error) {
+	values, err := s.numericValues(true)
+	if err != nil {
+		return 0, err
 	}
-	return sum
+	return sumFloat64s(values), nil
 }
 
 // Mean returns the mean of all numeric values
 func (s *Series) Mean() float64 {
-	count := 0
-	var sum float64
-	for _, v := range s.data {
-		if v == nil || IsNA(v) {
-			continue
-		}
-		f, err := toFloat64(v)
-		if err == nil {
-			sum += f
-			count++
-		}
-	}
-	if count == 0 {
-		return math.NaN()
+	values, _ := s.numericValues(false)
+	return meanFloat64s(values)
+}
+
+// MeanE returns the mean of all numeric values, or an error if any non-NA
+// value cannot be converted to float64.
+func (s *Series) MeanE() (float64, error) {
+	values, err := s.numericValues(true)
+	if err != nil {
+		return math.NaN(), err
 	}
-	return sum / float64(count)
+	return meanFloat64s(values), nil
 }
 
 // Median returns the median of all numeric values
 func (s *Series) Median() float64 {
-	var values []float64
-	for _, v := range s.data {
-		if v == nil || IsNA(v) {
-			continue
-		}
-		f, err := toFloat64(v)
-		if err == nil {
-			values = append(values, f)
-		}
-	}
-	if len(values) == 0 {
-		return math.NaN()
-	}
-	sort.Float64s(values)
-	n := len(values)
-	if n%2 == 0 {
-		return (values[n/2-1] + values[n/2]) / 2
+	values, _ := s.numericValues(false)
+	return medianFloat64s(values)
+}
+
+// MedianE returns the median of all numeric values, or an error if any
+// non-NA value cannot be converted to float64.
+func (s *Series) MedianE() (float64, error) {
+	values, err := s.numericValues(true)
+	if err != nil {
+		return math.NaN(), err
 	}
-	return values[n/2]
+	return medianFloat64s(values), nil
 }
 
-// Std returns the standard deviation
-func (s *Series) Std() float64 {
-	return math.Sqrt(s.Var())
+// Std returns the standard deviation, the square root of Var.
+// ddof defaults to 1 (sample standard deviation); pass 0 for the population
+// standard deviation.
+func (s *Series) Std(ddof ...int) float64 {
+	return math.Sqrt(s.Var(ddof...))
 }
 
-// Var returns the variance
-func (s *Series) Var() float64 {
-	mean := s.Mean()
-	if math.IsNaN(mean) {
-		return math.NaN()
+// StdE returns the standard deviation, or an error if any non-NA value
+// cannot be converted to float64.
+func (s *Series) StdE(ddof ...int) (float64, error) {
+	v, err := s.VarE(ddof...)
+	if err != nil {
+		return math.NaN(), err
 	}
-	var sumSq float64
-	count := 0
-	for _, v := range s.data {
-		if v == nil || IsNA(v) {
-			continue
-		}
-		f, err := toFloat64(v)
-		if err == nil {
-			diff := f - mean
-			sumSq += diff * diff
-			count++
-		}
+	return math.Sqrt(v), nil
+}
+
+// Var returns the variance of the non-NA numeric values:
+//
+//	sum((x - mean)^2) / (n - ddof)
+//
+// ddof (delta degrees of freedom) defaults to 1, the sample variance used by
+// pandas; ddof=0 gives the population variance used by numpy. The result is
+// NaN when n <= ddof.
+func (s *Series) Var(ddof ...int) float64 {
+	values, _ := s.numericValues(false)
+	return varFloat64s(values, resolveDDOF(ddof))
+}
+
+// VarE returns the variance, or an error if any non-NA value cannot be
+// converted to float64.
+func (s *Series) VarE(ddof ...int) (float64, error) {
+	values, err := s.numericValues(true)
+	if err != nil {
+		return math.NaN(), err
 	}
-	if count <= 1 {
-		return math.NaN()
+	return varFloat64s(values, resolveDDOF(ddof)), nil
+}
+
+// resolveDDOF returns the delta degrees of freedom, defaulting to 1.
+func resolveDDOF(ddof []int) int {
+	if len(ddof) > 0 {
+		return ddof[0]
 	}
-	return sumSq / float64(count-1) // Sample variance (n-1)
+	return 1
 }
 
 // Min returns the minimum value
@@ -323,7 +426,7 @@ func (s *Series) Min() interface{} {
 	var minVal float64 = math.MaxFloat64
 	found := false
 	for _, v := range s.data {
-		if v == nil || IsNA(v) {
+		if s.isNA(v) {
 			continue
 		}
 		f, err := toFloat64(v)
@@ -345,7 +448,7 @@ func (s *Series) Max() interface{} {
 	var maxVal float64 = -math.MaxFloat64
 	found := false
 	for _, v := range s.data {
-		if v == nil || IsNA(v) {
+		if s.isNA(v) {
 			continue
 		}
 		f, err := toFloat64(v)
@@ -362,29 +465,335 @@ func (s *Series) Max() interface{} {
 	return maxVal
 }
 
+// MinE returns the minimum value, or an error if any non-NA value cannot
+// be converted to float64.
+func (s *Series) MinE() (interface{}, error) {
+	if _, err := s.numericValues(true); err != nil {
+		return nil, err
+	}
+	return s.Min(), nil
+}
+
+// MaxE returns the maximum value, or an error if any non-NA value cannot
+// be converted to float64.
+func (s *Series) MaxE() (interface{}, error) {
+	if _, err := s.numericValues(true); err != nil {
+		return nil, err
+	}
+	return s.Max(), nil
+}
+
+// SeriesSummary holds descriptive statistics of a Series.
+// Numeric Series fill Count through Max; other Series fill Count, NUnique,
+// Top and Freq.
+type SeriesSummary struct {
+	Name    string
+	DType   DType
+	Numeric bool
+
+	Count  int
+	Mean   float64
+	Std    float64
+	Min    float64
+	Q1     float64
+	Median float64
+	Q3     float64
+	Max    float64
+
+	NUnique int
+	Top     interface{} // most frequent non-NA value
+	Freq    int         // number of occurrences of Top
+}
+
+// Describe returns descriptive statistics of the Series.
+// Quartiles use linear interpolation, as pandas does by default.
+func (s *Series) Describe() SeriesSummary {
+	summary := SeriesSummary{
+		Name:    s.name,
+		DType:   s.dtype,
+		Numeric: s.IsNumeric(),
+		Count:   s.Count(),
+	}
+
+	if summary.Numeric {
+		values, _ := s.numericValues(false)
+		sorted := make([]float64, len(values))
+		copy(sorted, values)
+		sort.Float64s(sorted)
+		summary.Mean = meanFloat64s(values)
+		summary.Std = math.Sqrt(varFloat64s(values, 1))
+		summary.Min = quantileSorted(sorted, 0)
+		summary.Q1 = quantileSorted(sorted, 0.25)
+		summary.Median = quantileSorted(sorted, 0.5)
+		summary.Q3 = quantileSorted(sorted, 0.75)
+		summary.Max = quantileSorted(sorted, 1)
+		return summary
+	}
+
+	counts := make(map[interface{}]int)
+	for _, v := range s.data {
+		if s.isNA(v) {
+			continue
+		}
+		key := hashKey(v)
+		counts[key]++
+		if counts[key] > summary.Freq {
+			summary.Top = v
+			summary.Freq = counts[key]
+		}
+	}
+	summary.NUnique = len(counts)
+	return summary
+}
+
+// describeMoments is Describe for the count, mean, std, min and max of a
+// numeric Series, computed in a single pass over the values with the
+// variance accumulated by Welford's method. The quartiles are NaN, and only
+// Count and Numeric are set for non-numeric Series.
+func (s *Series) describeMoments() SeriesSummary {
+	nan := math.NaN()
+	summary := SeriesSummary{
+		Name:   s.name,
+		DType:  s.dtype,
+		Mean:   nan,
+		Std:    nan,
+		Min:    nan,
+		Q1:     nan,
+		Median: nan,
+		Q3:     nan,
+		Max:    nan,
+	}
+
+	var n int
+	var sum, mean, m2 float64
+	hasValue := false
+	for _, v := range s.data {
+		if s.isNA(v) {
+			continue
+		}
+		summary.Count++
+		f, err := toFloat64(v)
+		if err != nil {
+			hasValue = true
+			continue
+		}
+		n++
+		sum += f
+		delta := f - mean
+		mean += delta / float64(n)
+		m2 += delta * (f - mean)
+		if n == 1 || f < summary.Min {
+			summary.Min = f
+		}
+		if n == 1 || f > summary.Max {
+			summary.Max = f
+		}
+	}
+
+	summary.Numeric = n > 0 || !hasValue
+	if n > 0 {
+		summary.Mean = sum / float64(n)
+	}
+	if n > 1 {
+		summary.Std = math.Sqrt(m2 / float64(n-1))
+	}
+	return summary
+}
+
+// String returns a readable representation of the summary.
+func (ss SeriesSummary) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Summary: %s (dtype: %s)\n", ss.Name, ss.DType))
+	if ss.Numeric {
+		sb.WriteString(fmt.Sprintf("count     %d\n", ss.Count))
+		sb.WriteString(fmt.Sprintf("mean      %g\n", ss.Mean))
+		sb.WriteString(fmt.Sprintf("std       %g\n", ss.Std))
+		sb.WriteString(fmt.Sprintf("min       %g\n", ss.Min))
+		sb.WriteString(fmt.Sprintf("25%%       %g\n", ss.Q1))
+		sb.WriteString(fmt.Sprintf("50%%       %g\n", ss.Median))
+		sb.WriteString(fmt.Sprintf("75%%       %g\n", ss.Q3))
+		sb.WriteString(fmt.Sprintf("max       %g\n", ss.Max))
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("count     %d\n", ss.Count))
+	sb.WriteString(fmt.Sprintf("unique    %d\n", ss.NUnique))
+	sb.WriteString(fmt.Sprintf("top       %v\n", ss.Top))
+	sb.WriteString(fmt.Sprintf("freq      %d\n", ss.Freq))
+	return sb.String()
+}
+
+// quantileSorted returns the q-th quantile of sorted values using linear
+// interpolation between the closest ranks. It returns NaN for no values.
+func quantileSorted(sorted []float64, q float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(n-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
+
+// IsNumeric reports whether the Series holds numeric data, that is, whether
+// at least one non-NA value converts to float64 or all values are NA.
+func (s *Series) IsNumeric() bool {
+	hasValue := false
+	for _, v := range s.data {
+		if s.isNA(v) {
+			continue
+		}
+		if _, err := toFloat64(v); err == nil {
+			return true
+		}
+		hasValue = true
+	}
+	return !hasValue
+}
+
+// numericValues collects the non-NA values converted to float64.
+// In strict mode the first value that cannot be converted is reported as an
+// error naming the Series and its dtype; otherwise such values are skipped.
+func (s *Series) numericValues(strict bool) ([]float64, error) {
+	values := make([]float64, 0, len(s.data))
+	for i, v := range s.data {
+		if s.isNA(v) {
+			continue
+		}
+		f, err := toFloat64(v)
+		if err != nil {
+			if strict {
+				return nil, &TypeConversionError{Value: v, Target: DTypeFloat64,
+					Err: fmt.Errorf("series '%s' (dtype: %s): value %v at position %d is not numeric", s.name, s.dtype, v, i)}
+			}
+			continue
+		}
+		values = append(values, f)
+	}
+	return values, nil
+}
+
+func sumFloat64s(values []float64) float64 {
+	var sum float64
+	for _, f := range values {
+		sum += f
+	}
+	return sum
+}
+
+func meanFloat64s(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return sumFloat64s(values) / float64(len(values))
+}
+
+func medianFloat64s(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
+
+func varFloat64s(values []float64, ddof int) float64 {
+	count := len(values)
+	if count <= ddof || count == 0 {
+		return math.NaN()
+	}
+	mean := meanFloat64s(values)
+	var sumSq float64
+	for _, f := range values {
+		diff := f - mean
+		sumSq += diff * diff
+	}
+	return sumSq / float64(count-ddof)
+}
+
 // Count returns the number of non-NA values
 func (s *Series) Count() int {
 	count := 0
 	for _, v := range s.data {
-		if v != nil && !IsNA(v) {
+		if !s.isNA(v) {
 			count++
 		}
 	}
 	return count
 }
 
-// Unique returns a Series with unique values
+// Unique returns a Series with unique values in order of first appearance.
+// Values are compared type-aware, so int64(1) and "1" are distinct.
 func (s *Series) Unique() *Series {
-	seen := make(map[interface{}]bool)
-	var unique []interface{}
+	return tallyValues(s.data).uniqueSeries(s.name)
+}
+
+// FactorizeOptions defines options for Factorize.
+type FactorizeOptions struct {
+	Sort bool // sort uniques before assigning codes
+}
+
+// Factorize encodes the Series as integer codes.
+// codes is an int64 Series assigning 0..k-1 to the distinct non-NA values in
+// order of first appearance (or sorted order with Sort), with -1 for NA
+// values. uniques lists the distinct values in code order.
+func (s *Series) Factorize(opts ...FactorizeOptions) (codes *Series, uniques *Series) {
+	var opt FactorizeOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	positions := make(map[interface{}]int64)
+	var values []interface{}
 	for _, v := range s.data {
-		key := fmt.Sprintf("%v", v)
-		if !seen[key] {
-			seen[key] = true
-			unique = append(unique, v)
+		if s.isNA(v) {
+			continue
+		}
+		key := hashKey(v)
+		if _, ok := positions[key]; !ok {
+			positions[key] = int64(len(values))
+			values = append(values, v)
+		}
+	}
+
+	if opt.Sort {
+		sort.SliceStable(values, func(i, j int) bool {
+			return compareValues(values[i], values[j]) < 0
+		})
+		for i, v := range values {
+			positions[hashKey(v)] = int64(i)
+		}
+	}
+
+	codeData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		if s.isNA(v) {
+			codeData[i] = int64(-1)
+			continue
 		}
+		codeData[i] = positions[hashKey(v)]
+	}
+
+	if values == nil {
+		values = []interface{}{}
+	}
+	codes = &Series{
+		name:  s.name,
+		data:  codeData,
+		dtype: DTypeInt64,
+		index: s.index.Copy(),
+		na:    s.na,
 	}
-	return NewSeries(unique, s.name)
+	return codes, NewSeries(values, s.name)
 }
 
 // NUnique returns the number of unique values
@@ -392,29 +801,18 @@ func (s *Series) NUnique() int {
 	return s.Unique().Len()
 }
 
-// ValueCounts returns a Series with counts of unique values
+// ValueCounts returns a Series with the number of occurrences of each unique
+// value, indexed by the values. Values are compared like Unique compares
+// them, and the counts are sorted largest first, with equal counts in order
+// of first appearance.
 func (s *Series) ValueCounts() *Series {
-	counts := make(map[string]int)
-	for _, v := range s.data {
-		key := fmt.Sprintf("%v", v)
-		counts[key]++
-	}
-
-	var labels []interface{}
-	var values []interface{}
-	for k, v := range counts {
-		labels = append(labels, k)
-		values = append(values, v)
-	}
-
-	result := NewSeries(values, "count")
-	result.index = NewIndex(labels, s.name)
-	return result
+	return tallyValues(s.data).countsSeries(s.name)
 }
 
 // ============ Data Manipulation Methods ============
 
-// Apply applies a function to each element
+// Apply applies a function to each element.
+// The dtype of the result is inferred from the returned values.
 func (s *Series) Apply(fn func(interface{}) interface{}) *Series {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
@@ -423,53 +821,161 @@ func (s *Series) Apply(fn func(interface{}) interface{}) *Series {
 	return &Series{
 		name:  s.name,
 		data:  newData,
-		dtype: DTypeObject,
+		dtype: InferDTypeFromSlice(newData),
 		index: s.index.Copy(),
+		na:    s.na,
 	}
 }
 
-// Map applies a mapping to each element
-func (s *Series) Map(mapping map[interface{}]interface{}) *Series {
+// ApplyWithIndex applies a function to each element together with its
+// index label.
+func (s *Series) ApplyWithIndex(fn func(label, value interface{}) interface{}) *Series {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
-		if mapped, ok := mapping[v]; ok {
-			newData[i] = mapped
-		} else {
-			newData[i] = v
-		}
+		label, _ := s.index.Get(i)
+		newData[i] = fn(label, v)
 	}
 	return &Series{
 		name:  s.name,
 		data:  newData,
-		dtype: DTypeObject,
+		dtype: InferDTypeFromSlice(newData),
 		index: s.index.Copy(),
+		na:    s.na,
 	}
 }
 
-// FillNA fills NA values with the specified value
-func (s *Series) FillNA(value interface{}) *Series {
+// TryApply applies a function that may fail to each element.
+// It stops at the first error and reports the position of the failing
+// element.
+func (s *Series) TryApply(fn func(interface{}) (interface{}, error)) (*Series, error) {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
-		if v == nil || IsNA(v) {
-			newData[i] = value
-		} else {
-			newData[i] = v
+		result, err := fn(v)
+		if err != nil {
+			return nil, fmt.Errorf("apply failed at position %d: %w", i, err)
 		}
+		newData[i] = result
 	}
 	return &Series{
 		name:  s.name,
 		data:  newData,
-		dtype: s.dtype,
+		dtype: InferDTypeFromSlice(newData),
 		index: s.index.Copy(),
+		na:    s.na,
+	}, nil
+}
+
+// Reduce folds the values of the Series in order: fn is called with the
+// accumulator, starting at init, and each value, and returns the new
+// accumulator. NA values are passed to fn like any other value.
+func (s *Series) Reduce(init interface{}, fn func(acc, v interface{}) interface{}) interface{} {
+	acc := init
+	for _, v := range s.data {
+		acc = fn(acc, v)
 	}
+	return acc
 }
 
-// DropNA removes NA values
-func (s *Series) DropNA() *Series {
-	var newData []interface{}
-	var newLabels []interface{}
-	for i, v := range s.data {
-		if v != nil && !IsNA(v) {
+// Map applies a mapping to each element
+func (s *Series) Map(mapping map[interface{}]interface{}) *Series {
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		if mapped, ok := mapping[v]; ok {
+			newData[i] = mapped
+		} else {
+			newData[i] = v
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: DTypeObject,
+		index: s.index.Copy(),
+		na:    s.na,
+	}
+}
+
+// FillNA fills NA values with the specified value.
+// The dtype is re-inferred, so filling a string Series with a number gives
+// an object Series.
+func (s *Series) FillNA(value interface{}) *Series {
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		if s.isNA(v) {
+			newData[i] = value
+		} else {
+			newData[i] = v
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: InferDTypeFromSlice(newData),
+		index: s.index.Copy(),
+		na:    s.na,
+	}
+}
+
+// FFill fills NA values with the last preceding non-NA value.
+// limit caps the number of consecutive NA values filled; 0 means no limit.
+func (s *Series) FFill(limit int) *Series {
+	newData := make([]interface{}, len(s.data))
+	copy(newData, s.data)
+	fillForward(newData, limit, s.naConfig())
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: s.dtype,
+		index: s.index.Copy(),
+		na:    s.na,
+	}
+}
+
+// BFill fills NA values with the next following non-NA value.
+// limit caps the number of consecutive NA values filled; 0 means no limit.
+func (s *Series) BFill(limit int) *Series {
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		newData[len(s.data)-1-i] = v
+	}
+	fillForward(newData, limit, s.naConfig())
+	for i, j := 0, len(newData)-1; i < j; i, j = i+1, j-1 {
+		newData[i], newData[j] = newData[j], newData[i]
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: s.dtype,
+		index: s.index.Copy(),
+		na:    s.na,
+	}
+}
+
+// fillForward propagates non-NA values forward over NA values in place.
+func fillForward(values []interface{}, limit int, na *naConfig) {
+	var last interface{}
+	hasLast := false
+	run := 0
+	for i, v := range values {
+		if !na.isNA(v) {
+			last = v
+			hasLast = true
+			run = 0
+			continue
+		}
+		run++
+		if hasLast && (limit <= 0 || run <= limit) {
+			values[i] = last
+		}
+	}
+}
+
+// DropNA removes NA values
+func (s *Series) DropNA() *Series {
+	var newData []interface{}
+	var newLabels []interface{}
+	for i, v := range s.data {
+		if !s.isNA(v) {
 			newData = append(newData, v)
 			label, _ := s.index.Get(i)
 			newLabels = append(newLabels, label)
@@ -480,14 +986,167 @@ func (s *Series) DropNA() *Series {
 		data:  newData,
 		dtype: s.dtype,
 		index: NewIndex(newLabels, s.index.Name()),
+		na:    s.na,
 	}
 }
 
+// Where keeps the values where cond is true and replaces the others with
+// other, which may be a scalar, a *Series of the same length, or a
+// func(interface{}) interface{} called with the original value.
+// NA entries in cond count as false.
+func (s *Series) Where(cond *Series, other interface{}) (*Series, error) {
+	return s.where(cond, other, false)
+}
+
+// Mask is the inverse of Where: it replaces the values where cond is true.
+// NA entries in cond count as true.
+func (s *Series) Mask(cond *Series, other interface{}) (*Series, error) {
+	return s.where(cond, other, true)
+}
+
+func (s *Series) where(cond *Series, other interface{}, invert bool) (*Series, error) {
+	if cond == nil {
+		return nil, fmt.Errorf("condition series is nil")
+	}
+	if cond.Len() != len(s.data) {
+		return nil, lengthMismatch(len(s.data), cond.Len(), "condition length %d does not match series length %d", cond.Len(), len(s.data))
+	}
+	otherSeries, isSeries := other.(*Series)
+	if isSeries && otherSeries.Len() != len(s.data) {
+		return nil, lengthMismatch(len(s.data), otherSeries.Len(), "other length %d does not match series length %d", otherSeries.Len(), len(s.data))
+	}
+	otherFunc, isFunc := other.(func(interface{}) interface{})
+
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		c := cond.data[i]
+		var keep bool
+		if cond.isNA(c) {
+			// NA is false for Where and true for Mask: replaced either way
+			keep = false
+		} else {
+			b, err := toBool(c)
+			if err != nil {
+				return nil, fmt.Errorf("condition element %d: %w", i, err)
+			}
+			keep = b != invert
+		}
+		switch {
+		case keep:
+			newData[i] = v
+		case isSeries:
+			newData[i] = otherSeries.data[i]
+		case isFunc:
+			newData[i] = otherFunc(v)
+		default:
+			newData[i] = other
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: InferDTypeFromSlice(newData),
+		index: s.index.Copy(),
+		na:    s.na,
+	}, nil
+}
+
+// Repeat repeats each element, along with its index label. counts is either
+// an int applied to every element or an integer Series of the same length
+// giving the repetitions per element. Negative counts are an error.
+func (s *Series) Repeat(counts interface{}) (*Series, error) {
+	reps := make([]int, len(s.data))
+	switch c := counts.(type) {
+	case *Series:
+		if c.Len() != len(s.data) {
+			return nil, lengthMismatch(len(s.data), c.Len(), "counts length %d does not match series length %d", c.Len(), len(s.data))
+		}
+		for i, v := range c.data {
+			n, err := toInt64(v)
+			if err != nil {
+				return nil, fmt.Errorf("counts element %d: %w", i, err)
+			}
+			reps[i] = int(n)
+		}
+	default:
+		n, err := toInt64(counts)
+		if err != nil || !isNumber(counts) {
+			return nil, fmt.Errorf("counts must be an int or a Series, got %T", counts)
+		}
+		for i := range reps {
+			reps[i] = int(n)
+		}
+	}
+
+	total := 0
+	for i, n := range reps {
+		if n < 0 {
+			return nil, fmt.Errorf("negative count %d at position %d", n, i)
+		}
+		total += n
+	}
+
+	newData := make([]interface{}, 0, total)
+	positions := make([]int, 0, total)
+	for i, n := range reps {
+		for j := 0; j < n; j++ {
+			newData = append(newData, s.data[i])
+			positions = append(positions, i)
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: s.dtype,
+		index: NewIndex(extractLabels(s.index, positions), s.index.Name()),
+		na:    s.na,
+	}, nil
+}
+
+// Explode expands every slice element into one row per item, repeating its
+// index label. Empty slices and nil produce a single nil row; other values
+// pass through unchanged.
+func (s *Series) Explode() *Series {
+	newData, positions := explodeValues(s.data)
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: InferDTypeFromSlice(newData),
+		index: NewIndex(extractLabels(s.index, positions), s.index.Name()),
+		na:    s.na,
+	}
+}
+
+// explodeValues expands slice values and returns the new values together
+// with the source position of each of them.
+func explodeValues(values []interface{}) ([]interface{}, []int) {
+	newData := make([]interface{}, 0, len(values))
+	positions := make([]int, 0, len(values))
+	for i, v := range values {
+		rv := reflect.ValueOf(v)
+		if v == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			newData = append(newData, v)
+			positions = append(positions, i)
+			continue
+		}
+		if rv.Len() == 0 {
+			newData = append(newData, nil)
+			positions = append(positions, i)
+			continue
+		}
+		for j := 0; j < rv.Len(); j++ {
+			newData = append(newData, rv.Index(j).Interface())
+			positions = append(positions, i)
+		}
+	}
+	return newData, positions
+}
+
 // IsNA returns a boolean Series indicating NA values
 func (s *Series) IsNA() *Series {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
-		newData[i] = v == nil || IsNA(v)
+		newData[i] = s.isNA(v)
 	}
 	return &Series{
 		name:  s.name + "_isna",
@@ -497,11 +1156,48 @@ func (s *Series) IsNA() *Series {
 	}
 }
 
+// Any reports whether any value is true. Non-bool values are converted with
+// the ConvertToType rules; nil and values that cannot be converted count as
+// false, so an empty or all-nil Series gives false.
+func (s *Series) Any() bool {
+	for _, v := range s.data {
+		if b, ok := truthValue(v); ok && b {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether every value is true. Non-bool values are converted
+// with the ConvertToType rules; nil and values that cannot be converted are
+// skipped (count as true), so an empty or all-nil Series gives true.
+func (s *Series) All() bool {
+	for _, v := range s.data {
+		if b, ok := truthValue(v); ok && !b {
+			return false
+		}
+	}
+	return true
+}
+
+// truthValue converts v to a bool, reporting false for nil and values that
+// cannot be converted.
+func truthValue(v interface{}) (bool, bool) {
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	if v == nil {
+		return false, false
+	}
+	b, err := toBool(v)
+	return b, err == nil
+}
+
 // NotNA returns a boolean Series indicating non-NA values
 func (s *Series) NotNA() *Series {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
-		newData[i] = v != nil && !IsNA(v)
+		newData[i] = !s.isNA(v)
 	}
 	return &Series{
 		name:  s.name + "_notna",
@@ -513,10 +1209,52 @@ func (s *Series) NotNA() *Series {
 
 // AsType converts the Series to the specified data type
 func (s *Series) AsType(dtype DType) (*Series, error) {
+	return s.asType(dtype, CastOptions{})
+}
+
+// AsTypeOpts converts the Series to the specified data type as opts say.
+// With CastCoerce values that cannot be converted become nil, and with
+// CastIgnore a Series that cannot be converted is returned unchanged.
+func (s *Series) AsTypeOpts(dtype DType, opts CastOptions) (*Series, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	converted, err := s.asType(dtype, opts)
+	if err != nil && opts.Errors == CastIgnore {
+		return s.Copy(), nil
+	}
+	return converted, err
+}
+
+// ParseNumeric converts the strings of the Series, such as "1,234.5", "85%"
+// or "$1,000", to float64 numbers as opts says; numbers are kept. NA
+// values, such as blank cells, become nil. Other values that cannot be
+// parsed are an error, or nil with opts.Errors CastCoerce.
+func (s *Series) ParseNumeric(opts NumericParseOptions) (*Series, error) {
+	switch opts.Errors {
+	case "", CastRaise, CastCoerce:
+	default:
+		return nil, fmt.Errorf("unknown numeric parse error policy '%s'", opts.Errors)
+	}
+	return s.AsTypeOpts(DTypeFloat64, CastOptions{Errors: opts.Errors, Numeric: opts})
+}
+
+// asType converts the Series to dtype, rounding floats and parsing datetimes
+// as opts say. With
+// CastCoerce, values that cannot be converted become nil instead of failing
+// the conversion.
+func (s *Series) asType(dtype DType, opts CastOptions) (*Series, error) {
 	newData := make([]interface{}, len(s.data))
 	for i, v := range s.data {
-		converted, err := ConvertToType(v, dtype)
+		if s.isNA(v) {
+			// Blank and NA cells are missing values, not conversion failures
+			continue
+		}
+		converted, err := ConvertToType(v, dtype, opts)
 		if err != nil {
+			if opts.Errors == CastCoerce {
+				continue
+			}
 			return nil, fmt.Errorf("error converting element %d: %w", i, err)
 		}
 		newData[i] = converted
@@ -526,6 +1264,7 @@ func (s *Series) AsType(dtype DType) (*Series, error) {
 		data:  newData,
 		dtype: dtype,
 		index: s.index.Copy(),
+		na:    s.na,
 	}, nil
 }
 
@@ -582,62 +1321,532 @@ func (s *Series) SortValues(ascending bool) *Series {
 		data:  newData,
 		dtype: s.dtype,
 		index: NewIndex(newLabels, s.index.Name()),
+		na:    s.na,
 	}
 }
 
-// ============ String Representation ============
+// ============ Searching ============
 
-// String returns the string representation of the Series
-func (s *Series) String() string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Series: %s (dtype: %s, length: %d)\n", s.name, s.dtype, len(s.data)))
+var (
+	// ErrUnsortedSeries is returned by SearchSorted when the Series is not
+	// monotonically increasing.
+	ErrUnsortedSeries = errors.New("series is not sorted in increasing order")
+	// ErrIncomparableValue is returned by SearchSorted when a value cannot be
+	// ordered against the Series values.
+	ErrIncomparableValue = errors.New("value is not comparable")
+)
 
-	maxShow := 10
-	if len(s.data) <= maxShow*2 {
-		for i, v := range s.data {
-			label, _ := s.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v    %v\n", label, v))
+// SearchSortedOptions defines options for SearchSorted.
+type SearchSortedOptions struct {
+	AssumeSorted bool // skip the monotonicity check
+}
+
+// SearchSorted returns the position at which value would be inserted to keep
+// the Series sorted. side is "left" (first suitable position) or "right"
+// (last suitable position). The Series must be monotonically increasing;
+// numbers, strings and time.Time values are supported.
+func (s *Series) SearchSorted(value interface{}, side string, opts ...SearchSortedOptions) (int, error) {
+	positions, err := s.searchSorted([]interface{}{value}, side, opts...)
+	if err != nil {
+		return -1, err
+	}
+	return positions[0], nil
+}
+
+// SearchSortedMany is like SearchSorted for several values at once and
+// returns the insertion positions as an int64 Series.
+func (s *Series) SearchSortedMany(values []interface{}, side string, opts ...SearchSortedOptions) (*Series, error) {
+	positions, err := s.searchSorted(values, side, opts...)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]interface{}, len(positions))
+	for i, p := range positions {
+		data[i] = int64(p)
+	}
+	return &Series{
+		name:  s.name,
+		data:  data,
+		dtype: DTypeInt64,
+		index: NewRangeIndex(len(data)),
+		na:    s.na,
+	}, nil
+}
+
+func (s *Series) searchSorted(values []interface{}, side string, opts ...SearchSortedOptions) ([]int, error) {
+	var opt SearchSortedOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if side != "left" && side != "right" {
+		return nil, fmt.Errorf("invalid side %q: must be \"left\" or \"right\"", side)
+	}
+
+	if !opt.AssumeSorted {
+		for i := 1; i < len(s.data); i++ {
+			c, ok := compareOrdered(s.data[i-1], s.data[i])
+			if !ok {
+				return nil, fmt.Errorf("series '%s' position %d: %w", s.name, i, ErrIncomparableValue)
+			}
+			if c > 0 {
+				return nil, fmt.Errorf("series '%s' position %d: %w", s.name, i, ErrUnsortedSeries)
+			}
 		}
-	} else {
-		// Show first and last elements
-		for i := 0; i < maxShow; i++ {
-			label, _ := s.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v    %v\n", label, s.data[i]))
+	}
+
+	positions := make([]int, len(values))
+	for i, value := range values {
+		var cmpErr error
+		pos := sort.Search(len(s.data), func(j int) bool {
+			c, ok := compareOrdered(s.data[j], value)
+			if !ok {
+				cmpErr = fmt.Errorf("%v (%T): %w", value, value, ErrIncomparableValue)
+				return true
+			}
+			if side == "left" {
+				return c >= 0
+			}
+			return c > 0
+		})
+		if cmpErr != nil {
+			return nil, cmpErr
 		}
-		sb.WriteString("...\n")
-		for i := len(s.data) - maxShow; i < len(s.data); i++ {
-			label, _ := s.index.Get(i)
-			sb.WriteString(fmt.Sprintf("%v    %v\n", label, s.data[i]))
+		positions[i] = pos
+	}
+	return positions, nil
+}
+
+// compareOrdered compares two values of the same kind: numbers, strings or
+// times. It reports false if the values cannot be ordered against each other.
+func compareOrdered(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	case time.Time:
+		bv, ok := b.(time.Time)
+		if !ok {
+			return 0, false
 		}
+		return av.Compare(bv), true
 	}
-	return sb.String()
+	if !isNumber(a) || !isNumber(b) {
+		return 0, false
+	}
+	fa, _ := toFloat64(a)
+	fb, _ := toFloat64(b)
+	if fa != fa || fb != fb {
+		return 0, false
+	}
+	switch {
+	case fa < fb:
+		return -1, true
+	case fa > fb:
+		return 1, true
+	}
+	return 0, true
+}
+
+// isNumber reports whether v is a Go integer or floating-point value.
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// ============ Typed Extraction ============
+
+// Float64s returns the values as a []float64 using the ConvertToType
+// coercion rules. NaN values are kept as NaN; any other NA value (nil, "NA",
+// ...) is an error. Use Float64sLenient to map NA values to NaN instead.
+func (s *Series) Float64s() ([]float64, error) {
+	return extractTyped(s, false, math.NaN(), toFloat64)
+}
+
+// Float64sLenient is like Float64s but maps NA values to NaN.
+func (s *Series) Float64sLenient() ([]float64, error) {
+	return extractTyped(s, true, math.NaN(), toFloat64)
+}
+
+// Int64s returns the values as a []int64 using the ConvertToType coercion
+// rules. NA values are an error; use Int64sLenient to map them to 0.
+func (s *Series) Int64s() ([]int64, error) {
+	return extractTyped(s, false, 0, toInt64)
+}
+
+// Int64sLenient is like Int64s but maps NA values to 0.
+func (s *Series) Int64sLenient() ([]int64, error) {
+	return extractTyped(s, true, 0, toInt64)
+}
+
+// Strings returns the values as a []string. String values are returned
+// as-is, including empty strings; other values are formatted with %v.
+// nil values are an error; use StringsLenient to map them to "".
+func (s *Series) Strings() ([]string, error) {
+	return extractTyped(s, false, "", toString)
+}
+
+// StringsLenient is like Strings but maps NA values to "".
+func (s *Series) StringsLenient() ([]string, error) {
+	return extractTyped(s, true, "", toString)
+}
+
+// Bools returns the values as a []bool using the ConvertToType coercion
+// rules. NA values are an error; use BoolsLenient to map them to false.
+func (s *Series) Bools() ([]bool, error) {
+	return extractTyped(s, false, false, toBool)
+}
+
+// BoolsLenient is like Bools but maps NA values to false.
+func (s *Series) BoolsLenient() ([]bool, error) {
+	return extractTyped(s, true, false, toBool)
+}
+
+// Times returns the values as a []time.Time using the ConvertToType
+// coercion rules. NA values are an error; use TimesLenient to map them to
+// the zero time.
+func (s *Series) Times() ([]time.Time, error) {
+	return extractTyped(s, false, time.Time{}, toDateTime)
+}
+
+// TimesLenient is like Times but maps NA values to the zero time.
+func (s *Series) TimesLenient() ([]time.Time, error) {
+	return extractTyped(s, true, time.Time{}, toDateTime)
+}
+
+// TzLocalize returns the DTypeDateTime Series with its times placed in loc,
+// keeping their clock time: 09:00 UTC becomes 09:00 in loc. It is used for
+// times parsed without a time zone, which are read as UTC. NA values are
+// kept.
+func (s *Series) TzLocalize(loc *time.Location) (*Series, error) {
+	return s.mapTimes("TzLocalize", loc, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	})
+}
+
+// TzConvert returns the DTypeDateTime Series with its times shown in loc,
+// keeping the instant they refer to: 09:00 UTC becomes 17:00 in
+// Asia/Shanghai. NA values are kept.
+func (s *Series) TzConvert(loc *time.Location) (*Series, error) {
+	return s.mapTimes("TzConvert", loc, func(t time.Time) time.Time {
+		return t.In(loc)
+	})
+}
+
+// mapTimes applies fn to the times of a DTypeDateTime Series for the method
+// op, failing on other dtypes and on a nil location.
+func (s *Series) mapTimes(op string, loc *time.Location, fn func(time.Time) time.Time) (*Series, error) {
+	if s.dtype != DTypeDateTime {
+		return nil, fmt.Errorf("%s: series '%s' (dtype: %s) is not datetime", op, s.name, s.dtype)
+	}
+	if loc == nil {
+		return nil, fmt.Errorf("%s: nil location", op)
+	}
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		if t, ok := v.(time.Time); ok && !s.isNA(t) {
+			newData[i] = fn(t)
+		} else {
+			newData[i] = v
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: s.dtype,
+		index: s.index.Copy(),
+		na:    s.na,
+	}, nil
+}
+
+// extractTyped converts every value of the Series to T. Values already of
+// type T are taken as-is; NA values become missing when lenient is set and
+// are reported as an error otherwise.
+func extractTyped[T any](s *Series, lenient bool, missing T, convert func(interface{}) (T, error)) ([]T, error) {
+	result := make([]T, len(s.data))
+	for i, v := range s.data {
+		if typed, ok := v.(T); ok {
+			result[i] = typed
+			continue
+		}
+		if s.isNA(v) {
+			if !lenient {
+				return nil, fmt.Errorf("series '%s': NA value at position %d cannot be converted to %T", s.name, i, missing)
+			}
+			result[i] = missing
+			continue
+		}
+		converted, err := convert(v)
+		if err != nil {
+			err = &TypeConversionError{Value: v, Target: InferDType(missing), Err: err}
+			return nil, fmt.Errorf("series '%s': error converting element %d: %w", s.name, i, err)
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// ============ Element-wise Math ============
+
+// Abs returns the absolute value of each element.
+// Integer values stay int64; nil and non-numeric values pass through
+// unchanged.
+func (s *Series) Abs() *Series {
+	return s.mathOp(func(v int64) int64 {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}, math.Abs)
+}
+
+// Round rounds each element to the given number of decimals, rounding half
+// away from zero. Negative decimals round to tens, hundreds, and so on.
+// Integer values stay int64; nil and non-numeric values pass through
+// unchanged.
+func (s *Series) Round(decimals int) *Series {
+	return s.mathOp(func(v int64) int64 {
+		if decimals >= 0 {
+			return v
+		}
+		return int64(roundFloat(float64(v), decimals))
+	}, func(v float64) float64 {
+		return roundFloat(v, decimals)
+	})
+}
+
+// Floor rounds each element down to the nearest integer.
+// Integer values stay int64; nil and non-numeric values pass through
+// unchanged.
+func (s *Series) Floor() *Series {
+	return s.mathOp(func(v int64) int64 { return v }, math.Floor)
+}
+
+// Ceil rounds each element up to the nearest integer.
+// Integer values stay int64; nil and non-numeric values pass through
+// unchanged.
+func (s *Series) Ceil() *Series {
+	return s.mathOp(func(v int64) int64 { return v }, math.Ceil)
+}
+
+// Clip limits each element to the range [lower, upper]; a nil bound leaves
+// that side open. Integer values stay integers and are clipped to the
+// nearest integer inside a fractional bound. nil, NaN and non-numeric values
+// pass through unchanged. Bounds that are not numeric, or a lower bound
+// above the upper one, are an error.
+func (s *Series) Clip(lower, upper interface{}) (*Series, error) {
+	lo, hi, err := clipBounds(lower, upper)
+	if err != nil {
+		return nil, err
+	}
+	return s.mathOp(func(v int64) int64 {
+		switch f := float64(v); {
+		case f < lo:
+			return int64(math.Ceil(lo))
+		case f > hi:
+			return int64(math.Floor(hi))
+		}
+		return v
+	}, func(v float64) float64 {
+		switch {
+		case v < lo:
+			return lo
+		case v > hi:
+			return hi
+		}
+		return v
+	}), nil
+}
+
+// clipBounds converts the bounds of Clip to float64, with infinities for
+// nil bounds.
+func clipBounds(lower, upper interface{}) (float64, float64, error) {
+	bounds := [2]float64{math.Inf(-1), math.Inf(1)}
+	for i, b := range []interface{}{lower, upper} {
+		if b == nil {
+			continue
+		}
+		f, err := toFloat64(b)
+		if err != nil {
+			return 0, 0, fmt.Errorf("clip bound %v is not numeric: %w", b, &TypeConversionError{Value: b, Target: DTypeFloat64, Err: err})
+		}
+		bounds[i] = f
+	}
+	if bounds[0] > bounds[1] {
+		return 0, 0, fmt.Errorf("clip lower bound %v is greater than upper bound %v", lower, upper)
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// mathOp applies intOp to integer values and floatOp to floating-point
+// values, leaving all other values untouched.
+func (s *Series) mathOp(intOp func(int64) int64, floatOp func(float64) float64) *Series {
+	newData := make([]interface{}, len(s.data))
+	for i, v := range s.data {
+		switch val := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			n, _ := toInt64(val)
+			newData[i] = intOp(n)
+		case float32, float64:
+			f, _ := toFloat64(val)
+			newData[i] = floatOp(f)
+		default:
+			newData[i] = v
+		}
+	}
+	return &Series{
+		name:  s.name,
+		data:  newData,
+		dtype: InferDTypeFromSlice(newData),
+		index: s.index.Copy(),
+		na:    s.na,
+	}
+}
+
+// roundFloat rounds v to the given number of decimals.
+func roundFloat(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
 }
 
 // ============ Arithmetic Operations ============
 
 // Add adds a value or Series to this Series
 func (s *Series) Add(other interface{}) *Series {
-	return s.arithmeticOp(other, func(a, b float64) float64 { return a + b })
+	return s.arithmeticOp(other, opAdd)
 }
 
 // Sub subtracts a value or Series from this Series
 func (s *Series) Sub(other interface{}) *Series {
-	return s.arithmeticOp(other, func(a, b float64) float64 { return a - b })
+	return s.arithmeticOp(other, opSub)
 }
 
 // Mul multiplies this Series by a value or Series
 func (s *Series) Mul(other interface{}) *Series {
-	return s.arithmeticOp(other, func(a, b float64) float64 { return a * b })
+	return s.arithmeticOp(other, opMul)
 }
 
 // Div divides this Series by a value or Series
 func (s *Series) Div(other interface{}) *Series {
-	return s.arithmeticOp(other, func(a, b float64) float64 {
-		if b == 0 {
-			return math.NaN()
+	return s.arithmeticOp(other, opDiv)
+}
+
+// Pow raises this Series to the power of a value or Series
+func (s *Series) Pow(other interface{}) *Series {
+	return s.arithmeticOp(other, math.Pow)
+}
+
+// Mod computes the remainder of dividing this Series by a value or Series.
+// It follows math.Mod: the result has the sign of the dividend, so
+// -7 mod 3 is -1. A zero divisor yields NaN.
+func (s *Series) Mod(other interface{}) *Series {
+	return s.arithmeticOp(other, math.Mod)
+}
+
+// FloorDiv divides this Series by a value or Series and rounds the result
+// down. A zero divisor yields NaN.
+func (s *Series) FloorDiv(other interface{}) *Series {
+	return s.arithmeticOp(other, opFloorDiv)
+}
+
+// RSub subtracts this Series from a value or Series (other - s)
+func (s *Series) RSub(other interface{}) *Series {
+	return s.arithmeticOp(other, reversedOp(opSub))
+}
+
+// RDiv divides a value or Series by this Series (other / s)
+func (s *Series) RDiv(other interface{}) *Series {
+	return s.arithmeticOp(other, reversedOp(opDiv))
+}
+
+// AddE is the checked form of Add, see arithmeticOpE.
+func (s *Series) AddE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, opAdd)
+}
+
+// SubE is the checked form of Sub, see arithmeticOpE.
+func (s *Series) SubE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, opSub)
+}
+
+// MulE is the checked form of Mul, see arithmeticOpE.
+func (s *Series) MulE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, opMul)
+}
+
+// DivE is the checked form of Div, see arithmeticOpE.
+func (s *Series) DivE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, opDiv)
+}
+
+// PowE is the checked form of Pow, see arithmeticOpE.
+func (s *Series) PowE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, math.Pow)
+}
+
+// ModE is the checked form of Mod, see arithmeticOpE.
+func (s *Series) ModE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, math.Mod)
+}
+
+// FloorDivE is the checked form of FloorDiv, see arithmeticOpE.
+func (s *Series) FloorDivE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, opFloorDiv)
+}
+
+// RSubE is the checked form of RSub, see arithmeticOpE.
+func (s *Series) RSubE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, reversedOp(opSub))
+}
+
+// RDivE is the checked form of RDiv, see arithmeticOpE.
+func (s *Series) RDivE(other interface{}) (*Series, error) {
+	return s.arithmeticOpE(other, reversedOp(opDiv))
+}
+
+func opAdd(a, b float64) float64 { return a + b }
+func opSub(a, b float64) float64 { return a - b }
+func opMul(a, b float64) float64 { return a * b }
+
+func opDiv(a, b float64) float64 {
+	if b == 0 {
+		return math.NaN()
+	}
+	return a / b
+}
+
+func opFloorDiv(a, b float64) float64 {
+	if b == 0 {
+		return math.NaN()
+	}
+	return math.Floor(a / b)
+}
+
+// reversedOp swaps the operands of op.
+func reversedOp(op func(float64, float64) float64) func(float64, float64) float64 {
+	return func(a, b float64) float64 { return op(b, a) }
+}
+
+// arithmeticOpE is the checked form of arithmeticOp. Instead of filling the
+// result with nil, it returns an error when other is a Series of a different
+// length or a scalar that is not numeric.
+func (s *Series) arithmeticOpE(other interface{}, op func(float64, float64) float64) (*Series, error) {
+	switch v := other.(type) {
+	case *Series:
+		if v.Len() != s.Len() {
+			return nil, lengthMismatch(s.Len(), v.Len(), "series length mismatch: %d vs %d", s.Len(), v.Len())
 		}
-		return a / b
-	})
+	default:
+		if _, err := toFloat64(other); err != nil {
+			return nil, fmt.Errorf("operand %v is not numeric: %w", other, &TypeConversionError{Value: other, Target: DTypeFloat64, Err: err})
+		}
+	}
+	return s.arithmeticOp(other, op), nil
 }
 
 func (s *Series) arithmeticOp(other interface{}, op func(float64, float64) float64) *Series {
@@ -681,5 +1890,6 @@ func (s *Series) arithmeticOp(other interface{}, op func(float64, float64) float
 		data:  newData,
 		dtype: DTypeFloat64,
 		index: s.index.Copy(),
+		na:    s.na,
 	}
 }
@@ -0,0 +1,314 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Join strategies for MergeOptions.Strategy
+const (
+	// StrategyHash builds a hash index of the right DataFrame
+	StrategyHash = "hash"
+	// StrategySort merges both DataFrames in key order with two pointers
+	StrategySort = "sort"
+)
+
+// useSortMerge decides whether Merge takes the sort-merge path. Without an
+// explicit strategy it is used when both sides are already sorted on their
+// keys, since the merge then needs no sorting and no string keys.
+func useSortMerge(left, right *DataFrame, leftKeys, rightKeys []string, strategy string) (bool, error) {
+	switch strategy {
+	case StrategyHash:
+		return false, nil
+	case StrategySort:
+		return true, nil
+	case "":
+		return rowsSorted(left, leftKeys) && rowsSorted(right, rightKeys), nil
+	default:
+		return false, fmt.Errorf("unknown join strategy '%s'", strategy)
+	}
+}
+
+// rowsSorted reports whether the rows of df are in ascending key order.
+func rowsSorted(df *DataFrame, keys []string) bool {
+	cols := keySeries(df, keys)
+	for i := 1; i < df.shape[0]; i++ {
+		if compareKeyRows(cols, i-1, cols, i) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// keySeries returns the Series of the key columns.
+func keySeries(df *DataFrame, keys []string) []*Series {
+	cols := make([]*Series, len(keys))
+	for i, k := range keys {
+		cols[i] = df.data[k]
+	}
+	return cols
+}
+
+// sortedPositions returns the row positions of df stably sorted by key.
+func sortedPositions(df *DataFrame, keys []string) []int {
+	cols := keySeries(df, keys)
+	positions := make([]int, df.shape[0])
+	for i := range positions {
+		positions[i] = i
+	}
+	if !rowsSorted(df, keys) {
+		sort.SliceStable(positions, func(i, j int) bool {
+			return compareKeyRows(cols, positions[i], cols, positions[j]) < 0
+		})
+	}
+	return positions
+}
+
+// compareKeyRows compares the key tuple of row a in aCols with row b in bCols.
+func compareKeyRows(aCols []*Series, a int, bCols []*Series, b int) int {
+	for k := range aCols {
+		if c := compareJoinValues(aCols[k].data[a], bCols[k].data[b]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// keyRowHasNA reports whether the key tuple of row pos contains nil or NaN.
+func keyRowHasNA(cols []*Series, pos int) bool {
+	for _, s := range cols {
+		if joinValueRank(s.data[pos]) <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// joinValueRank orders the kinds of key values relative to each other.
+func joinValueRank(v interface{}) int {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case float32:
+		if val != val {
+			return 1
+		}
+		return 2
+	case float64:
+		if val != val {
+			return 1
+		}
+		return 2
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return 2
+	case string:
+		return 3
+	case bool:
+		return 4
+	case time.Time:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// compareJoinValues is a total order over key values that never converts
+// them to strings. Values of different kinds are ordered by kind; numbers
+// compare numerically across integer and float types, matching the
+// equivalence used by joinKey.
+func compareJoinValues(a, b interface{}) int {
+	ra, rb := joinValueRank(a), joinValueRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	switch ra {
+	case 0, 1:
+		return 0
+	case 2:
+		if ia, ok := joinInt(a); ok {
+			if ib, ok := joinInt(b); ok {
+				return compareInt64(ia, ib)
+			}
+		}
+		fa, _ := toFloat64(a)
+		fb, _ := toFloat64(b)
+		return compareFloat64(fa, fb)
+	case 3:
+		return strings.Compare(a.(string), b.(string))
+	case 4:
+		ba, bb := a.(bool), b.(bool)
+		switch {
+		case ba == bb:
+			return 0
+		case !ba:
+			return -1
+		}
+		return 1
+	case 5:
+		return a.(time.Time).Compare(b.(time.Time))
+	}
+	if c := strings.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+		return c
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// joinInt returns v as an int64 when it is an integer that fits.
+func joinInt(v interface{}) (int64, bool) {
+	switch val := v.(type) {
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	case int32:
+		return int64(val), true
+	case int16:
+		return int64(val), true
+	case int8:
+		return int64(val), true
+	case uint8:
+		return int64(val), true
+	case uint16:
+		return int64(val), true
+	case uint32:
+		return int64(val), true
+	case uint:
+		return int64(val), val <= math.MaxInt64
+	case uint64:
+		return int64(val), val <= math.MaxInt64
+	}
+	return 0, false
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sortMergeJoin joins left and right with a two-pointer merge over their
+// key-sorted row positions. Matches are recorded as ranges into the sorted
+// positions of the other side, and rows are emitted in the same order as
+// the hash join produces them.
+func sortMergeJoin(left, right *DataFrame, leftKeys, rightKeys []string, opts MergeOptions) (*DataFrame, error) {
+	lpos := sortedPositions(left, leftKeys)
+	rpos := sortedPositions(right, rightKeys)
+	lcols := keySeries(left, leftKeys)
+	rcols := keySeries(right, rightKeys)
+
+	// lStart[l]:lEnd[l] are the positions in rpos matching left row l, and
+	// rStart[r]:rEnd[r] the positions in lpos matching right row r
+	lStart := make([]int, len(lpos))
+	lEnd := make([]int, len(lpos))
+	rStart := make([]int, len(rpos))
+	rEnd := make([]int, len(rpos))
+	i, j := 0, 0
+	for i < len(lpos) && j < len(rpos) {
+		c := compareKeyRows(lcols, lpos[i], rcols, rpos[j])
+		if c < 0 {
+			i++
+			continue
+		}
+		if c > 0 {
+			j++
+			continue
+		}
+		i2 := i + 1
+		for i2 < len(lpos) && compareKeyRows(lcols, lpos[i2], lcols, lpos[i]) == 0 {
+			i2++
+		}
+		j2 := j + 1
+		for j2 < len(rpos) && compareKeyRows(rcols, rpos[j2], rcols, rpos[j]) == 0 {
+			j2++
+		}
+		// Keys containing NA never match, like in the hash join
+		if !keyRowHasNA(lcols, lpos[i]) {
+			for _, l := range lpos[i:i2] {
+				lStart[l], lEnd[l] = j, j2
+			}
+			for _, r := range rpos[j:j2] {
+				rStart[r], rEnd[r] = i, i2
+			}
+		}
+		i, j = i2, j2
+	}
+
+	if opts.How == SemiJoin || opts.How == AntiJoin {
+		positions := make([]int, 0, len(lpos))
+		for l := range lStart {
+			if (lStart[l] < lEnd[l]) == (opts.How == SemiJoin) {
+				positions = append(positions, l)
+			}
+		}
+		return left.takeRows(positions), nil
+	}
+
+	resultCols, colMapping := prepareResultColumns(left, right, leftKeys, rightKeys, opts)
+	resultData := initResultData(resultCols)
+	rows := &joinRows{}
+	var indicators []interface{}
+	addIndicator := func(v string) {
+		if opts.Indicator {
+			indicators = append(indicators, v)
+		}
+	}
+
+	if opts.How == RightJoin {
+		for r := range rStart {
+			if rStart[r] == rEnd[r] {
+				appendRightOnlyRow(resultData, rows, colMapping, left, right, r, leftKeys, rightKeys, opts)
+				addIndicator("right_only")
+				continue
+			}
+			for _, l := range lpos[rStart[r]:rEnd[r]] {
+				appendJoinedRow(resultData, rows, colMapping, left, right, l, r, leftKeys, rightKeys, opts)
+				addIndicator("both")
+			}
+		}
+		return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
+	}
+
+	for l := range lStart {
+		if lStart[l] == lEnd[l] {
+			if opts.How != InnerJoin {
+				appendLeftOnlyRow(resultData, rows, colMapping, left, right, l, leftKeys, rightKeys, opts)
+				addIndicator("left_only")
+			}
+			continue
+		}
+		for _, r := range rpos[lStart[l]:lEnd[l]] {
+			appendJoinedRow(resultData, rows, colMapping, left, right, l, r, leftKeys, rightKeys, opts)
+			addIndicator("both")
+		}
+	}
+	if opts.How == OuterJoin {
+		for r := range rStart {
+			if rStart[r] == rEnd[r] {
+				appendRightOnlyRow(resultData, rows, colMapping, left, right, r, leftKeys, rightKeys, opts)
+				addIndicator("right_only")
+			}
+		}
+	}
+
+	return buildJoinResult(resultCols, resultData, indicators, joinResultIndex(left, right, rows.left, rows.right, opts.KeepIndex), opts)
+}
@@ -0,0 +1,257 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// structTag is the struct tag key used to map fields to column names.
+const structTag = "dataframe"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structField describes a struct field mapped to a DataFrame column.
+type structField struct {
+	column string
+	index  int
+	typ    reflect.Type
+}
+
+// structFields returns the exported fields of t that map to columns.
+// The column name comes from the `dataframe:"name"` tag and falls back to the
+// field name; a tag of "-" skips the field.
+func structFields(t reflect.Type) ([]structField, error) {
+	fields := make([]structField, 0, t.NumField())
+	seen := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		column := f.Name
+		if tag, ok := f.Tag.Lookup(structTag); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				column = name
+			}
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("duplicate column '%s' in struct %s", column, t)
+		}
+		seen[column] = true
+		fields = append(fields, structField{column: column, index: i, typ: f.Type})
+	}
+	return fields, nil
+}
+
+// typeDType returns the DType that holds values of Go type t.
+// Pointer types map to the dtype of the type they point to.
+func typeDType(t reflect.Type) DType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return DTypeDateTime
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return DTypeInt64
+	case reflect.Float32, reflect.Float64:
+		return DTypeFloat64
+	case reflect.String:
+		return DTypeString
+	case reflect.Bool:
+		return DTypeBool
+	default:
+		return DTypeObject
+	}
+}
+
+// FromStructs creates a DataFrame from a slice of structs or struct pointers.
+// Columns follow the field order and are named by the `dataframe` tag or the
+// field name. Column dtypes come from the field types, nil pointers (and nil
+// elements of a pointer slice) become NA.
+func FromStructs(src interface{}) (*DataFrame, error) {
+	rv := reflect.ValueOf(src)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("FromStructs requires a slice of structs, got nil %T", src)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("FromStructs requires a slice of structs, got %T", src)
+	}
+
+	elemType := rv.Type().Elem()
+	elemIsPtr := elemType.Kind() == reflect.Ptr
+	if elemIsPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FromStructs requires a slice of structs, got %T", src)
+	}
+
+	fields, err := structFields(elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	n := rv.Len()
+	columns := make([][]interface{}, len(fields))
+	for j := range columns {
+		columns[j] = make([]interface{}, n)
+	}
+	for i := 0; i < n; i++ {
+		elem := rv.Index(i)
+		if elemIsPtr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		for j, f := range fields {
+			fv := elem.Field(f.index)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			columns[j][i] = fv.Interface()
+		}
+	}
+
+	names := make([]string, len(fields))
+	seriesMap := make(map[string]*Series, len(fields))
+	for j, f := range fields {
+		dtype := typeDType(f.typ)
+		if dtype == DTypeObject {
+			dtype = InferDTypeFromSlice(columns[j])
+		}
+		names[j] = f.column
+		seriesMap[f.column] = &Series{
+			name:  f.column,
+			data:  columns[j],
+			dtype: dtype,
+			index: NewRangeIndex(n),
+		}
+	}
+
+	return &DataFrame{
+		columns: names,
+		data:    seriesMap,
+		index:   NewRangeIndex(n),
+		shape:   [2]int{n, len(names)},
+	}, nil
+}
+
+// ToStructs fills dest, a pointer to a slice of structs or struct pointers,
+// with one element per row. Fields are matched to columns by the `dataframe`
+// tag or the field name; fields without a matching column are left at their
+// zero value. Values are converted with the ConvertToType rules. NA values
+// set pointer fields to nil and other fields to their zero value.
+func (df *DataFrame) ToStructs(dest interface{}) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ToStructs requires a pointer to a slice of structs, got %T", dest)
+	}
+
+	sliceType := rv.Elem().Type()
+	elemType := sliceType.Elem()
+	elemIsPtr := elemType.Kind() == reflect.Ptr
+	if elemIsPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("ToStructs requires a pointer to a slice of structs, got %T", dest)
+	}
+
+	fields, err := structFields(elemType)
+	if err != nil {
+		return err
+	}
+	matched := fields[:0]
+	for _, f := range fields {
+		if _, ok := df.data[f.column]; ok {
+			matched = append(matched, f)
+		}
+	}
+
+	n := df.shape[0]
+	out := reflect.MakeSlice(sliceType, n, n)
+	for i := 0; i < n; i++ {
+		elem := out.Index(i)
+		if elemIsPtr {
+			ptr := reflect.New(elemType)
+			elem.Set(ptr)
+			elem = ptr.Elem()
+		}
+		for _, f := range matched {
+			v := df.data[f.column].data[i]
+			if err := setStructField(elem.Field(f.index), v); err != nil {
+				return fmt.Errorf("row %d, column '%s': %w", i, f.column, err)
+			}
+		}
+	}
+
+	rv.Elem().Set(out)
+	return nil
+}
+
+// setStructField stores v in the struct field fv.
+func setStructField(fv reflect.Value, v interface{}) error {
+	if v == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	if fv.Kind() == reflect.Ptr {
+		if f, ok := v.(float64); ok && math.IsNaN(f) {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setStructField(ptr.Elem(), v); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+
+	val := reflect.ValueOf(v)
+	if val.Type().AssignableTo(fv.Type()) {
+		fv.Set(val)
+		return nil
+	}
+
+	dtype := typeDType(fv.Type())
+	if dtype == DTypeObject {
+		return fmt.Errorf("cannot assign %T to %s", v, fv.Type())
+	}
+	converted, err := ConvertToType(v, dtype)
+	if err != nil {
+		return err
+	}
+	cv := reflect.ValueOf(converted)
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.OverflowInt(cv.Int()) {
+			return fmt.Errorf("value %v overflows %s", v, fv.Type())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if cv.Int() < 0 || fv.OverflowUint(uint64(cv.Int())) {
+			return fmt.Errorf("value %v overflows %s", v, fv.Type())
+		}
+		fv.SetUint(uint64(cv.Int()))
+		return nil
+	}
+	fv.Set(cv.Convert(fv.Type()))
+	return nil
+}
@@ -0,0 +1,134 @@
+package dataframe
+
+import "sort"
+
+// parallelCountValues is the number of values at or below which
+// ParallelValueCounts and ParallelUnique use the serial path.
+const parallelCountValues = 1 << 17
+
+// valueTally holds the distinct values of a Series, or of a range of it, in
+// order of first appearance together with how often each occurs. Values are
+// told apart like hashKey tells them apart. Integers, strings and floats are
+// looked up in maps of their own so that counting them does not box a key.
+type valueTally struct {
+	values []interface{} // distinct values in order of first appearance
+	counts []int         // occurrences of each value
+	ints   map[int64]int // positions in values
+	strs   map[string]int
+	floats map[float64]int
+	others map[interface{}]int // by hashKey
+}
+
+func newValueTally() *valueTally {
+	return &valueTally{
+		ints:   make(map[int64]int),
+		strs:   make(map[string]int),
+		floats: make(map[float64]int),
+		others: make(map[interface{}]int),
+	}
+}
+
+// tallyValues counts the values of data.
+func tallyValues(data []interface{}) *valueTally {
+	t := newValueTally()
+	for _, v := range data {
+		t.add(v, 1)
+	}
+	return t
+}
+
+// add counts n occurrences of v.
+func (t *valueTally) add(v interface{}, n int) {
+	if i, ok := joinInt(v); ok {
+		if pos, found := t.ints[i]; found {
+			t.counts[pos] += n
+			return
+		}
+		t.ints[i] = len(t.values)
+	} else if s, ok := v.(string); ok {
+		if pos, found := t.strs[s]; found {
+			t.counts[pos] += n
+			return
+		}
+		t.strs[s] = len(t.values)
+	} else if f, ok := v.(float64); ok && f == f {
+		if pos, found := t.floats[f]; found {
+			t.counts[pos] += n
+			return
+		}
+		t.floats[f] = len(t.values)
+	} else {
+		key := hashKey(v)
+		if pos, found := t.others[key]; found {
+			t.counts[pos] += n
+			return
+		}
+		t.others[key] = len(t.values)
+	}
+	t.values = append(t.values, v)
+	t.counts = append(t.counts, n)
+}
+
+// merge adds the counts of other, which tallies values that come after
+// those of t, so the order of first appearance is kept.
+func (t *valueTally) merge(other *valueTally) {
+	for i, v := range other.values {
+		t.add(v, other.counts[i])
+	}
+}
+
+// tallyValuesParallel is tallyValues with data split into chunks that are
+// counted concurrently by up to workers goroutines. The chunk tallies are
+// merged in chunk order, so the result is the same as that of tallyValues.
+func tallyValuesParallel(opts ParallelOptions, data []interface{}, workers int) *valueTally {
+	n := len(data)
+	tallies := make([]*valueTally, chunkCount(n, workers))
+	runChunks(opts, n, workers, func(chunk, start, end int) error {
+		tallies[chunk] = tallyValues(data[start:end])
+		return nil
+	})
+	t := tallies[0]
+	for c := 1; c < len(tallies); c++ {
+		t.merge(tallies[c])
+		tallies[c] = nil
+	}
+	return t
+}
+
+// countWorkers returns the number of workers for ParallelValueCounts and
+// ParallelUnique over n values, and false when the serial path should be
+// used.
+func countWorkers(opts ParallelOptions, n int) (int, bool) {
+	if n <= parallelCountValues {
+		return 1, false
+	}
+	workers := getNumWorkers(opts, n)
+	return workers, workers > 1
+}
+
+// uniqueSeries returns the distinct values as a Series named name.
+func (t *valueTally) uniqueSeries(name string) *Series {
+	return NewSeries(t.values, name)
+}
+
+// countsSeries returns the counts as ValueCounts returns them: sorted by
+// count, largest first, with ties in order of first appearance, and indexed
+// by the values.
+func (t *valueTally) countsSeries(name string) *Series {
+	order := make([]int, len(t.values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return t.counts[order[a]] > t.counts[order[b]]
+	})
+	labels := make([]interface{}, len(order))
+	counts := make([]interface{}, len(order))
+	for i, pos := range order {
+		labels[i] = t.values[pos]
+		counts[i] = t.counts[pos]
+	}
+	result := NewSeries(counts, "count")
+	result.index = NewIndex(labels, name)
+	return result
+}
@@ -0,0 +1,199 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// parallelTransformCells is the number of cells above which transformColumns,
+// behind the DataFrame Rank, Shift, Diff, Round, Abs and Clip, transforms
+// columns with ParallelTransform.
+const parallelTransformCells = 1 << 17
+
+// RankOptions defines options for Rank.
+type RankOptions struct {
+	Method     string   // "average" (default), "min", "max", "first" or "dense"
+	Descending bool     // rank the largest value first
+	Pct        bool     // return ranks as a fraction of the number of ranked values
+	Columns    []string // DataFrame.Rank only: columns to rank (default all numeric columns)
+}
+
+// Rank returns the 1-based rank of every value as float64. Values are
+// ordered like SortBy orders them, ties are resolved by Method, and NA values
+// get a nil rank.
+func (s *Series) Rank(opts RankOptions) (*Series, error) {
+	if err := validateRankMethod(opts.Method); err != nil {
+		return nil, err
+	}
+
+	positions := make([]int, 0, len(s.data))
+	for i, v := range s.data {
+		if !s.isNA(v) {
+			positions = append(positions, i)
+		}
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		c := compareValues(s.data[positions[i]], s.data[positions[j]])
+		if opts.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	ranks := make([]interface{}, len(s.data))
+	dense := 0
+	for i := 0; i < len(positions); {
+		j := i + 1
+		for j < len(positions) && compareValues(s.data[positions[i]], s.data[positions[j]]) == 0 {
+			j++
+		}
+		dense++
+		for k := i; k < j; k++ {
+			var rank float64
+			switch opts.Method {
+			case "min":
+				rank = float64(i + 1)
+			case "max":
+				rank = float64(j)
+			case "first":
+				rank = float64(k + 1)
+			case "dense":
+				rank = float64(dense)
+			default:
+				rank = float64(i+1+j) / 2
+			}
+			ranks[positions[k]] = rank
+		}
+		i = j
+	}
+
+	if opts.Pct && len(positions) > 0 {
+		total := float64(len(positions))
+		if opts.Method == "dense" {
+			total = float64(dense)
+		}
+		for _, pos := range positions {
+			ranks[pos] = ranks[pos].(float64) / total
+		}
+	}
+
+	return &Series{name: s.name, data: ranks, dtype: DTypeFloat64, index: s.index.Copy(), na: s.na}, nil
+}
+
+func validateRankMethod(method string) error {
+	switch method {
+	case "", "average", "min", "max", "first", "dense":
+		return nil
+	}
+	return fmt.Errorf("unknown rank method '%s'", method)
+}
+
+// Shift returns the Series with its values moved down by periods positions
+// (up for negative periods). Positions left empty are filled with nil, and
+// the index is unchanged.
+func (s *Series) Shift(periods int) *Series {
+	n := len(s.data)
+	newData := make([]interface{}, n)
+	for i := range newData {
+		if src := i - periods; src >= 0 && src < n {
+			newData[i] = s.data[src]
+		}
+	}
+	return &Series{name: s.name, data: newData, dtype: s.dtype, index: s.index.Copy(), na: s.na}
+}
+
+// Diff returns the difference between each value and the value periods
+// positions earlier (later for negative periods). Integers stay int64;
+// positions without a partner and pairs involving NA or non-numeric values
+// are nil.
+func (s *Series) Diff(periods int) *Series {
+	n := len(s.data)
+	newData := make([]interface{}, n)
+	for i := range newData {
+		prev := i - periods
+		if prev < 0 || prev >= n {
+			continue
+		}
+		a, b := s.data[i], s.data[prev]
+		if s.isNA(a) || s.isNA(b) {
+			continue
+		}
+		if ia, ok := joinInt(a); ok {
+			if ib, ok := joinInt(b); ok {
+				newData[i] = ia - ib
+				continue
+			}
+		}
+		fa, erra := toFloat64(a)
+		fb, errb := toFloat64(b)
+		if erra == nil && errb == nil {
+			newData[i] = fa - fb
+		}
+	}
+	return &Series{name: s.name, data: newData, dtype: InferDTypeFromSlice(newData), index: s.index.Copy(), na: s.na}
+}
+
+// Rank ranks the selected columns, see Series.Rank. Without opts.Columns all
+// int64 and float64 columns are ranked; other columns are passed through
+// unchanged.
+func (df *DataFrame) Rank(opts RankOptions) (*DataFrame, error) {
+	if err := validateRankMethod(opts.Method); err != nil {
+		return nil, err
+	}
+	return df.transformColumns(opts.Columns, func(s *Series) *Series {
+		ranked, _ := s.Rank(opts)
+		return ranked
+	})
+}
+
+// Shift shifts the selected columns by periods, see Series.Shift. Without
+// columns all int64 and float64 columns are shifted; other columns are passed
+// through unchanged.
+func (df *DataFrame) Shift(periods int, columns ...string) (*DataFrame, error) {
+	return df.transformColumns(columns, func(s *Series) *Series { return s.Shift(periods) })
+}
+
+// Diff takes the difference of the selected columns, see Series.Diff.
+// Without columns all int64 and float64 columns are differenced; other
+// columns are passed through unchanged.
+func (df *DataFrame) Diff(periods int, columns ...string) (*DataFrame, error) {
+	return df.transformColumns(columns, func(s *Series) *Series { return s.Diff(periods) })
+}
+
+// transformColumns returns a copy of df with fn applied to the given columns,
+// or to the numeric columns when none are given. The column order, shape and
+// index are those of df. Large frames are transformed with ParallelTransform.
+func (df *DataFrame) transformColumns(columns []string, fn func(*Series) *Series) (*DataFrame, error) {
+	selected := make(map[*Series]bool)
+	if len(columns) == 0 {
+		for _, col := range df.columns {
+			if s := df.data[col]; s.dtype.isNumeric() {
+				selected[s] = true
+			}
+		}
+	}
+	for _, col := range columns {
+		s, ok := df.data[col]
+		if !ok {
+			return nil, &ColumnNotFoundError{Column: col}
+		}
+		selected[s] = true
+	}
+
+	apply := func(s *Series) *Series {
+		if !selected[s] {
+			return s.Copy()
+		}
+		return fn(s)
+	}
+	if df.shape[0]*len(df.columns) > parallelTransformCells {
+		return df.ParallelTransform(apply), nil
+	}
+	newDF := df.Copy()
+	for _, col := range df.columns {
+		if s := df.data[col]; selected[s] {
+			newDF.data[col] = fn(s)
+		}
+	}
+	return newDF, nil
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -15,6 +17,94 @@ type CSVOptions struct {
 	SkipRows  int
 	UseCols   []string
 	DTypes    map[string]dataframe.DType
+	Cast      dataframe.CastOptions // conversion options for DTypes, see DataFrame.AsTypes
+	// InferBoolTokens reads columns of any boolean tokens, such as yes/no or
+	// Y/N, as DTypeBool; by default only true/false columns are.
+	InferBoolTokens bool
+}
+
+// checkedCast returns the options used to convert read columns to DTypes.
+// Unless a rounding mode is set, values that are not whole numbers or do
+// not fit in int64 are errors, so that "3.5" is never read as 3.
+func checkedCast(opts dataframe.CastOptions) dataframe.CastOptions {
+	if opts.Rounding == "" {
+		opts.Rounding = dataframe.RoundingError
+		opts.CheckOverflow = true
+	}
+	return opts
+}
+
+// readDTypes returns dtypes without the columns of the file that were not
+// read, such as those left out by UseCols, so that they do not fail the
+// read. Columns the file does not have at all are kept and reported by
+// AsTypes.
+func readDTypes(dtypes map[string]dataframe.DType, columns []string, colData map[string][]interface{}) map[string]dataframe.DType {
+	skipped := make(map[string]bool)
+	for _, col := range columns {
+		if _, ok := colData[col]; !ok {
+			skipped[col] = true
+		}
+	}
+	result := make(map[string]dataframe.DType, len(dtypes))
+	for col, dtype := range dtypes {
+		if !skipped[col] {
+			result[col] = dtype
+		}
+	}
+	return result
+}
+
+// inferBoolColumns converts the columns of colData that are not in dtypes
+// and whose values are all "true" or "false", in any case, to bools, so
+// that they are read as DTypeBool. NA values become nil. With tokens any
+// boolean token is accepted, see dataframe.ParseBool, but columns of only
+// "1" and "0" are left alone, as they are more likely numbers. Code columns
+// such as Y/N are only read as bools when asked for, since they often
+// hold other letters too.
+func inferBoolColumns(colData map[string][]interface{}, dtypes map[string]dataframe.DType, tokens bool) {
+	for col, values := range colData {
+		if _, ok := dtypes[col]; ok {
+			continue
+		}
+		bools := make([]interface{}, len(values))
+		words := false
+		for i, v := range values {
+			if v == nil || dataframe.IsNA(v) {
+				continue
+			}
+			s, ok := v.(string)
+			if !ok {
+				bools = nil
+				break
+			}
+			b, err := parseInferredBool(s, tokens)
+			if err != nil {
+				bools = nil
+				break
+			}
+			if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+				words = true
+			}
+			bools[i] = b
+		}
+		if bools != nil && words {
+			colData[col] = bools
+		}
+	}
+}
+
+// parseInferredBool reads s as a bool for inferBoolColumns.
+func parseInferredBool(s string, tokens bool) (bool, error) {
+	if tokens {
+		return dataframe.ParseBool(s)
+	}
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("'%s' is not true or false", s)
 }
 
 // CSVWriteOptions defines options for writing CSV files.
@@ -104,18 +194,16 @@ func ReadCSV(path string, opts CSVOptions) (*dataframe.DataFrame, error) {
 		}
 	}
 
+	inferBoolColumns(colData, opts.DTypes, opts.InferBoolTokens)
+
 	df, err := dataframe.New(colData)
 	if err != nil {
 		return nil, err
 	}
 
-	for col, dtype := range opts.DTypes {
-		if s, ok := df.GetSeries(col); ok {
-			converted, err := s.AsType(dtype)
-			if err == nil {
-				_ = df.SetColumn(col, converted)
-			}
-		}
+	// Apply dtypes if provided
+	if dtypes := readDTypes(opts.DTypes, columns, colData); len(dtypes) > 0 {
+		return df.AsTypes(dtypes, checkedCast(opts.Cast))
 	}
 
 	return df, nil
@@ -174,7 +262,7 @@ func WriteCSV(path string, df *dataframe.DataFrame, opts CSVWriteOptions) error
 		for _, col := range cols {
 			series, ok := df.GetSeries(col)
 			if !ok {
-				return fmt.Errorf("column '%s' not found", col)
+				return &dataframe.ColumnNotFoundError{Column: col}
 			}
 			value, err := series.Get(r)
 			if err != nil {
@@ -14,6 +14,10 @@ type ExcelOptions struct {
 	SkipRows  int
 	UseCols   []string
 	DTypes    map[string]dataframe.DType
+	Cast      dataframe.CastOptions // conversion options for DTypes, see DataFrame.AsTypes
+	// InferBoolTokens reads columns of any boolean tokens as DTypeBool, see
+	// CSVOptions.
+	InferBoolTokens bool
 }
 
 // ExcelWriteOptions defines options for writing Excel files.
@@ -106,19 +110,16 @@ func ReadExcel(path string, opts ExcelOptions) (*dataframe.DataFrame, error) {
 		}
 	}
 
+	inferBoolColumns(colData, opts.DTypes, opts.InferBoolTokens)
+
 	df, err := dataframe.New(colData)
 	if err != nil {
 		return nil, err
 	}
 
 	// Apply dtypes if provided
-	for col, dtype := range opts.DTypes {
-		if s, ok := df.GetSeries(col); ok {
-			converted, err := s.AsType(dtype)
-			if err == nil {
-				_ = df.SetColumn(col, converted)
-			}
-		}
+	if dtypes := readDTypes(opts.DTypes, columns, colData); len(dtypes) > 0 {
+		return df.AsTypes(dtypes, checkedCast(opts.Cast))
 	}
 
 	return df, nil
@@ -191,7 +192,7 @@ func WriteExcel(path string, df *dataframe.DataFrame, opts ExcelWriteOptions) er
 		for c, col := range cols {
 			series, ok := df.GetSeries(col)
 			if !ok {
-				return fmt.Errorf("column '%s' not found", col)
+				return &dataframe.ColumnNotFoundError{Column: col}
 			}
 			value, err := series.Get(r)
 			if err != nil {
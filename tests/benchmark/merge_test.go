@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/BAIGUANGMEI/datago/dataframe"
+)
+
+func benchmarkMerge1M(b *testing.B, strategy string, parallel *dataframe.ParallelOptions) {
+	const n = 1000000
+	leftIDs := make([]interface{}, n)
+	leftVals := make([]interface{}, n)
+	rightIDs := make([]interface{}, n)
+	rightVals := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		leftIDs[i] = int64(i)
+		leftVals[i] = float64(i)
+		rightIDs[i] = int64(i * 2)
+		rightVals[i] = float64(i)
+	}
+	left, _ := dataframe.New(map[string][]interface{}{"id": leftIDs, "x": leftVals})
+	right, _ := dataframe.New(map[string][]interface{}{"id": rightIDs, "y": rightVals})
+	opts := dataframe.DefaultMergeOptions()
+	opts.On = []string{"id"}
+	opts.Strategy = strategy
+	opts.Parallel = parallel
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := dataframe.Merge(left, right, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMergeHash1M(b *testing.B) {
+	benchmarkMerge1M(b, dataframe.StrategyHash, nil)
+}
+
+func BenchmarkMergeHashParallel1M(b *testing.B) {
+	benchmarkMerge1M(b, dataframe.StrategyHash, &dataframe.ParallelOptions{})
+}
+
+func BenchmarkMergeSort1M(b *testing.B) {
+	benchmarkMerge1M(b, dataframe.StrategySort, nil)
+}
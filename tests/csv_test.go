@@ -1,8 +1,12 @@
 package tests
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
@@ -77,3 +81,227 @@ func TestWriteCSVSeries(t *testing.T) {
 		t.Fatalf("unexpected value: %v", val)
 	}
 }
+
+func TestReadCSVDTypes(t *testing.T) {
+	outputDir := filepath.Join(".", "output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatalf("Create output dir error: %v", err)
+	}
+	path := filepath.Join(outputDir, "dtypes.csv")
+	if err := os.WriteFile(path, []byte("id,score\n1,1.5\n2,n/a\n"), 0o644); err != nil {
+		t.Fatalf("Write file error: %v", err)
+	}
+
+	dtypes := map[string]dataframe.DType{"id": dataframe.DTypeInt64, "score": dataframe.DTypeFloat64}
+	if _, err := io.ReadCSV(path, io.CSVOptions{HasHeader: true, DTypes: dtypes}); err == nil {
+		t.Error("Expected conversion error for score")
+	}
+
+	df, err := io.ReadCSV(path, io.CSVOptions{
+		HasHeader: true,
+		DTypes:    dtypes,
+		Cast:      dataframe.CastOptions{Errors: dataframe.CastCoerce},
+	})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	score, _ := df.GetSeries("score")
+	if v, _ := score.Get(1); v != nil {
+		t.Errorf("Expected coerced nil, got %v", v)
+	}
+	if id, _ := df.GetSeries("id"); id.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64 id, got %v", id.DType())
+	}
+
+	if _, err := io.ReadCSV(path, io.CSVOptions{HasHeader: true, DTypes: map[string]dataframe.DType{"nope": dataframe.DTypeInt64}}); err == nil {
+		t.Error("Expected error for unknown dtype column")
+	}
+}
+
+func TestReadCSVDTypesBlankCells(t *testing.T) {
+	outputDir := filepath.Join(".", "output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatalf("Create output dir error: %v", err)
+	}
+	path := filepath.Join(outputDir, "blanks.csv")
+	if err := os.WriteFile(path, []byte("a,b,c\n1,1.5,x\n,,y\nNA,2,z\n"), 0o644); err != nil {
+		t.Fatalf("Write file error: %v", err)
+	}
+
+	df, err := io.ReadCSV(path, io.CSVOptions{
+		HasHeader: true,
+		DTypes:    map[string]dataframe.DType{"a": dataframe.DTypeInt64, "b": dataframe.DTypeFloat64},
+	})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	a, _ := df.GetSeries("a")
+	b, _ := df.GetSeries("b")
+	if !reflect.DeepEqual(a.Values(), []interface{}{int64(1), nil, nil}) || a.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected blank and NA cells to be nil, got %v (%v)", a.Values(), a.DType())
+	}
+	if !reflect.DeepEqual(b.Values(), []interface{}{1.5, nil, 2.0}) {
+		t.Errorf("Expected blank cell to be nil, got %v", b.Values())
+	}
+
+	// A dtype for a column left out by UseCols is ignored
+	df, err = io.ReadCSV(path, io.CSVOptions{
+		HasHeader: true,
+		UseCols:   []string{"b", "c"},
+		DTypes:    map[string]dataframe.DType{"a": dataframe.DTypeInt64, "b": dataframe.DTypeFloat64},
+	})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	// ReadCSV does not keep the file's column order, so compare as a set
+	cols := append([]string(nil), df.Columns()...)
+	sort.Strings(cols)
+	if !reflect.DeepEqual(cols, []string{"b", "c"}) {
+		t.Errorf("Unexpected columns: %v", df.Columns())
+	}
+}
+
+func TestReadCSVDTypesRounding(t *testing.T) {
+	outputDir := filepath.Join(".", "output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatalf("Create output dir error: %v", err)
+	}
+	path := filepath.Join(outputDir, "rounding.csv")
+	if err := os.WriteFile(path, []byte("n\n1\n3.5\n"), 0o644); err != nil {
+		t.Fatalf("Write file error: %v", err)
+	}
+
+	dtypes := map[string]dataframe.DType{"n": dataframe.DTypeInt64}
+	if _, err := io.ReadCSV(path, io.CSVOptions{HasHeader: true, DTypes: dtypes}); err == nil {
+		t.Error("Expected error reading 3.5 as int64")
+	}
+
+	df, err := io.ReadCSV(path, io.CSVOptions{
+		HasHeader: true,
+		DTypes:    dtypes,
+		Cast:      dataframe.CastOptions{Rounding: dataframe.RoundingTrunc},
+	})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	n, _ := df.GetSeries("n")
+	if v, _ := n.Get(1); v != int64(3) {
+		t.Errorf("Expected truncated 3, got %v", v)
+	}
+}
+
+func BenchmarkReadCSVWithDTypes(b *testing.B) {
+	const rows = 100000
+	var sb strings.Builder
+	sb.WriteString("id,price,qty,name\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&sb, "%d,%.3f,%d,item%d\n", i, float64(i)*1.25, i%50, i%100)
+	}
+	path := filepath.Join(b.TempDir(), "bench.csv")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	opts := io.CSVOptions{
+		HasHeader: true,
+		DTypes: map[string]dataframe.DType{
+			"id":    dataframe.DTypeInt64,
+			"price": dataframe.DTypeFloat64,
+			"qty":   dataframe.DTypeInt64,
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := io.ReadCSV(path, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReadCSVInfersBool(t *testing.T) {
+	outputDir := filepath.Join(".", "output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatalf("Create output dir error: %v", err)
+	}
+	path := filepath.Join(outputDir, "bools.csv")
+	data := "agree,flag,bits,label,ok\nYes,true,1,yes,TRUE\nno,F,0,maybe,\n,on,1,no,false\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Write file error: %v", err)
+	}
+
+	// By default only true/false columns are bools; code letters stay strings
+	df, err := io.ReadCSV(path, io.CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	ok, _ := df.GetSeries("ok")
+	if got := ok.Values(); ok.DType() != dataframe.DTypeBool || !reflect.DeepEqual(got, []interface{}{true, nil, false}) {
+		t.Errorf("Expected bool ok [true <nil> false], got %v (%v)", got, ok.DType())
+	}
+	for _, col := range []string{"agree", "flag", "bits", "label"} {
+		if s, _ := df.GetSeries(col); s.DType() != dataframe.DTypeString {
+			t.Errorf("Expected string %s by default, got %v", col, s.DType())
+		}
+	}
+
+	df, err = io.ReadCSV(path, io.CSVOptions{HasHeader: true, InferBoolTokens: true})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	agree, _ := df.GetSeries("agree")
+	if agree.DType() != dataframe.DTypeBool {
+		t.Errorf("Expected bool agree, got %v", agree.DType())
+	}
+	if got := agree.Values(); !reflect.DeepEqual(got, []interface{}{true, false, nil}) {
+		t.Errorf("Expected [true false <nil>], got %v", got)
+	}
+	flag, _ := df.GetSeries("flag")
+	if got := flag.Values(); !reflect.DeepEqual(got, []interface{}{true, false, true}) {
+		t.Errorf("Expected [true false true], got %v", got)
+	}
+	// Only 1 and 0, and a column with other words, stay strings
+	for _, col := range []string{"bits", "label"} {
+		if s, _ := df.GetSeries(col); s.DType() != dataframe.DTypeString {
+			t.Errorf("Expected string %s, got %v", col, s.DType())
+		}
+	}
+
+	df, err = io.ReadCSV(path, io.CSVOptions{HasHeader: true, InferBoolTokens: true, DTypes: map[string]dataframe.DType{"flag": dataframe.DTypeString}})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	if v, _ := df.At(1, "flag"); v != "F" {
+		t.Errorf("Expected flag kept as string F, got %v", v)
+	}
+}
+
+func TestReadCSVBlankDates(t *testing.T) {
+	outputDir := filepath.Join(".", "output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatalf("Create output dir error: %v", err)
+	}
+	path := filepath.Join(outputDir, "dates.csv")
+	if err := os.WriteFile(path, []byte("id,date\n1,2024-01-05\n2,\n3,2024-02-10\n4,NA\n"), 0o644); err != nil {
+		t.Fatalf("Write file error: %v", err)
+	}
+
+	df, err := io.ReadCSV(path, io.CSVOptions{HasHeader: true, DTypes: map[string]dataframe.DType{"date": dataframe.DTypeDateTime}})
+	if err != nil {
+		t.Fatalf("ReadCSV error: %v", err)
+	}
+	dates, _ := df.GetSeries("date")
+	if got := dates.Count(); got != 2 {
+		t.Errorf("Count() = %d, want 2", got)
+	}
+	if v, _ := dates.Get(1); v != nil {
+		t.Errorf("Expected blank date to be nil, got %v", v)
+	}
+	dropped, err := df.DropNA(dataframe.DropNAOptions{})
+	if err != nil {
+		t.Fatalf("DropNA error: %v", err)
+	}
+	if dropped.Shape()[0] != 2 {
+		t.Errorf("Expected 2 rows after DropNA, got %d", dropped.Shape()[0])
+	}
+}
@@ -1,7 +1,12 @@
 package tests
 
 import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -35,17 +40,60 @@ func TestDataFrameFilterSort(t *testing.T) {
 	df, _ := dataframe.New(data)
 
 	filtered := df.Filter(func(r dataframe.Row) bool {
-		return r.Get("age").(int) >= 25
+		age, err := r.GetInt("age")
+		return err == nil && age >= 25
 	})
 	if filtered.Shape()[0] != 2 {
 		t.Fatalf("Filter() rows = %d, want 2", filtered.Shape()[0])
 	}
 
-	sorted := df.SortBy("age", dataframe.Ascending)
-	v, _ := sorted.At(0, "age")
+	sorted, err := df.SortBy("age", dataframe.Ascending)
+	if err != nil {
+		t.Fatalf("SortBy() error: %v", err)
+	}
+	ages, _ := sorted.GetSeries("age")
+	v, _ := ages.Get(0)
 	if v != 20 {
 		t.Fatalf("SortBy() first age = %v, want 20", v)
 	}
+	if label, _ := sorted.Index().Get(0); label != 2 {
+		t.Fatalf("SortBy() first label = %v, want 2", label)
+	}
+
+	if _, err := df.SortBy("missing", dataframe.Ascending); err == nil {
+		t.Fatalf("SortBy() expected error for missing column")
+	}
+}
+
+func TestDataFrameSortByColumns(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"East", 10.0, "a"},
+		{"West", 30.0, "b"},
+		{"East", 20.0, "c"},
+		{"West", nil, "d"},
+		{"East", 20.0, "e"},
+	}, []string{"region", "sales", "id"})
+
+	sorted, err := df.SortByColumns([]string{"region", "sales"}, []dataframe.SortOrder{dataframe.Ascending, dataframe.Descending})
+	if err != nil {
+		t.Fatalf("SortByColumns() error: %v", err)
+	}
+	ids, _ := sorted.GetSeries("id")
+	want := []string{"c", "e", "a", "b", "d"}
+	for i, w := range want {
+		if v, _ := ids.Get(i); v != w {
+			t.Fatalf("SortByColumns() id[%d] = %v, want %s", i, v, w)
+		}
+	}
+
+	nullsFirst, _ := df.SortByColumns([]string{"sales"}, nil, dataframe.SortOptions{Nulls: dataframe.NullsFirst})
+	if v, _ := nullsFirst.At(3, "id"); v != "d" {
+		t.Fatalf("NullsFirst: label 3 should keep id d, got %v", v)
+	}
+	first, _ := nullsFirst.GetSeries("id")
+	if v, _ := first.Get(0); v != "d" {
+		t.Fatalf("NullsFirst: first id = %v, want d", v)
+	}
 }
 
 func TestDataFrameDescribe(t *testing.T) {
@@ -59,3 +107,1231 @@ func TestDataFrameDescribe(t *testing.T) {
 		t.Fatalf("Describe() shape = %v, want [2 5]", desc.Shape())
 	}
 }
+
+func TestDataFrameDescribeSkipsNonNumeric(t *testing.T) {
+	data := map[string][]interface{}{
+		"a":    {1, 2, 3},
+		"name": {"x", "y", "z"},
+	}
+	df, _ := dataframe.New(data)
+	desc := df.Describe()
+	if desc.Shape()[0] != 1 {
+		t.Fatalf("Describe() rows = %d, want 1", desc.Shape()[0])
+	}
+}
+
+func TestDataFrameHeadDoesNotAlias(t *testing.T) {
+	df, _ := dataframe.New(map[string][]interface{}{
+		"a": {1, 2, 3},
+	})
+	head := df.Head(2)
+	s, _ := head.GetSeries("a")
+	_ = s.Set(0, 100)
+
+	orig, _ := df.GetSeries("a")
+	if v, _ := orig.Get(0); v != 1 {
+		t.Fatalf("parent changed after Head() mutation: got %v, want 1", v)
+	}
+}
+
+func TestDataFrameIterRows(t *testing.T) {
+	df, _ := dataframe.New(map[string][]interface{}{
+		"age": {25, 30, 35},
+	})
+	total := 0
+	for label, row := range df.IterRows() {
+		if label == 2 {
+			break
+		}
+		total += row.Get("age").(int)
+	}
+	if total != 55 {
+		t.Fatalf("IterRows() total = %d, want 55", total)
+	}
+}
+
+func TestDataFrameRound(t *testing.T) {
+	df, _ := dataframe.New(map[string][]interface{}{
+		"price": {1.234, 5.678},
+		"name":  {"a", "b"},
+	})
+	rounded, err := df.Round(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, _ := rounded.GetSeries("price")
+	if v, _ := s.Get(1); v != 5.7 {
+		t.Fatalf("Round(1) price = %v, want 5.7", v)
+	}
+	orig, _ := df.GetSeries("price")
+	if v, _ := orig.Get(1); v != 5.678 {
+		t.Fatalf("Round() mutated receiver: %v", v)
+	}
+}
+
+func TestDataFrameClipAbsRound(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{int64(-3), -1.256, "a"},
+		{int64(40), 2.5, "b"},
+		{int64(7), nil, "c"},
+	}, []string{"n", "f", "s"})
+
+	abs, err := df.Abs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, _ := abs.GetSeries("n")
+	if !reflect.DeepEqual(n.Values(), []interface{}{int64(3), int64(40), int64(7)}) || n.DType() != dataframe.DTypeInt64 {
+		t.Errorf("unexpected abs values: %v (%s)", n.Values(), n.DType())
+	}
+	s, _ := abs.GetSeries("s")
+	if !reflect.DeepEqual(s.Values(), []interface{}{"a", "b", "c"}) {
+		t.Errorf("expected string column to pass through, got %v", s.Values())
+	}
+
+	// Only the listed column is clipped
+	clipped, err := df.Clip(0, 10, "n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, _ = clipped.GetSeries("n")
+	f, _ := clipped.GetSeries("f")
+	if !reflect.DeepEqual(n.Values(), []interface{}{int64(0), int64(10), int64(7)}) {
+		t.Errorf("unexpected clipped values: %v", n.Values())
+	}
+	if !reflect.DeepEqual(f.Values(), []interface{}{-1.256, 2.5, nil}) {
+		t.Errorf("expected unlisted column unchanged, got %v", f.Values())
+	}
+	if !reflect.DeepEqual(clipped.Columns(), df.Columns()) || !clipped.Index().Equals(df.Index()) {
+		t.Errorf("expected column order and index to be kept")
+	}
+
+	rounded, err := df.Round(-1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, _ = rounded.GetSeries("n")
+	if !reflect.DeepEqual(n.Values(), []interface{}{int64(0), int64(40), int64(10)}) {
+		t.Errorf("expected int columns to be rounded, got %v", n.Values())
+	}
+
+	if _, err := df.Clip(nil, 1, "missing"); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("expected column not found, got %v", err)
+	}
+	if _, err := df.Clip(2, 1); err == nil {
+		t.Error("expected error for invalid bounds")
+	}
+
+	// Large frames go through ParallelTransform
+	rows := 100000
+	a := make([]float64, rows)
+	b := make([]int, rows)
+	for i := range a {
+		a[i] = float64(i%200-100) / 3
+		b[i] = i%50 - 25
+	}
+	large, err := dataframe.NewBuilder().AddFloats("a", a).AddInts("b", b).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := large.Clip(-10, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, col := range large.Columns() {
+		src, _ := large.GetSeries(col)
+		want, _ := src.Clip(-10, 10)
+		s, _ := got.GetSeries(col)
+		if !reflect.DeepEqual(s.Values(), want.Values()) || s.DType() != src.DType() {
+			t.Errorf("column %s differs from Series.Clip", col)
+		}
+	}
+	if !reflect.DeepEqual(got.Columns(), large.Columns()) {
+		t.Errorf("unexpected column order: %v", got.Columns())
+	}
+}
+
+func TestDataFrameExplode(t *testing.T) {
+	df, _ := dataframe.New(map[string][]interface{}{
+		"id":   {1, 2},
+		"tags": {[]interface{}{"x", "y", "z"}, "w"},
+	})
+	exploded, err := df.Explode("tags")
+	if err != nil {
+		t.Fatalf("Explode() error: %v", err)
+	}
+	if exploded.Shape()[0] != 4 {
+		t.Fatalf("Explode() rows = %d, want 4", exploded.Shape()[0])
+	}
+	ids, _ := exploded.GetSeries("id")
+	if v, _ := ids.Get(2); v != 1 {
+		t.Fatalf("Explode() repeated id = %v, want 1", v)
+	}
+	if _, err := df.Explode("missing"); err == nil {
+		t.Fatalf("Explode() expected error for missing column")
+	}
+}
+
+func TestDataFrameDropNA(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1, "x", 1.0},
+		{nil, nil, nil},
+		{3, nil, 3.0},
+	}, []string{"a", "b", "c"})
+
+	anyNA, err := df.DropNA(dataframe.DropNAOptions{})
+	if err != nil {
+		t.Fatalf("DropNA() error: %v", err)
+	}
+	if anyNA.Shape()[0] != 1 {
+		t.Fatalf("DropNA(any) rows = %d, want 1", anyNA.Shape()[0])
+	}
+
+	allNA, _ := df.DropNA(dataframe.DropNAOptions{How: "all"})
+	if allNA.Shape()[0] != 2 {
+		t.Fatalf("DropNA(all) rows = %d, want 2", allNA.Shape()[0])
+	}
+	if label, _ := allNA.Index().Get(1); label != 2 {
+		t.Fatalf("DropNA(all) kept label = %v, want 2", label)
+	}
+
+	thresh, _ := df.DropNA(dataframe.DropNAOptions{Thresh: 2})
+	if thresh.Shape()[0] != 2 {
+		t.Fatalf("DropNA(thresh=2) rows = %d, want 2", thresh.Shape()[0])
+	}
+
+	subset, _ := df.DropNA(dataframe.DropNAOptions{Subset: []string{"a"}})
+	if subset.Shape()[0] != 2 {
+		t.Fatalf("DropNA(subset=a) rows = %d, want 2", subset.Shape()[0])
+	}
+
+	cols, _ := df.DropNA(dataframe.DropNAOptions{Axis: 1})
+	if cols.Shape()[1] != 0 {
+		t.Fatalf("DropNA(axis=1) cols = %d, want 0", cols.Shape()[1])
+	}
+	if _, err := df.DropNA(dataframe.DropNAOptions{Axis: 1, Subset: []string{"a"}}); err == nil {
+		t.Fatal("Expected error for Subset with axis 1")
+	}
+
+	mask := df.IsNA()
+	if mask.Shape() != df.Shape() {
+		t.Fatalf("IsNA() shape = %v, want %v", mask.Shape(), df.Shape())
+	}
+	if v, _ := mask.At(1, "b"); v != true {
+		t.Fatalf("IsNA() at (1, b) = %v, want true", v)
+	}
+}
+
+func TestDataFrameFillNA(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1.0, "x"},
+		{nil, nil},
+		{nil, "z"},
+	}, []string{"a", "b"})
+
+	scalar, err := df.FillNA(0)
+	if err != nil {
+		t.Fatalf("FillNA(0) error: %v", err)
+	}
+	if v, _ := scalar.At(1, "b"); v != 0 {
+		t.Fatalf("FillNA(0) at (1, b) = %v, want 0", v)
+	}
+	b, _ := scalar.GetSeries("b")
+	if b.DType() != dataframe.DTypeObject {
+		t.Fatalf("FillNA(0) string column dtype = %v, want object", b.DType())
+	}
+	if v, _ := df.At(1, "b"); v != nil {
+		t.Fatalf("FillNA() mutated receiver: %v", v)
+	}
+
+	perCol, _ := df.FillNA(map[string]interface{}{"a": -1.0})
+	if v, _ := perCol.At(2, "a"); v != -1.0 {
+		t.Fatalf("FillNA(map) at (2, a) = %v, want -1", v)
+	}
+	if v, _ := perCol.At(1, "b"); v != nil {
+		t.Fatalf("FillNA(map) untouched column = %v, want nil", v)
+	}
+
+	ffill, _ := df.FillNA(dataframe.FillOptions{Method: "ffill", Limit: 1})
+	if v, _ := ffill.At(1, "a"); v != 1.0 {
+		t.Fatalf("ffill at (1, a) = %v, want 1", v)
+	}
+	if v, _ := ffill.At(2, "a"); v != nil {
+		t.Fatalf("ffill limit exceeded at (2, a) = %v, want nil", v)
+	}
+
+	bfill, _ := df.FillNA(dataframe.FillOptions{Method: "bfill", Columns: []string{"b"}})
+	if v, _ := bfill.At(1, "b"); v != "z" {
+		t.Fatalf("bfill at (1, b) = %v, want z", v)
+	}
+
+	if _, err := df.FillNA(dataframe.FillOptions{Method: "bogus"}); err == nil {
+		t.Fatalf("FillNA() expected error for invalid method")
+	}
+}
+
+func TestDataFrameApplyRows(t *testing.T) {
+	df, _ := dataframe.New(map[string][]interface{}{
+		"price": {2.0, 3.0},
+		"qty":   {4.0, 5.0},
+	})
+	withTotal, err := df.ApplyRows(func(r dataframe.Row) interface{} {
+		return r.Get("price").(float64) * r.Get("qty").(float64)
+	}, "total")
+	if err != nil {
+		t.Fatalf("ApplyRows() error: %v", err)
+	}
+	total, ok := withTotal.GetSeries("total")
+	if !ok || total.DType() != dataframe.DTypeFloat64 {
+		t.Fatalf("ApplyRows() total column missing or wrong dtype")
+	}
+	if v, _ := total.Get(1); v != 15.0 {
+		t.Fatalf("ApplyRows() total[1] = %v, want 15", v)
+	}
+	if df.Shape()[1] != 2 {
+		t.Fatalf("ApplyRows() mutated receiver")
+	}
+
+	doubled, err := df.ApplyColumns(func(s *dataframe.Series) *dataframe.Series {
+		return s.Mul(2)
+	})
+	if err != nil {
+		t.Fatalf("ApplyColumns() error: %v", err)
+	}
+	if v, _ := doubled.At(0, "qty"); v != 8.0 {
+		t.Fatalf("ApplyColumns() qty[0] = %v, want 8", v)
+	}
+	_, err = df.ApplyColumns(func(s *dataframe.Series) *dataframe.Series {
+		return s.Head(1)
+	})
+	if err == nil {
+		t.Fatalf("ApplyColumns() expected length mismatch error")
+	}
+}
+
+func TestDataFrameWithColumnAndPipe(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1, 10},
+		{2, 20},
+	}, []string{"a", "b"})
+
+	replaced := df.WithColumn("a", func(r dataframe.Row) interface{} {
+		return r.Get("a").(int) * 100
+	})
+	if cols := replaced.Columns(); len(cols) != 2 || cols[0] != df.Columns()[0] {
+		t.Fatalf("WithColumn() columns = %v, want order kept", cols)
+	}
+	if v, _ := replaced.At(1, "a"); v != 200 {
+		t.Fatalf("WithColumn() a[1] = %v, want 200", v)
+	}
+
+	if _, err := df.WithColumnSeries("c", dataframe.NewSeriesFromInts([]int{1}, "c")); err == nil {
+		t.Fatalf("WithColumnSeries() expected length mismatch error")
+	}
+
+	result, err := df.Pipe(func(d *dataframe.DataFrame) (*dataframe.DataFrame, error) {
+		return d.WithColumnSeries("c", dataframe.NewSeriesFromInts([]int{7, 8}, "c"))
+	})
+	if err != nil {
+		t.Fatalf("Pipe() error: %v", err)
+	}
+	if result.Shape()[1] != 3 {
+		t.Fatalf("Pipe() cols = %d, want 3", result.Shape()[1])
+	}
+}
+
+type structRow struct {
+	ID     int      `dataframe:"id"`
+	Name   string   `dataframe:"name"`
+	Score  *float64 `dataframe:"score"`
+	Hidden string   `dataframe:"-"`
+	Active bool
+}
+
+func TestDataFrameStructsRoundTrip(t *testing.T) {
+	score := 9.5
+	rows := []structRow{
+		{ID: 1, Name: "a", Score: &score, Hidden: "x", Active: true},
+		{ID: 2, Name: "b", Score: nil},
+	}
+	df, err := dataframe.FromStructs(rows)
+	if err != nil {
+		t.Fatalf("FromStructs failed: %v", err)
+	}
+	if got := strings.Join(df.Columns(), ","); got != "id,name,score,Active" {
+		t.Fatalf("Expected columns id,name,score,Active, got %s", got)
+	}
+	scores, _ := df.GetSeries("score")
+	if v, _ := scores.Get(1); scores.DType() != dataframe.DTypeFloat64 || v != nil {
+		t.Errorf("Expected float64 score with NA, got %v %v", scores.DType(), v)
+	}
+
+	var back []*structRow
+	if err := df.ToStructs(&back); err != nil {
+		t.Fatalf("ToStructs failed: %v", err)
+	}
+	if len(back) != 2 || back[0].ID != 1 || *back[0].Score != 9.5 || back[1].Score != nil || !back[0].Active || back[0].Hidden != "" {
+		t.Errorf("Round trip mismatch: %+v %+v", back[0], back[1])
+	}
+
+	records := df.ToRecords()
+	if len(records) != 2 || records[1][1] != "b" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+	maps := df.ToMaps()
+	if maps[0]["name"] != "a" || maps[1]["score"] != nil {
+		t.Errorf("Unexpected maps: %v", maps)
+	}
+
+	bad, _ := dataframe.New(map[string][]interface{}{"id": {1, "x"}})
+	var out []structRow
+	err = bad.ToStructs(&out)
+	if err == nil || !strings.Contains(err.Error(), "row 1, column 'id'") {
+		t.Errorf("Expected conversion error for row 1, got %v", err)
+	}
+}
+
+func TestDataFrameInsertPopReorder(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"id", "name"})
+
+	if err := df.InsertColumn(1, "score", dataframe.NewSeries([]interface{}{1.5, 2.5}, "")); err != nil {
+		t.Fatalf("InsertColumn failed: %v", err)
+	}
+	if got := strings.Join(df.Columns(), ","); got != "id,score,name" {
+		t.Errorf("Expected id,score,name, got %s", got)
+	}
+	if header := strings.Fields(strings.SplitN(df.String(), "\n", 3)[1]); strings.Join(header, ",") != "id,score,name" {
+		t.Errorf("String header does not follow column order: %q", df.String())
+	}
+	if err := df.InsertColumn(0, "short", dataframe.NewSeries([]interface{}{1}, "")); err == nil {
+		t.Error("Expected length mismatch error")
+	}
+	if err := df.InsertColumn(5, "far", dataframe.NewSeries([]interface{}{1, 2}, "")); err == nil {
+		t.Error("Expected position out of range error")
+	}
+	if err := df.InsertColumn(0, "none", nil); err == nil {
+		t.Error("Expected error for nil series")
+	}
+	if err := df.SetColumn("none", nil); err == nil {
+		t.Error("Expected SetColumn error for nil series")
+	}
+
+	popped, rest, err := df.PopColumn("score")
+	if err != nil {
+		t.Fatalf("PopColumn failed: %v", err)
+	}
+	if popped.Name() != "score" || rest.Shape()[1] != 2 || df.Shape()[1] != 3 {
+		t.Errorf("Unexpected PopColumn result: %s, %v, %v", popped.Name(), rest.Shape(), df.Shape())
+	}
+
+	reordered, err := df.ReorderColumns([]string{"name", "score", "id"})
+	if err != nil {
+		t.Fatalf("ReorderColumns failed: %v", err)
+	}
+	if got := strings.Join(reordered.Columns(), ","); got != "name,score,id" {
+		t.Errorf("Expected name,score,id, got %s", got)
+	}
+	if _, err := df.ReorderColumns([]string{"name", "name", "id"}); err == nil {
+		t.Error("Expected duplicate column error")
+	}
+	if _, err := df.ReorderColumns([]string{"name", "id"}); err == nil {
+		t.Error("Expected missing column error")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	expected, _ := dataframe.FromRecords([][]interface{}{
+		{1, 1.0, "a"},
+		{2, math.NaN(), "b"},
+		{3, 3.0, "c"},
+	}, []string{"id", "value", "name"})
+	actual, _ := dataframe.FromRecords([][]interface{}{
+		{1, 1.0000001, "a"},
+		{2, math.NaN(), "x"},
+		{3, 3.5, "c"},
+	}, []string{"id", "value", "name"})
+
+	diff, err := dataframe.Compare(expected, actual, dataframe.CompareOptions{Tolerance: 1e-6, NaNEqual: true})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if diff.Shape()[0] != 2 {
+		t.Fatalf("Expected 2 differing cells, got %d\n%v", diff.Shape()[0], diff)
+	}
+	columns, _ := diff.GetSeries("column")
+	left, _ := diff.GetSeries("left")
+	right, _ := diff.GetSeries("right")
+	if got := columns.Values(); !reflect.DeepEqual(got, []interface{}{"name", "value"}) {
+		t.Errorf("Expected differing columns [name value], got %v", got)
+	}
+	if l, _ := left.Get(1); l != 3.0 {
+		t.Errorf("Expected left 3.0, got %v", l)
+	}
+	if r, _ := right.Get(0); r != "x" {
+		t.Errorf("Expected right x, got %v", r)
+	}
+
+	diff, _ = dataframe.Compare(expected, actual, dataframe.CompareOptions{Tolerance: 1e-6})
+	if diff.Shape()[0] != 3 {
+		t.Errorf("Expected NaN cells to differ without NaNEqual, got %d rows", diff.Shape()[0])
+	}
+
+	shorter := expected.Head(2).Drop("name")
+	if _, err := dataframe.Compare(expected, shorter, dataframe.CompareOptions{}); err == nil {
+		t.Error("Expected error for mismatched columns")
+	}
+	diff, err = dataframe.Compare(expected, shorter, dataframe.CompareOptions{AllowMismatch: true, NaNEqual: true})
+	if err != nil {
+		t.Fatalf("Compare with AllowMismatch failed: %v", err)
+	}
+	// name is missing for all 3 rows, plus id and value of the third row
+	if diff.Shape()[0] != 5 {
+		t.Errorf("Expected 5 differing cells, got %d\n%v", diff.Shape()[0], diff)
+	}
+}
+
+func TestDataFrameAnyAll(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{true, 1, "x", time.Now()},
+		{false, 0, "y", time.Now()},
+		{nil, 1, "z", time.Now()},
+	}, []string{"flag", "num", "name", "ts"})
+
+	anyCols := df.Any(0)
+	if got := anyCols.Index().Labels(); !reflect.DeepEqual(got, []interface{}{"flag", "num"}) {
+		t.Errorf("Expected string and datetime columns to be skipped, got %v", got)
+	}
+	allCols := df.All(0)
+	if got := allCols.Values(); !reflect.DeepEqual(got, []interface{}{false, false}) {
+		t.Errorf("Expected All per column [false false], got %v", got)
+	}
+	allRows := df.All(1)
+	if got := allRows.Values(); !reflect.DeepEqual(got, []interface{}{true, false, true}) {
+		t.Errorf("Expected All per row [true false true], got %v", got)
+	}
+
+	if df.IsNA().Any(0).Any() != true {
+		t.Error("Expected a null somewhere")
+	}
+	if !dataframe.NewSeries([]interface{}{nil, nil}, "").All() || dataframe.NewSeries([]interface{}{nil}, "").Any() {
+		t.Error("Expected nil to count as true for All and false for Any")
+	}
+}
+
+func TestDataFrameSelectRows(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}, {3, "c"}}, []string{"id", "name"})
+
+	mask := dataframe.NewSeries([]interface{}{false, false, false}, "mask")
+	empty, err := df.SelectRowsByMask(mask)
+	if err != nil {
+		t.Fatalf("SelectRowsByMask failed: %v", err)
+	}
+	if ids, _ := empty.GetSeries("id"); empty.Shape()[0] != 0 || ids.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected empty selection to keep int64 dtype, got %v", ids.DType())
+	}
+	if _, err := df.SelectRowsByMask(dataframe.NewSeries([]interface{}{true}, "")); err == nil {
+		t.Error("Expected mask length error")
+	}
+	if _, err := df.SelectRowsByMask(dataframe.NewSeries([]interface{}{1, 0, 1}, "")); err == nil {
+		t.Error("Expected mask dtype error")
+	}
+
+	picked, err := df.SelectRowsAt([]int{-1, 0})
+	if err != nil {
+		t.Fatalf("SelectRowsAt failed: %v", err)
+	}
+	if got := picked.Index().Labels(); !reflect.DeepEqual(got, []interface{}{2, 0}) {
+		t.Errorf("Expected labels [2 0], got %v", got)
+	}
+	if _, err := df.SelectRowsAt([]int{3}); err == nil {
+		t.Error("Expected out of range error")
+	}
+
+	byLabel, err := df.SelectRowsByLabels([]interface{}{1})
+	if err != nil {
+		t.Fatalf("SelectRowsByLabels failed: %v", err)
+	}
+	if names, _ := byLabel.GetSeries("name"); names.Values()[0] != "b" {
+		t.Errorf("Expected row b, got %v", names.Values())
+	}
+	if _, err := df.SelectRowsByLabels([]interface{}{7}); err == nil {
+		t.Error("Expected missing label error")
+	}
+}
+
+func TestDataFrameILocNegative(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}, {3, "c"}}, []string{"id", "name"})
+
+	last2 := df.ILoc(-2, 10, -1, 2)
+	if last2.Shape() != [2]int{2, 1} || last2.Columns()[0] != "name" {
+		t.Errorf("Expected last 2 rows of name, got %v %v", last2.Shape(), last2.Columns())
+	}
+	if empty := df.ILoc(2, 1, 0, 2); empty.Shape()[0] != 0 {
+		t.Errorf("Expected empty range, got %v", empty.Shape())
+	}
+
+	row, err := df.ILocRow(-1)
+	if err != nil || row.Get("name") != "c" {
+		t.Errorf("Expected last row c, got %v (%v)", row.Get("name"), err)
+	}
+	if _, err := df.ILocRow(3); err == nil {
+		t.Error("Expected out of range error for row 3")
+	}
+
+	col, err := df.ILocCol(-2)
+	if err != nil || col.Name() != "id" {
+		t.Fatalf("Expected id column, got %v (%v)", col, err)
+	}
+	col.Set(0, 100)
+	if v, _ := df.At(0, "id"); v != 1 {
+		t.Errorf("Expected ILocCol to return a copy, original changed to %v", v)
+	}
+	if _, err := df.ILocCol(-3); err == nil {
+		t.Error("Expected out of range error for column -3")
+	}
+}
+
+func TestDataFrameSetAtSetRowAppendRow(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"id", "name"})
+
+	if err := df.SetAt(1, "id", 20); err != nil {
+		t.Fatalf("SetAt failed: %v", err)
+	}
+	if v, _ := df.At(1, "id"); v != 20 {
+		t.Errorf("Expected 20, got %v", v)
+	}
+	if err := df.SetAt(0, "id", "x", dataframe.SetOptions{Strict: true}); err == nil {
+		t.Error("Expected strict dtype error")
+	}
+	if err := df.SetAt(0, "id", "x"); err != nil {
+		t.Fatalf("SetAt failed: %v", err)
+	}
+	if ids, _ := df.GetSeries("id"); ids.DType() != dataframe.DTypeObject {
+		t.Errorf("Expected id promoted to object, got %v", ids.DType())
+	}
+	if err := df.SetAt(0, "missing", 1); err == nil {
+		t.Error("Expected missing column error")
+	}
+
+	if err := df.SetRow(0, map[string]interface{}{"id": 1, "missing": 2}); err == nil {
+		t.Error("Expected missing column error from SetRow")
+	}
+	if v, _ := df.At(0, "id"); v != "x" {
+		t.Errorf("Expected failed SetRow to leave row unchanged, got %v", v)
+	}
+
+	grown, err := df.AppendRow(map[string]interface{}{"name": "c"}, "new")
+	if err != nil {
+		t.Fatalf("AppendRow failed: %v", err)
+	}
+	if grown.Shape()[0] != 3 || df.Shape()[0] != 2 {
+		t.Errorf("Expected 3 rows in result and 2 in original, got %v and %v", grown.Shape(), df.Shape())
+	}
+	if v, _ := grown.At("new", "id"); v != nil {
+		t.Errorf("Expected nil fill for id, got %v", v)
+	}
+	if v, _ := grown.At("new", "name"); v != "c" {
+		t.Errorf("Expected name c, got %v", v)
+	}
+}
+
+func TestAddColumnErrors(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"id", "name"})
+
+	added, err := df.AddColumn("score", dataframe.NewSeries([]interface{}{1.5, 2.5}, "ignored"))
+	if err != nil {
+		t.Fatalf("AddColumn failed: %v", err)
+	}
+	if !reflect.DeepEqual(added.Columns(), []string{"id", "name", "score"}) || len(df.Columns()) != 2 {
+		t.Errorf("Unexpected columns %v (original %v)", added.Columns(), df.Columns())
+	}
+	if s, _ := added.GetSeries("score"); s.Name() != "score" {
+		t.Errorf("Expected series renamed to score, got %s", s.Name())
+	}
+
+	_, err = df.AddColumn("score", dataframe.NewSeries([]interface{}{1.5}, "score"))
+	if err == nil || !strings.Contains(err.Error(), "'score'") || !strings.Contains(err.Error(), "1") {
+		t.Errorf("Expected length error naming the column, got %v", err)
+	}
+	if _, err := df.AddColumn("id", dataframe.NewSeries([]interface{}{3, 4}, "id")); err == nil {
+		t.Error("Expected error for existing column")
+	}
+}
+
+func TestLenientSelectors(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"id", "name"})
+
+	sub := df.Loc([]interface{}{1, 5}, []string{"name", "missing"})
+	if sub.Shape() != [2]int{1, 1} || !reflect.DeepEqual(sub.Columns(), []string{"name"}) {
+		t.Errorf("Expected Loc to skip missing labels and columns, got %v %v", sub.Shape(), sub.Columns())
+	}
+	if v, _ := sub.At(1, "name"); v != "b" {
+		t.Errorf("Expected b, got %v", v)
+	}
+	if sel := df.Select("id", "missing"); !reflect.DeepEqual(sel.Columns(), []string{"id"}) {
+		t.Errorf("Expected Select to skip missing columns, got %v", sel.Columns())
+	}
+
+	// Strict counterparts report the missing names
+	if _, err := df.SelectRowsByLabels([]interface{}{1, 5}); err == nil {
+		t.Error("Expected SelectRowsByLabels error for missing label")
+	}
+	if _, err := df.SortBy("missing", dataframe.Ascending); err == nil {
+		t.Error("Expected SortBy error for missing column")
+	}
+}
+
+func TestInfoAndMemoryUsage(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{int64(1), 1.5, "ab"},
+		{int64(2), nil, "cde"},
+	}, []string{"id", "score", "name"})
+
+	dtypes := df.DTypes()
+	if dtypes["id"] != dataframe.DTypeInt64 || dtypes["name"] != dataframe.DTypeString {
+		t.Errorf("Unexpected dtypes %v", dtypes)
+	}
+
+	shallow := df.MemoryUsage(false)
+	deep := df.MemoryUsage(true)
+	// 16-byte interface slots plus the boxed values
+	if shallow["id"] != 2*16+2*8 || shallow["score"] != 2*16+8 {
+		t.Errorf("Unexpected numeric usage %v", shallow)
+	}
+	if shallow["name"] != 2*16+2*16 || deep["name"] != shallow["name"]+5 {
+		t.Errorf("Unexpected string usage shallow=%d deep=%d", shallow["name"], deep["name"])
+	}
+
+	expected := "DataFrame: rows=2, cols=3\n" +
+		"Index: 2 entries, 0 to 1\n" +
+		"#  Column  Non-Null  Dtype    Memory\n" +
+		"0  id      2         int64    48 B\n" +
+		"1  score   1         float64  40 B\n" +
+		"2  name    2         string   69 B\n" +
+		"dtypes: float64(1), int64(1), string(1)\n" +
+		"memory usage: 157 B (interface{} slots: 96 B)\n"
+	if got := df.Info(); got != expected {
+		t.Errorf("Unexpected Info output:\n%s", got)
+	}
+}
+
+func TestDataFrameStringFormat(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"Alice", int64(25), 1.5},
+		{"Bob", int64(130), nil},
+		{"Christopher", int64(7), 20.25},
+	}, []string{"name", "age", "score"})
+
+	expected := "DataFrame: rows=3, cols=3\n" +
+		"   name         age  score\n" +
+		"0  Alice         25    1.5\n" +
+		"1  Bob          130  <nil>\n" +
+		"2  Christopher    7  20.25\n"
+	if got := df.String(); got != expected {
+		t.Errorf("Unexpected String output:\n%s", got)
+	}
+
+	opts := dataframe.DisplayOptions{MaxRows: 2, MaxCols: 2, MaxColWidth: 6, FloatFormat: "%.1f"}
+	expected = "DataFrame: rows=3, cols=3\n" +
+		"   name    …  score\n" +
+		"0  Alice   …    1.5\n" +
+		"…  …       …      …\n" +
+		"2  Chris…  …   20.2\n"
+	if got := df.StringWith(opts); got != expected {
+		t.Errorf("Unexpected StringWith output:\n%s", got)
+	}
+
+	defer dataframe.SetDisplayOptions(dataframe.GetDisplayOptions())
+	dataframe.SetDisplayOptions(opts)
+	if got := df.String(); got != expected {
+		t.Errorf("Expected String to use package display options:\n%s", got)
+	}
+}
+
+func TestRowTypedGetters(t *testing.T) {
+	when := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{int64(1), "2.5", "x", true, when, nil},
+	}, []string{"id", "score", "name", "ok", "at", "empty"})
+	row, _ := df.Row(0)
+
+	if v, err := row.GetInt("id"); err != nil || v != 1 {
+		t.Errorf("GetInt = %v, %v", v, err)
+	}
+	if v, err := row.GetFloat("score"); err != nil || v != 2.5 {
+		t.Errorf("GetFloat = %v, %v", v, err)
+	}
+	if v, err := row.GetString("name"); err != nil || v != "x" {
+		t.Errorf("GetString = %v, %v", v, err)
+	}
+	if v, err := row.GetBool("ok"); err != nil || !v {
+		t.Errorf("GetBool = %v, %v", v, err)
+	}
+	if v, err := row.GetTime("at"); err != nil || !v.Equal(when) {
+		t.Errorf("GetTime = %v, %v", v, err)
+	}
+
+	if _, err := row.GetInt("missing"); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("Expected ErrColumnNotFound, got %v", err)
+	}
+	if _, err := row.GetInt("empty"); !errors.Is(err, dataframe.ErrNAValue) {
+		t.Errorf("Expected ErrNAValue, got %v", err)
+	}
+	_, err := row.GetInt("name")
+	if err == nil || errors.Is(err, dataframe.ErrColumnNotFound) || errors.Is(err, dataframe.ErrNAValue) {
+		t.Errorf("Expected plain conversion error, got %v", err)
+	}
+
+	if !row.Has("empty") || row.Has("missing") {
+		t.Error("Unexpected Has result")
+	}
+	if !reflect.DeepEqual(row.Columns(), df.Columns()) {
+		t.Errorf("Expected columns %v, got %v", df.Columns(), row.Columns())
+	}
+	cols := row.Columns()
+	cols[0] = "changed"
+	_ = append(row.Columns()[:1], "changed")
+	if got := df.Columns(); got[0] != "id" || got[1] != "score" {
+		t.Errorf("Expected editing Columns to leave the frame alone, got %v", got)
+	}
+	if _, ok := df.GetSeries("id"); !ok {
+		t.Error("Expected column id to still be found")
+	}
+	m := row.ToMap()
+	if len(m) != 6 || m["name"] != "x" || m["empty"] != nil {
+		t.Errorf("Unexpected ToMap %v", m)
+	}
+}
+
+func TestDataFrameRankShiftDiff(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", int64(3), 1.0},
+		{"b", int64(1), 4.0},
+		{"c", int64(2), 9.0},
+	}, []string{"name", "n", "x"})
+	df, _ = df.SelectRowsAt([]int{2, 0, 1})
+
+	check := func(name string, got *dataframe.DataFrame) {
+		t.Helper()
+		if got.Shape() != df.Shape() || !got.Index().Equals(df.Index()) {
+			t.Errorf("%s: shape or index changed: %v %v", name, got.Shape(), got.Index().Labels())
+		}
+		if !reflect.DeepEqual(got.Columns(), df.Columns()) {
+			t.Errorf("%s: columns changed: %v", name, got.Columns())
+		}
+		names, _ := got.GetSeries("name")
+		if !reflect.DeepEqual(names.Values(), []interface{}{"c", "a", "b"}) {
+			t.Errorf("%s: expected name passed through, got %v", name, names.Values())
+		}
+	}
+
+	ranked, err := df.Rank(dataframe.RankOptions{})
+	if err != nil {
+		t.Fatalf("Rank error: %v", err)
+	}
+	check("Rank", ranked)
+	if n, _ := ranked.GetSeries("n"); !reflect.DeepEqual(n.Values(), []interface{}{2.0, 3.0, 1.0}) {
+		t.Errorf("Rank n = %v", n.Values())
+	}
+
+	shifted, err := df.Shift(1, "x")
+	if err != nil {
+		t.Fatalf("Shift error: %v", err)
+	}
+	check("Shift", shifted)
+	if n, _ := shifted.GetSeries("n"); !reflect.DeepEqual(n.Values(), []interface{}{int64(2), int64(3), int64(1)}) {
+		t.Errorf("Expected n unchanged, got %v", n.Values())
+	}
+	if x, _ := shifted.GetSeries("x"); !reflect.DeepEqual(x.Values(), []interface{}{nil, 9.0, 1.0}) {
+		t.Errorf("Shift x = %v", x.Values())
+	}
+
+	diffed, err := df.Diff(1)
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+	check("Diff", diffed)
+	if x, _ := diffed.GetSeries("x"); !reflect.DeepEqual(x.Values(), []interface{}{nil, -8.0, 3.0}) {
+		t.Errorf("Diff x = %v", x.Values())
+	}
+
+	if _, err := df.Diff(1, "missing"); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+func TestDataFrameDiffLargeFrame(t *testing.T) {
+	n := 50000
+	a := make([]interface{}, n)
+	b := make([]interface{}, n)
+	c := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		a[i] = int64(i * 2)
+		b[i] = float64(i)
+		c[i] = "x"
+	}
+	df, _ := dataframe.New(map[string][]interface{}{"a": a, "b": b, "c": c})
+
+	diffed, err := df.Diff(1)
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+	if diffed.Shape() != df.Shape() || !reflect.DeepEqual(diffed.Columns(), df.Columns()) {
+		t.Fatalf("Unexpected shape %v or columns %v", diffed.Shape(), diffed.Columns())
+	}
+	s, _ := diffed.GetSeries("a")
+	if v, _ := s.Get(n - 1); v != int64(2) {
+		t.Errorf("Expected diff 2, got %v", v)
+	}
+	if s, _ := diffed.GetSeries("c"); s.DType() != dataframe.DTypeString {
+		t.Errorf("Expected c passed through, got %v", s.DType())
+	}
+}
+
+func TestHashRows(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1, "a"},
+		{int64(1), "a"},
+		{1.0, "a"},
+		{"1", "a"},
+		{nil, "a"},
+		{0, "a"},
+		{"", "a"},
+	}, []string{"k", "v"})
+	h := dataframe.HashRows(df, []string{"k", "v"})
+	if h[0] != h[1] {
+		t.Error("Expected int and int64 of equal value to hash alike")
+	}
+	for i := 2; i < len(h); i++ {
+		for j := 0; j < i; j++ {
+			if h[i] == h[j] && !(i == 1 && j == 0) {
+				t.Errorf("Expected rows %d and %d to hash differently", i, j)
+			}
+		}
+	}
+	if single := dataframe.HashRows(df, []string{"v"}); single[0] != single[4] {
+		t.Error("Expected equal values to hash alike")
+	}
+}
+
+func TestDuplicatedRows(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1},
+		{"b", 2},
+		{"a", 1},
+		{nil, 3},
+		{"b", 2.0},
+		{nil, 3},
+		{"c", 1},
+	}, []string{"k", "v"})
+
+	dups, err := df.DuplicatedRows(nil)
+	if err != nil {
+		t.Fatalf("DuplicatedRows failed: %v", err)
+	}
+	if !reflect.DeepEqual(dups.Index().Labels(), []interface{}{0, 2, 3, 5}) {
+		t.Errorf("Unexpected rows %v", dups.Index().Labels())
+	}
+	groups, _ := dups.GetSeries("_dup_group")
+	if !reflect.DeepEqual(groups.Values(), []interface{}{int64(0), int64(0), int64(1), int64(1)}) {
+		t.Errorf("Unexpected groups %v", groups.Values())
+	}
+
+	byKey, err := df.DuplicatedRows([]string{"k"})
+	if err != nil {
+		t.Fatalf("DuplicatedRows failed: %v", err)
+	}
+	if !reflect.DeepEqual(byKey.Index().Labels(), []interface{}{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("Unexpected rows for subset %v", byKey.Index().Labels())
+	}
+
+	if _, err := df.DuplicatedRows([]string{"missing"}); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+	none, _ := df.DuplicatedRows([]string{"k", "v"})
+	if _, err := none.DuplicatedRows(nil); err == nil {
+		t.Error("Expected error when _dup_group already exists")
+	}
+}
+
+func TestStructuredErrors(t *testing.T) {
+	df, err := dataframe.New(map[string][]interface{}{
+		"a": {1, 2, 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = df.At(0, "missing")
+	var colErr *dataframe.ColumnNotFoundError
+	if !errors.As(err, &colErr) || colErr.Column != "missing" || !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Fatalf("expected ColumnNotFoundError, got %v", err)
+	}
+	if err.Error() != "column 'missing' not found" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+
+	_, err = df.Row(5)
+	var rangeErr *dataframe.IndexOutOfRangeError
+	if !errors.As(err, &rangeErr) || rangeErr.Pos != 5 || rangeErr.Len != 3 || !errors.Is(err, dataframe.ErrIndexOutOfRange) {
+		t.Fatalf("expected IndexOutOfRangeError, got %v", err)
+	}
+	if err.Error() != "row 5 out of range" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+
+	_, err = dataframe.NewIndex([]interface{}{"x", "y"}, "").GetLoc("z")
+	var labelErr *dataframe.LabelNotFoundError
+	if !errors.As(err, &labelErr) || labelErr.Label != "z" || !errors.Is(err, dataframe.ErrLabelNotFound) {
+		t.Fatalf("expected LabelNotFoundError, got %v", err)
+	}
+
+	err = df.SetColumn("b", dataframe.NewSeries([]interface{}{1, 2}, "b"))
+	var lenErr *dataframe.LengthMismatchError
+	if !errors.As(err, &lenErr) || lenErr.Want != 3 || lenErr.Got != 2 || !errors.Is(err, dataframe.ErrLengthMismatch) {
+		t.Fatalf("expected LengthMismatchError, got %v", err)
+	}
+	if err.Error() != "series length 2 does not match dataframe rows 3" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+
+	_, err = dataframe.ConvertToType("abc", dataframe.DTypeInt64)
+	var convErr *dataframe.TypeConversionError
+	if !errors.As(err, &convErr) || convErr.Value != "abc" || convErr.Target != dataframe.DTypeInt64 || !errors.Is(err, dataframe.ErrTypeConversion) {
+		t.Fatalf("expected TypeConversionError, got %v", err)
+	}
+	if errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Fatalf("conversion error should not match ErrColumnNotFound")
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	b := dataframe.NewBuilder().
+		AddStrings("name", []string{"Alice", "Bob"}).
+		AddInts("age", []int{25, 30}).
+		AddFloats("score", []float64{1.5, 2.5}).
+		AddBools("active", []bool{true, false}).
+		AddTimes("joined", []time.Time{day, {}})
+	df, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(df.Columns(), []string{"name", "age", "score", "active", "joined"}) {
+		t.Fatalf("unexpected column order: %v", df.Columns())
+	}
+	wantTypes := []dataframe.DType{dataframe.DTypeString, dataframe.DTypeInt64, dataframe.DTypeFloat64, dataframe.DTypeBool, dataframe.DTypeDateTime}
+	for i, col := range df.Columns() {
+		s, _ := df.GetSeries(col)
+		if s.DType() != wantTypes[i] {
+			t.Fatalf("column %s: expected %s, got %s", col, wantTypes[i], s.DType())
+		}
+	}
+	joined, _ := df.GetSeries("joined")
+	if na, _ := joined.IsNA().Get(1); na != true {
+		t.Fatalf("expected zero time to be NA")
+	}
+
+	// Building again gives an independent DataFrame
+	other, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := df.SetAt(0, "age", 99); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := other.At(0, "age"); v != int64(25) {
+		t.Fatalf("expected builds to be independent, got %v", v)
+	}
+
+	_, err = dataframe.NewBuilder().AddInts("a", []int{1, 2}).AddInts("b", []int{1}).Build()
+	if !errors.Is(err, dataframe.ErrLengthMismatch) {
+		t.Fatalf("expected length mismatch, got %v", err)
+	}
+	_, err = dataframe.NewBuilder().AddInts("a", []int{1}).AddStrings("a", []string{"x"}).Build()
+	if err == nil {
+		t.Fatalf("expected duplicate column error")
+	}
+
+	empty, err := dataframe.NewBuilder().Build()
+	if err != nil || empty.Shape() != [2]int{0, 0} {
+		t.Fatalf("expected empty DataFrame, got %v, %v", empty, err)
+	}
+}
+
+func TestDataFrameArithmetic(t *testing.T) {
+	a, _ := dataframe.FromRecords([][]interface{}{
+		{1, 10.0, "x"},
+		{2, 20.0, "y"},
+		{3, 30.0, "z"},
+	}, []string{"n", "f", "s"})
+	b, _ := dataframe.FromRecords([][]interface{}{
+		{10, 0.0, 5},
+		{20, 4.0, 6},
+		{30, 5.0, 7},
+	}, []string{"n", "f", "extra"})
+
+	sum, err := a.AddDF(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sum.Columns(), []string{"n", "f", "s", "extra"}) {
+		t.Fatalf("unexpected columns: %v", sum.Columns())
+	}
+	n, _ := sum.GetSeries("n")
+	if !reflect.DeepEqual(n.Values(), []interface{}{11.0, 22.0, 33.0}) {
+		t.Errorf("unexpected sums: %v", n.Values())
+	}
+	for _, col := range []string{"s", "extra"} {
+		s, _ := sum.GetSeries(col)
+		if !reflect.DeepEqual(s.Values(), []interface{}{nil, nil, nil}) {
+			t.Errorf("expected nil column %s, got %v", col, s.Values())
+		}
+	}
+
+	quot, err := a.DivDF(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f, _ := quot.GetSeries("f")
+	if v, _ := f.Get(0); !math.IsNaN(v.(float64)) {
+		t.Errorf("expected NaN for division by zero, got %v", v)
+	}
+	if v, _ := f.Get(1); v != 5.0 {
+		t.Errorf("expected 5, got %v", v)
+	}
+
+	// Rows are aligned by label: only label 2 is in both frames
+	diff, err := a.Head(3).SubDF(b.Tail(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(diff.Index().Labels(), []interface{}{0, 1, 2}) {
+		t.Fatalf("unexpected index: %v", diff.Index().Labels())
+	}
+	n, _ = diff.GetSeries("n")
+	if !reflect.DeepEqual(n.Values(), []interface{}{nil, nil, -27.0}) {
+		t.Errorf("unexpected aligned difference: %v", n.Values())
+	}
+
+	// Dividing every numeric column by one column, down the rows
+	total, _ := a.GetSeries("n")
+	norm, err := a.DivSeries(total, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f, _ = norm.GetSeries("f")
+	if !reflect.DeepEqual(f.Values(), []interface{}{10.0, 10.0, 10.0}) {
+		t.Errorf("unexpected normalized values: %v", f.Values())
+	}
+	s, _ := norm.GetSeries("s")
+	if !reflect.DeepEqual(s.Values(), []interface{}{"x", "y", "z"}) || s.DType() != dataframe.DTypeString {
+		t.Errorf("expected string column to pass through, got %v", s.Values())
+	}
+
+	// Across the columns, matched by name
+	offsets := dataframe.NewSeriesWithIndex([]interface{}{100, 1000}, "offset", dataframe.NewIndex([]interface{}{"n", "other"}, ""))
+	shifted, err := a.AddSeries(offsets, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, _ = shifted.GetSeries("n")
+	f, _ = shifted.GetSeries("f")
+	if !reflect.DeepEqual(n.Values(), []interface{}{101.0, 102.0, 103.0}) {
+		t.Errorf("unexpected values for n: %v", n.Values())
+	}
+	if !reflect.DeepEqual(f.Values(), []interface{}{nil, nil, nil}) {
+		t.Errorf("expected nil for a column missing from the series, got %v", f.Values())
+	}
+
+	if _, err := a.AddSeries(offsets, 2); err == nil {
+		t.Error("expected error for invalid axis")
+	}
+
+	// Repeated labels cannot be aligned, so no row is silently dropped
+	exploded, err := dataframe.NewBuilder().AddInts("x", []int{1, 2, 3}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exploded, err = exploded.SelectRowsAt([]int{0, 0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	one, _ := dataframe.NewBuilder().AddInts("x", []int{10}).Build()
+	if _, err := exploded.AddDF(one); err == nil {
+		t.Error("expected error for repeated index labels")
+	}
+	if _, err := one.AddDF(exploded); err == nil {
+		t.Error("expected error for repeated index labels on the right")
+	}
+	// Equal indexes are combined row by row, repeated labels included
+	doubled, err := exploded.AddDF(exploded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x, _ := doubled.GetSeries("x")
+	if !reflect.DeepEqual(x.Values(), []interface{}{2.0, 2.0, 4.0}) {
+		t.Errorf("unexpected values: %v", x.Values())
+	}
+	if _, err := one.AddSeries(x, 0); err == nil {
+		t.Error("expected error for a series with repeated labels")
+	}
+}
+
+func TestDataFrameWhereMask(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1.0, 5},
+		{50.0, 7},
+		{nil, 100},
+	}, []string{"a", "b"})
+
+	outlier, err := df.ApplyColumns(func(s *dataframe.Series) *dataframe.Series {
+		return s.Apply(func(v interface{}) interface{} {
+			if v == nil {
+				return nil
+			}
+			f, _ := dataframe.ConvertToType(v, dataframe.DTypeFloat64)
+			return f.(float64) > 10
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	censored, err := df.MaskDF(outlier, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a, _ := censored.GetSeries("a")
+	b, _ := censored.GetSeries("b")
+	// The nil condition is false, so the NA value is kept
+	if !reflect.DeepEqual(a.Values(), []interface{}{1.0, 10, nil}) {
+		t.Errorf("unexpected masked values: %v", a.Values())
+	}
+	if !reflect.DeepEqual(b.Values(), []interface{}{5, 7, 10}) {
+		t.Errorf("unexpected masked values: %v", b.Values())
+	}
+
+	kept, err := df.WhereDF(outlier, df.IsNA())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a, _ = kept.GetSeries("a")
+	if !reflect.DeepEqual(a.Values(), []interface{}{false, 50.0, true}) {
+		t.Errorf("unexpected values from other frame: %v", a.Values())
+	}
+	if !reflect.DeepEqual(kept.Columns(), df.Columns()) || !kept.Index().Equals(df.Index()) {
+		t.Errorf("expected columns and index to be kept")
+	}
+
+	if _, err := df.WhereDF(outlier.Head(2), 0); !errors.Is(err, dataframe.ErrLengthMismatch) {
+		t.Errorf("expected length mismatch, got %v", err)
+	}
+	if _, err := df.WhereDF(outlier.Select("a"), 0); err == nil {
+		t.Error("expected error for missing condition column")
+	}
+	renamed := outlier.Rename(map[string]string{"b": "c"})
+	if _, err := df.MaskDF(renamed, 0); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("expected column not found, got %v", err)
+	}
+}
@@ -0,0 +1,491 @@
+package tests
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BAIGUANGMEI/datago/dataframe"
+)
+
+func TestInferDTypeFromSlicePromotion(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []interface{}
+		want   dataframe.DType
+	}{
+		{"ints", []interface{}{1, int64(2), nil}, dataframe.DTypeInt64},
+		{"int then float", []interface{}{1, 2.5, 3}, dataframe.DTypeFloat64},
+		{"float then int", []interface{}{2.5, 1}, dataframe.DTypeFloat64},
+		{"strings", []interface{}{"a", nil, "b"}, dataframe.DTypeString},
+		{"string and int", []interface{}{"a", 1}, dataframe.DTypeObject},
+		{"int and string", []interface{}{1, "a"}, dataframe.DTypeObject},
+		{"bools", []interface{}{true, false}, dataframe.DTypeBool},
+		{"bool and int", []interface{}{true, 1}, dataframe.DTypeObject},
+		{"times", []interface{}{time.Now(), nil}, dataframe.DTypeDateTime},
+		{"all nil", []interface{}{nil, nil}, dataframe.DTypeObject},
+		{"empty", []interface{}{}, dataframe.DTypeObject},
+	}
+	for _, tc := range cases {
+		if got := dataframe.InferDTypeFromSlice(tc.values); got != tc.want {
+			t.Errorf("%s: InferDTypeFromSlice() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNewSeriesUsesFullScan(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2.5, 3}, "v")
+	if s.DType() != dataframe.DTypeFloat64 {
+		t.Fatalf("NewSeries() dtype = %v, want float64", s.DType())
+	}
+}
+
+func TestConvertToTypeNumbers(t *testing.T) {
+	type myInt int
+	cases := []struct {
+		in        interface{}
+		wantInt   int64
+		wantFloat float64
+		ok        bool
+	}{
+		{"42", 42, 42, true},
+		{" 42 ", 42, 42, true},
+		{"\t-7\n", -7, -7, true},
+		{"+3", 3, 3, true},
+		{"1e5", 100000, 100000, true},
+		{"3.7", 3, 3.7, true},
+		{"1E-3", 0, 0.001, true},
+		{".5", 0, 0.5, true},
+		{"12abc", 12, 12, true}, // a leading number is accepted as before
+		{"abc", 0, 0, false},
+		{"", 0, 0, false},
+		{int8(-5), -5, -5, true},
+		{uint32(9), 9, 9, true},
+		{float32(2.5), 2, 2.5, true},
+		{myInt(11), 11, 11, true},
+		{true, 0, 0, false},
+	}
+	for _, tc := range cases {
+		i, err := dataframe.ConvertToType(tc.in, dataframe.DTypeInt64)
+		if tc.ok && (err != nil || i != tc.wantInt) {
+			t.Errorf("ConvertToType(%#v, int64) = %v, %v; want %d", tc.in, i, err, tc.wantInt)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("ConvertToType(%#v, int64) = %v; want error", tc.in, i)
+		}
+		f, err := dataframe.ConvertToType(tc.in, dataframe.DTypeFloat64)
+		if tc.ok && (err != nil || f != tc.wantFloat) {
+			t.Errorf("ConvertToType(%#v, float64) = %v, %v; want %v", tc.in, f, err, tc.wantFloat)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("ConvertToType(%#v, float64) = %v; want error", tc.in, f)
+		}
+	}
+
+	if f, err := dataframe.ConvertToType("NaN", dataframe.DTypeFloat64); err != nil || !math.IsNaN(f.(float64)) {
+		t.Errorf("Expected NaN, got %v, %v", f, err)
+	}
+	if _, err := dataframe.ConvertToType("NaN", dataframe.DTypeInt64); err == nil {
+		t.Error("Expected error converting NaN to int64")
+	}
+	if _, err := dataframe.ConvertToType("9223372036854775808", dataframe.DTypeInt64); err == nil {
+		t.Error("Expected out of range error")
+	}
+}
+
+func TestConvertToTypeRounding(t *testing.T) {
+	trunc := dataframe.CastOptions{}
+	round := dataframe.CastOptions{Rounding: dataframe.RoundingRound}
+	strict := dataframe.CastOptions{Rounding: dataframe.RoundingError, CheckOverflow: true}
+	cases := []struct {
+		in   interface{}
+		opts dataframe.CastOptions
+		want int64
+		ok   bool
+	}{
+		{3.5, trunc, 3, true},
+		{-3.5, trunc, -3, true},
+		{3.5, round, 4, true},
+		{-2.5, round, -3, true},
+		{"2.6", round, 3, true},
+		{3.5, strict, 0, false},
+		{"3.5", strict, 0, false},
+		{"12abc", strict, 0, false},
+		{3.0, strict, 3, true},
+		{"1e5", strict, 100000, true},
+		{uint64(math.MaxUint64), strict, 0, false},
+		{uint64(42), strict, 42, true},
+		{1e20, strict, 0, false},
+		{"1e20", strict, 0, false},
+		{math.Inf(1), strict, 0, false},
+		{math.NaN(), dataframe.CastOptions{CheckOverflow: true}, 0, false},
+	}
+	for _, tc := range cases {
+		got, err := dataframe.ConvertToType(tc.in, dataframe.DTypeInt64, tc.opts)
+		if tc.ok && (err != nil || got != tc.want) {
+			t.Errorf("ConvertToType(%#v, %+v) = %v, %v; want %d", tc.in, tc.opts, got, err, tc.want)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("ConvertToType(%#v, %+v) = %v; want error", tc.in, tc.opts, got)
+		}
+	}
+
+	// Without options large values still wrap as before
+	if got, err := dataframe.ConvertToType(uint64(math.MaxUint64), dataframe.DTypeInt64); err != nil || got != int64(-1) {
+		t.Errorf("Expected wrapped -1, got %v, %v", got, err)
+	}
+}
+
+func TestSeriesAsTypeOpts(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1.0, 2.5, nil}, "x")
+
+	if _, err := s.AsTypeOpts(dataframe.DTypeInt64, dataframe.CastOptions{Rounding: dataframe.RoundingError}); err == nil {
+		t.Error("Expected error for fractional value")
+	}
+
+	rounded, err := s.AsTypeOpts(dataframe.DTypeInt64, dataframe.CastOptions{Rounding: dataframe.RoundingRound})
+	if err != nil {
+		t.Fatalf("AsTypeOpts error: %v", err)
+	}
+	if v, _ := rounded.Get(1); v != int64(3) {
+		t.Errorf("Expected 3, got %v", v)
+	}
+
+	coerced, err := s.AsTypeOpts(dataframe.DTypeInt64, dataframe.CastOptions{Errors: dataframe.CastCoerce, Rounding: dataframe.RoundingError})
+	if err != nil {
+		t.Fatalf("AsTypeOpts error: %v", err)
+	}
+	if v, _ := coerced.Get(0); v != int64(1) {
+		t.Errorf("Expected 1, got %v", v)
+	}
+	if v, _ := coerced.Get(1); v != nil {
+		t.Errorf("Expected coerced nil, got %v", v)
+	}
+
+	ignored, err := s.AsTypeOpts(dataframe.DTypeInt64, dataframe.CastOptions{Errors: dataframe.CastIgnore, Rounding: dataframe.RoundingError})
+	if err != nil {
+		t.Fatalf("AsTypeOpts error: %v", err)
+	}
+	if v, _ := ignored.Get(1); v != 2.5 || ignored.DType() != dataframe.DTypeFloat64 {
+		t.Errorf("Expected unchanged Series, got %v (%v)", v, ignored.DType())
+	}
+
+	if _, err := s.AsTypeOpts(dataframe.DTypeInt64, dataframe.CastOptions{Rounding: "ceil"}); err == nil {
+		t.Error("Expected error for unknown rounding mode")
+	}
+}
+
+func TestParseBoolTokens(t *testing.T) {
+	tokens := map[string]bool{
+		"true": true, "false": false,
+		"t": true, "f": false,
+		"yes": true, "no": false,
+		"y": true, "n": false,
+		"on": true, "off": false,
+		"1": true, "0": false,
+	}
+	for token, want := range tokens {
+		for _, s := range []string{token, strings.ToUpper(token), " " + token + " "} {
+			got, err := dataframe.ParseBool(s)
+			if err != nil || got != want {
+				t.Errorf("ParseBool(%q) = %v, %v; want %v", s, got, err, want)
+			}
+			if v, err := dataframe.ConvertToType(s, dataframe.DTypeBool); err != nil || v != want {
+				t.Errorf("ConvertToType(%q, bool) = %v, %v; want %v", s, v, err, want)
+			}
+		}
+	}
+	if got, err := dataframe.ParseBool("False"); err != nil || got {
+		t.Errorf("ParseBool(\"False\") = %v, %v; want false", got, err)
+	}
+
+	for _, s := range []string{"abc", "2", "yess", "-1"} {
+		if _, err := dataframe.ParseBool(s); err == nil {
+			t.Errorf("ParseBool(%q): expected error", s)
+		}
+		if _, err := dataframe.ConvertToType(s, dataframe.DTypeBool); err == nil {
+			t.Errorf("ConvertToType(%q, bool): expected error", s)
+		}
+	}
+	// The empty string is not a token; as NA it becomes nil when a column is
+	// cast. Numbers are true unless zero
+	if _, err := dataframe.ConvertToType("", dataframe.DTypeBool); err == nil {
+		t.Error("ConvertToType(\"\", bool): expected error")
+	}
+	cast, err := dataframe.NewSeries([]interface{}{"yes", "", "NA", "off"}, "b").AsType(dataframe.DTypeBool)
+	if err != nil || !reflect.DeepEqual(cast.Values(), []interface{}{true, nil, nil, false}) {
+		t.Errorf("AsType(bool) = %v, %v; want [true <nil> <nil> false]", cast, err)
+	}
+	if v, err := dataframe.ConvertToType(2.5, dataframe.DTypeBool); err != nil || v != true {
+		t.Errorf("ConvertToType(2.5, bool) = %v, %v; want true", v, err)
+	}
+
+	if _, err := dataframe.ParseBool("ja"); err == nil {
+		t.Error("Expected error before registering ja")
+	}
+	dataframe.RegisterBoolToken("ja", true)
+	dataframe.RegisterBoolToken("Nein", false)
+	if got, err := dataframe.ParseBool("JA"); err != nil || !got {
+		t.Errorf("ParseBool(\"JA\") = %v, %v; want true", got, err)
+	}
+	if got, err := dataframe.ParseBool("nein"); err != nil || got {
+		t.Errorf("ParseBool(\"nein\") = %v, %v; want false", got, err)
+	}
+}
+
+func BenchmarkConvertStringToFloat64(b *testing.B) {
+	values := []string{"42", "3.14159", " 1e5 ", "-0.001", "abc"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = dataframe.ConvertToType(values[i%len(values)], dataframe.DTypeFloat64)
+	}
+}
+
+func BenchmarkConvertStringToInt64(b *testing.B) {
+	values := []string{"42", "-17", " 123456789 ", "1e5", "abc"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = dataframe.ConvertToType(values[i%len(values)], dataframe.DTypeInt64)
+	}
+}
+
+func BenchmarkConvertIntToFloat64(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = dataframe.ConvertToType(i, dataframe.DTypeFloat64)
+	}
+}
+
+func TestAsTypes(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"1", "1.5", "x"},
+		{"2", "bad", "y"},
+	}, []string{"a", "b", "c"})
+
+	converted, err := df.AsTypes(map[string]dataframe.DType{"a": dataframe.DTypeInt64}, dataframe.CastOptions{})
+	if err != nil {
+		t.Fatalf("AsTypes failed: %v", err)
+	}
+	if s, _ := converted.GetSeries("a"); s.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64, got %v", s.DType())
+	}
+	if s, _ := df.GetSeries("a"); s.DType() != dataframe.DTypeString {
+		t.Error("Expected original DataFrame unchanged")
+	}
+
+	// Every failing column is reported
+	bad := map[string]dataframe.DType{"a": dataframe.DTypeInt64, "b": dataframe.DTypeFloat64, "c": dataframe.DTypeDateTime}
+	_, err = df.AsTypes(bad, dataframe.CastOptions{Errors: dataframe.CastRaise})
+	if err == nil || !strings.Contains(err.Error(), "'b'") || !strings.Contains(err.Error(), "'c'") {
+		t.Errorf("Expected errors for b and c, got %v", err)
+	}
+
+	coerced, err := df.AsTypes(bad, dataframe.CastOptions{Errors: dataframe.CastCoerce})
+	if err != nil {
+		t.Fatalf("Coerce failed: %v", err)
+	}
+	if s, _ := coerced.GetSeries("b"); s.DType() != dataframe.DTypeFloat64 {
+		t.Errorf("Expected float64, got %v", s.DType())
+	} else if v, _ := s.Get(1); v != nil {
+		t.Errorf("Expected coerced nil, got %v", v)
+	}
+
+	ignored, err := df.AsTypes(bad, dataframe.CastOptions{Errors: dataframe.CastIgnore})
+	if err != nil {
+		t.Fatalf("Ignore failed: %v", err)
+	}
+	if s, _ := ignored.GetSeries("b"); s.DType() != dataframe.DTypeString {
+		t.Errorf("Expected b left unchanged, got %v", s.DType())
+	}
+	if s, _ := ignored.GetSeries("a"); s.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected a converted, got %v", s.DType())
+	}
+
+	if _, err := df.AsTypes(map[string]dataframe.DType{"missing": dataframe.DTypeInt64}, dataframe.CastOptions{}); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+func TestDateTimeFormatsAndLocation(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"03/04/2024", "2024.05.06 07h08"},
+		{"12/01/2024", nil},
+	}, []string{"ambiguous", "custom"})
+
+	// Without a registered layout the custom column cannot be parsed
+	if _, err := df.AsTypes(map[string]dataframe.DType{"custom": dataframe.DTypeDateTime}, dataframe.CastOptions{}); err == nil {
+		t.Fatal("Expected error for unregistered layout")
+	}
+	dataframe.RegisterDateTimeFormat("2006.01.02 15h04")
+	dataframe.RegisterDateTimeFormat("2006.01.02 15h04")
+	converted, err := df.AsTypes(map[string]dataframe.DType{"custom": dataframe.DTypeDateTime}, dataframe.CastOptions{})
+	if err != nil {
+		t.Fatalf("AsTypes failed: %v", err)
+	}
+	custom, _ := converted.GetSeries("custom")
+	if v, _ := custom.Get(0); !v.(time.Time).Equal(time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected custom time %v", v)
+	}
+
+	// The built-in US layout reads ambiguous dates unless a layout is preferred
+	dates := map[string]dataframe.DType{"ambiguous": dataframe.DTypeDateTime}
+	us, _ := df.AsTypes(dates, dataframe.CastOptions{})
+	usSeries, _ := us.GetSeries("ambiguous")
+	if v, _ := usSeries.Get(0); v.(time.Time).Month() != time.March {
+		t.Errorf("Expected March, got %v", v)
+	}
+	cet := time.FixedZone("CET", 3600)
+	eu, err := df.AsTypes(dates, dataframe.CastOptions{DateTimeFormats: []string{"02/01/2006"}, Location: cet})
+	if err != nil {
+		t.Fatalf("AsTypes failed: %v", err)
+	}
+	euSeries, _ := eu.GetSeries("ambiguous")
+	want := []time.Time{time.Date(2024, 4, 3, 0, 0, 0, 0, cet), time.Date(2024, 1, 12, 0, 0, 0, 0, cet)}
+	for i, w := range want {
+		if v, _ := euSeries.Get(i); !v.(time.Time).Equal(w) || v.(time.Time).Location() != cet {
+			t.Errorf("Row %d: expected %v, got %v", i, w, v)
+		}
+	}
+}
+
+func TestSeriesTzLocalizeConvert(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), nil}, "ts")
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	localized, err := s.TzLocalize(shanghai)
+	if err != nil {
+		t.Fatalf("TzLocalize failed: %v", err)
+	}
+	if v, _ := localized.Get(0); !v.(time.Time).Equal(time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 09:00 CST, got %v", v)
+	}
+	if v, _ := localized.Get(1); v != nil {
+		t.Errorf("Expected nil kept, got %v", v)
+	}
+
+	converted, err := s.TzConvert(shanghai)
+	if err != nil {
+		t.Fatalf("TzConvert failed: %v", err)
+	}
+	if v, _ := converted.Get(0); v.(time.Time).Hour() != 17 || !v.(time.Time).Equal(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 17:00 CST for the same instant, got %v", v)
+	}
+
+	if _, err := dataframe.NewSeries([]interface{}{"x"}, "s").TzConvert(shanghai); err == nil {
+		t.Error("Expected error for non-datetime Series")
+	}
+	if _, err := s.TzLocalize(nil); err == nil {
+		t.Error("Expected error for nil location")
+	}
+}
+
+func TestPromoteDTypesMatrix(t *testing.T) {
+	const (
+		u   = dataframe.DTypeUnknown
+		i64 = dataframe.DTypeInt64
+		f64 = dataframe.DTypeFloat64
+		str = dataframe.DTypeString
+		b   = dataframe.DTypeBool
+		dt  = dataframe.DTypeDateTime
+		obj = dataframe.DTypeObject
+	)
+	dtypes := []dataframe.DType{u, i64, f64, str, b, dt, obj}
+	// want[i][j] is PromoteDTypes(dtypes[i], dtypes[j])
+	want := [][]dataframe.DType{
+		/* u   */ {u, i64, f64, str, b, dt, obj},
+		/* i64 */ {i64, i64, f64, obj, i64, obj, obj},
+		/* f64 */ {f64, f64, f64, obj, f64, obj, obj},
+		/* str */ {str, obj, obj, str, obj, obj, obj},
+		/* b   */ {b, i64, f64, obj, b, obj, obj},
+		/* dt  */ {dt, obj, obj, obj, obj, dt, obj},
+		/* obj */ {obj, obj, obj, obj, obj, obj, obj},
+	}
+	for i, a := range dtypes {
+		for j, c := range dtypes {
+			if got := dataframe.PromoteDTypes(a, c); got != want[i][j] {
+				t.Errorf("PromoteDTypes(%v, %v) = %v, want %v", a, c, got, want[i][j])
+			}
+		}
+	}
+}
+
+func TestParseNumeric(t *testing.T) {
+	us := dataframe.NumericParseOptions{Thousands: ',', Symbols: []string{"$"}, Percent: true}
+	eu := dataframe.NumericParseOptions{Thousands: '.', Decimal: ',', Symbols: []string{"€"}}
+	cases := []struct {
+		in   string
+		opts dataframe.NumericParseOptions
+		want float64
+		ok   bool
+	}{
+		{"1,234.5", us, 1234.5, true},
+		{"85%", us, 0.85, true},
+		{"12.5 %", us, 0.125, true},
+		{"$1,000", us, 1000, true},
+		{"-$1,000", us, -1000, true},
+		{"$-5", us, -5, true},
+		{" 42 ", us, 42, true},
+		{"1.234,5", eu, 1234.5, true},
+		{"1.234.567,89 €", eu, 1234567.89, true},
+		{"-0,5", eu, -0.5, true},
+		{"1,2,3", eu, 0, false},
+		{"1.5", dataframe.NumericParseOptions{Decimal: ','}, 0, false},
+		{"abc", us, 0, false},
+		{"12abc", us, 0, false},
+		{"85%", dataframe.NumericParseOptions{Thousands: ','}, 0, false},
+	}
+	for _, tc := range cases {
+		got, err := dataframe.ConvertToType(tc.in, dataframe.DTypeFloat64, dataframe.CastOptions{Numeric: tc.opts})
+		if tc.ok && (err != nil || got != tc.want) {
+			t.Errorf("ConvertToType(%q, %+v) = %v, %v; want %v", tc.in, tc.opts, got, err, tc.want)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("ConvertToType(%q, %+v) = %v; want error", tc.in, tc.opts, got)
+		}
+	}
+
+	// Integers keep their precision and follow Rounding
+	if got, err := dataframe.ConvertToType("9,007,199,254,740,993", dataframe.DTypeInt64, dataframe.CastOptions{Numeric: us}); err != nil || got != int64(9007199254740993) {
+		t.Errorf("Expected 9007199254740993, got %v, %v", got, err)
+	}
+	if _, err := dataframe.ConvertToType("1,234.5", dataframe.DTypeInt64, dataframe.CastOptions{Numeric: us, Rounding: dataframe.RoundingError}); err == nil {
+		t.Error("Expected error for fractional int64")
+	}
+
+	s := dataframe.NewSeries([]interface{}{"$1,200", "15%", nil, 3, "n/a"}, "amount")
+	if _, err := s.ParseNumeric(us); err == nil {
+		t.Error("Expected error for n/a")
+	}
+	us.Errors = dataframe.CastCoerce
+	parsed, err := s.ParseNumeric(us)
+	if err != nil {
+		t.Fatalf("ParseNumeric error: %v", err)
+	}
+	want := []interface{}{1200.0, 0.15, nil, 3.0, nil}
+	if got := parsed.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNumeric() = %v, want %v", got, want)
+	}
+	if parsed.DType() != dataframe.DTypeFloat64 {
+		t.Errorf("Expected float64, got %v", parsed.DType())
+	}
+
+	// Blank and NA cells are missing values, not parse failures, with the
+	// default raise policy too
+	blanks := dataframe.NewSeries([]interface{}{"$5", "", "NA", "1,000"}, "amount")
+	parsed, err = blanks.ParseNumeric(dataframe.NumericParseOptions{Thousands: ',', Symbols: []string{"$"}})
+	if err != nil {
+		t.Fatalf("ParseNumeric error: %v", err)
+	}
+	if got := parsed.Values(); !reflect.DeepEqual(got, []interface{}{5.0, nil, nil, 1000.0}) {
+		t.Errorf("ParseNumeric() with blanks = %v", got)
+	}
+
+	if _, err := s.ParseNumeric(dataframe.NumericParseOptions{Thousands: '.'}); err == nil {
+		t.Error("Expected error for '.' as both separators")
+	}
+	if _, err := s.ParseNumeric(dataframe.NumericParseOptions{Errors: "skip"}); err == nil {
+		t.Error("Expected error for unknown error policy")
+	}
+}
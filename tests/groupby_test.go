@@ -1,7 +1,13 @@
 package tests
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -110,6 +116,97 @@ func TestGroupByAgg(t *testing.T) {
 	if result.Shape()[0] != 2 {
 		t.Errorf("Expected 2 rows, got %d", result.Shape()[0])
 	}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, []string{"group", "value_sum", "value_mean"}) {
+		t.Errorf("Expected columns [group value_sum value_mean], got %v", cols)
+	}
+}
+
+func TestGroupByAggPopulationStdVar(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"A", 2.0}, {"A", 4.0}, {"A", 4.0}, {"A", 4.0}, {"A", 5.0}, {"A", 5.0}, {"A", 7.0}, {"A", 9.0},
+		{"B", 3.0},
+		{"C", nil}, {"C", nil},
+	}, []string{"group", "value"})
+	gb, _ := df.GroupBy("group")
+	result, err := gb.Agg(map[string][]dataframe.AggFunc{
+		"value": {dataframe.AggStdP, dataframe.AggVarP, dataframe.AggVar},
+	})
+	if err != nil {
+		t.Fatalf("Agg failed: %v", err)
+	}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, []string{"group", "value_stdp", "value_varp", "value_var"}) {
+		t.Fatalf("Unexpected columns %v", cols)
+	}
+
+	check := func(col string, want []float64) {
+		t.Helper()
+		s, _ := result.GetSeries(col)
+		for i, w := range want {
+			v, _ := s.Get(i)
+			got, ok := v.(float64)
+			if !ok || (math.IsNaN(w) != math.IsNaN(got)) || (!math.IsNaN(w) && math.Abs(got-w) > 1e-12) {
+				t.Errorf("%s[%d] = %v, want %v", col, i, v, w)
+			}
+		}
+	}
+	// Group A has mean 5 and squared deviations summing to 32 over 8 values;
+	// a single value has no spread, and an all-NA group has no variance
+	check("value_stdp", []float64{2, 0, math.NaN()})
+	check("value_varp", []float64{4, 0, math.NaN()})
+	check("value_var", []float64{32.0 / 7, math.NaN(), math.NaN()})
+}
+
+func TestGroupByAggNamed(t *testing.T) {
+	df, err := dataframe.FromRecords([][]interface{}{
+		{"A", 10.0, int64(1)},
+		{"A", 20.0, int64(2)},
+		{"B", 30.0, int64(3)},
+	}, []string{"group", "value", "qty"})
+	if err != nil {
+		t.Fatalf("Failed to create DataFrame: %v", err)
+	}
+	gb, err := df.GroupBy("group")
+	if err != nil {
+		t.Fatalf("Failed to create GroupBy: %v", err)
+	}
+
+	spread := func(s *dataframe.Series) interface{} {
+		return s.Max().(float64) - s.Min().(float64)
+	}
+	result, err := gb.AggNamed(
+		dataframe.NamedAgg{Column: "value", Func: dataframe.AggMean},
+		dataframe.NamedAgg{Column: "qty", Func: dataframe.AggSum, Name: "total_qty"},
+		dataframe.NamedAgg{Column: "value", Func: spread, Name: "value_spread"},
+	)
+	if err != nil {
+		t.Fatalf("AggNamed failed: %v", err)
+	}
+	want := []string{"group", "value_mean", "total_qty", "value_spread"}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	group, _ := result.At(0, "group")
+	mean, _ := result.At(0, "value_mean")
+	spreadVal, _ := result.At(0, "value_spread")
+	if group != "A" || mean != 15.0 || spreadVal != 10.0 {
+		t.Errorf("Unexpected first row: %v %v %v", group, mean, spreadVal)
+	}
+	if v, _ := result.At(1, "total_qty"); v != 3.0 {
+		t.Errorf("Expected total_qty 3 for B, got %v", v)
+	}
+
+	if _, err := gb.AggNamed(dataframe.NamedAgg{Column: "value", Func: spread}); err == nil {
+		t.Error("Expected error for custom function without a name")
+	}
+	if _, err := gb.AggNamed(
+		dataframe.NamedAgg{Column: "value", Func: dataframe.AggSum},
+		dataframe.NamedAgg{Column: "qty", Func: dataframe.AggSum, Name: "value_sum"},
+	); err == nil {
+		t.Error("Expected error for duplicate result column")
+	}
+	if _, err := gb.AggNamed(dataframe.NamedAgg{Column: "missing", Func: dataframe.AggSum}); err == nil {
+		t.Error("Expected error for missing column")
+	}
 }
 
 func TestGroupByFilter(t *testing.T) {
@@ -184,3 +281,1047 @@ func TestConcat(t *testing.T) {
 		t.Errorf("Expected 2 columns, got %d", result.Shape()[1])
 	}
 }
+
+func TestGroupBySumSkipsNonNumeric(t *testing.T) {
+	data := map[string][]interface{}{
+		"group": {"A", "A", "B"},
+		"label": {"x", "y", "z"},
+		"value": {1.0, 2.0, 3.0},
+	}
+	df, _ := dataframe.New(data)
+	gb, _ := df.GroupBy("group")
+
+	sumDF := gb.Sum()
+	if sumDF == nil {
+		t.Fatal("Sum returned nil")
+	}
+	if _, ok := sumDF.GetSeries("label_sum"); ok {
+		t.Errorf("Sum should skip non-numeric column 'label'")
+	}
+	if _, ok := sumDF.GetSeries("value_sum"); !ok {
+		t.Errorf("Sum missing column 'value_sum'")
+	}
+}
+
+func TestGroupByTypedKeys(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1, 10}, {"1", 20}, {1, 30}}, []string{"key", "value"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	if gb.NGroups() != 2 {
+		t.Errorf("Expected 1 and \"1\" to form 2 groups, got %d", gb.NGroups())
+	}
+}
+
+func TestConcatPromotesDTypes(t *testing.T) {
+	when := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		a, b      []interface{}
+		wantDType dataframe.DType
+		want      []interface{}
+	}{
+		{"int64+float64", []interface{}{int64(1)}, []interface{}{2.5}, dataframe.DTypeFloat64, []interface{}{1.0, 2.5}},
+		{"float64+int64", []interface{}{2.5}, []interface{}{int64(1)}, dataframe.DTypeFloat64, []interface{}{2.5, 1.0}},
+		{"int64+bool", []interface{}{int64(3)}, []interface{}{true}, dataframe.DTypeInt64, []interface{}{int64(3), int64(1)}},
+		{"bool+float64", []interface{}{false}, []interface{}{0.5}, dataframe.DTypeFloat64, []interface{}{0.0, 0.5}},
+		{"string+int64", []interface{}{"a"}, []interface{}{int64(1)}, dataframe.DTypeObject, []interface{}{"a", int64(1)}},
+		{"datetime+string", []interface{}{when}, []interface{}{"x"}, dataframe.DTypeObject, []interface{}{when, "x"}},
+		{"nil+int64", []interface{}{nil}, []interface{}{int64(7)}, dataframe.DTypeInt64, []interface{}{nil, int64(7)}},
+		{"int64+nil", []interface{}{int64(7)}, []interface{}{nil}, dataframe.DTypeInt64, []interface{}{int64(7), nil}},
+		{"bool+bool", []interface{}{true}, []interface{}{false}, dataframe.DTypeBool, []interface{}{true, false}},
+	}
+	for _, tc := range cases {
+		df1, _ := dataframe.FromRecords([][]interface{}{tc.a}, []string{"v"})
+		df2, _ := dataframe.FromRecords([][]interface{}{tc.b}, []string{"v"})
+		v, _ := dataframe.Concat(df1, df2).GetSeries("v")
+		if v.DType() != tc.wantDType {
+			t.Errorf("%s: dtype %v, want %v", tc.name, v.DType(), tc.wantDType)
+		}
+		if got := v.Values(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: values %#v, want %#v", tc.name, got, tc.want)
+		}
+	}
+
+	// A column missing from one DataFrame keeps the dtype of the others
+	df1, _ := dataframe.FromRecords([][]interface{}{{int64(1), "x"}}, []string{"n", "s"})
+	df2, _ := dataframe.FromRecords([][]interface{}{{int64(2)}}, []string{"n"})
+	out, _ := dataframe.ConcatWith(dataframe.ConcatOptions{}, df1, df2)
+	if s, _ := out.GetSeries("s"); s.DType() != dataframe.DTypeString {
+		t.Errorf("Expected string column, got %v", s.DType())
+	}
+}
+
+func TestConcatWith(t *testing.T) {
+	df1, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"id", "name"})
+	df2, _ := dataframe.FromRecords([][]interface{}{{3, 30.0}}, []string{"id", "score"})
+
+	outer, err := dataframe.ConcatWith(dataframe.ConcatOptions{Keys: []string{"first", "second"}}, df1, df2)
+	if err != nil {
+		t.Fatalf("ConcatWith failed: %v", err)
+	}
+	if got := strings.Join(outer.Columns(), ","); got != "_key,id,name,score" {
+		t.Errorf("Expected columns _key,id,name,score, got %s", got)
+	}
+	score, _ := outer.GetSeries("score")
+	if got := score.Values(); !reflect.DeepEqual(got, []interface{}{nil, nil, 30.0}) {
+		t.Errorf("Expected nil-filled score, got %v", got)
+	}
+	if got := outer.Index().Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, 0}) {
+		t.Errorf("Expected source labels [0 1 0], got %v", got)
+	}
+	keys, _ := outer.GetSeries("_key")
+	if v, _ := keys.Get(2); v != "second" {
+		t.Errorf("Expected key 'second' for last row, got %v", v)
+	}
+
+	inner, err := dataframe.ConcatWith(dataframe.ConcatOptions{Join: "inner", IgnoreIndex: true}, df1, df2)
+	if err != nil {
+		t.Fatalf("Inner ConcatWith failed: %v", err)
+	}
+	if got := strings.Join(inner.Columns(), ","); got != "id" {
+		t.Errorf("Expected only id column, got %s", got)
+	}
+	if got := inner.Index().Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, 2}) {
+		t.Errorf("Expected RangeIndex, got %v", got)
+	}
+
+	side, err := dataframe.ConcatWith(dataframe.ConcatOptions{Axis: 1, Keys: []string{"l", "r"}}, df1, df1)
+	if err != nil {
+		t.Fatalf("Axis 1 ConcatWith failed: %v", err)
+	}
+	if got := strings.Join(side.Columns(), ","); got != "l_id,l_name,r_id,r_name" {
+		t.Errorf("Expected prefixed columns, got %s", got)
+	}
+	if _, err := dataframe.ConcatWith(dataframe.ConcatOptions{Axis: 1}, df1, df2); err == nil {
+		t.Error("Expected row count mismatch error")
+	}
+	if _, err := dataframe.ConcatWith(dataframe.ConcatOptions{Axis: 1}, df1, df1); err == nil {
+		t.Error("Expected duplicate column error")
+	}
+}
+
+func TestGroupByMissingColumns(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{"a", 1}, {"a", 2}, {"b", 3}}, []string{"key", "v"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	// Convenience aggregations skip missing columns
+	sum := gb.Sum("v", "missing")
+	if _, ok := sum.GetSeries("missing"); ok {
+		t.Error("Expected missing column to be skipped by Sum")
+	}
+	if sum.Shape()[0] != 2 {
+		t.Errorf("Expected 2 groups, got %d", sum.Shape()[0])
+	}
+
+	// Agg reports them
+	if _, err := gb.Agg(map[string][]dataframe.AggFunc{"missing": {dataframe.AggSum}}); err == nil {
+		t.Error("Expected Agg error for missing column")
+	}
+}
+
+func TestGroupByMedianQuantileNUnique(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1.0, "x"},
+		{"a", nil, "y"},
+		{"a", 3.0, "x"},
+		{"a", 10.0, nil},
+		{"b", 5.0, "z"},
+		{"b", nil, nil},
+	}, []string{"key", "v", "tag"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	median := gb.Median()
+	if _, ok := median.GetSeries("tag_median"); ok {
+		t.Error("Median should skip non-numeric column 'tag'")
+	}
+	medians, _ := median.GetSeries("v_median")
+	if got := medians.Values(); !reflect.DeepEqual(got, []interface{}{3.0, 5.0}) {
+		t.Errorf("Expected medians [3 5], got %v", got)
+	}
+
+	nunique, _ := gb.NUnique().GetSeries("tag_nunique")
+	if got := nunique.Values(); !reflect.DeepEqual(got, []interface{}{2, 1}) {
+		t.Errorf("Expected tag nunique [2 1], got %v", got)
+	}
+
+	for _, workers := range []int{1, 2} {
+		result, err := gb.ParallelAgg(map[string][]dataframe.AggFunc{
+			"v": {dataframe.AggMedian, dataframe.AggQuantile(0.25), dataframe.AggNUnique},
+		}, dataframe.ParallelOptions{NumWorkers: workers})
+		if err != nil {
+			t.Fatalf("ParallelAgg failed: %v", err)
+		}
+		want := []string{"key", "v_median", "v_1", "v_nunique"}
+		if cols := result.Columns(); !reflect.DeepEqual(cols, want) {
+			t.Fatalf("Expected columns %v, got %v", want, cols)
+		}
+		q, _ := result.GetSeries("v_1")
+		if got := q.Values(); !reflect.DeepEqual(got, []interface{}{2.0, 5.0}) {
+			t.Errorf("Expected 25%% quantiles [2 5], got %v", got)
+		}
+		n, _ := result.GetSeries("v_nunique")
+		if got := n.Values(); !reflect.DeepEqual(got, []interface{}{3, 1}) {
+			t.Errorf("Expected v nunique [3 1], got %v", got)
+		}
+	}
+}
+
+func TestGroupByTransforms(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", int64(1)},
+		{"b", int64(10)},
+		{"a", int64(3)},
+		{"b", nil},
+		{"a", int64(2)},
+		{"b", int64(30)},
+	}, []string{"key", "v"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	cumsum, err := gb.CumSum("v")
+	if err != nil {
+		t.Fatalf("CumSum failed: %v", err)
+	}
+	want := []interface{}{int64(1), int64(10), int64(4), nil, int64(6), int64(40)}
+	if got := cumsum.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected cumsum %v, got %v", want, got)
+	}
+
+	ranks, err := gb.RankWithin("v", "min")
+	if err != nil {
+		t.Fatalf("RankWithin failed: %v", err)
+	}
+	want = []interface{}{1.0, 1.0, 3.0, nil, 2.0, 2.0}
+	if got := ranks.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected ranks %v, got %v", want, got)
+	}
+	if _, err := gb.RankWithin("v", "bogus"); err == nil {
+		t.Error("Expected error for unknown rank method")
+	}
+
+	z, err := gb.ZScore("v")
+	if err != nil {
+		t.Fatalf("ZScore failed: %v", err)
+	}
+	want = []interface{}{-1.0, -math.Sqrt(0.5), 1.0, nil, 0.0, math.Sqrt(0.5)}
+	for i, w := range want {
+		got, _ := z.Get(i)
+		if w == nil {
+			if got != nil {
+				t.Errorf("Expected nil z-score at %d, got %v", i, got)
+			}
+		} else if math.Abs(got.(float64)-w.(float64)) > 1e-9 {
+			t.Errorf("Expected z-score %v at %d, got %v", w, i, got)
+		}
+	}
+
+	// Transform writes values back in group row order and rejects
+	// results of the wrong length
+	reversed, err := gb.Transform("v", func(s *dataframe.Series) *dataframe.Series {
+		values := s.Values()
+		out := make([]interface{}, len(values))
+		for i, v := range values {
+			out[len(values)-1-i] = v
+		}
+		return dataframe.NewSeries(out, s.Name())
+	})
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	want = []interface{}{int64(2), int64(30), int64(3), nil, int64(1), int64(10)}
+	if got := reversed.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected reversed groups %v, got %v", want, got)
+	}
+	if _, err := gb.Transform("v", func(s *dataframe.Series) *dataframe.Series { return s.Head(1) }); err == nil {
+		t.Error("Expected error when transform changes the group length")
+	}
+}
+
+func TestGroupByNthHeadTail(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"u1", int64(1)},
+		{"u2", int64(2)},
+		{"u1", int64(3)},
+		{"u1", int64(4)},
+		{"u3", int64(5)},
+		{"u2", int64(6)},
+	}, []string{"user", "event"})
+	gb, err := df.GroupBy("user")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	labels := func(result *dataframe.DataFrame) []interface{} {
+		var out []interface{}
+		for i := 0; i < result.Index().Len(); i++ {
+			label, _ := result.Index().Get(i)
+			out = append(out, label)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		result *dataframe.DataFrame
+		want   []interface{}
+	}{
+		{"Nth(1)", gb.Nth(1), []interface{}{2, 5}},
+		{"Nth(-1)", gb.Nth(-1), []interface{}{3, 4, 5}},
+		{"Nth(5)", gb.Nth(5), nil},
+		{"HeadN(2)", gb.HeadN(2), []interface{}{0, 1, 2, 4, 5}},
+		{"TailN(2)", gb.TailN(2), []interface{}{1, 2, 3, 4, 5}},
+		{"TailN(0)", gb.TailN(0), nil},
+	}
+	for _, tt := range tests {
+		if got := labels(tt.result); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected rows %v, got %v", tt.name, tt.want, got)
+		}
+		if cols := tt.result.Columns(); !reflect.DeepEqual(cols, []string{"user", "event"}) {
+			t.Errorf("%s: expected all columns, got %v", tt.name, cols)
+		}
+	}
+
+	events, _ := gb.HeadN(1).GetSeries("event")
+	if got := events.Values(); !reflect.DeepEqual(got, []interface{}{int64(1), int64(2), int64(5)}) {
+		t.Errorf("Expected first events [1 2 5], got %v", got)
+	}
+}
+
+func TestGroupByGetGroupAndIteration(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"A", 1, 10.0},
+		{65, 1, 20.0},
+		{"A", 2, 30.0},
+		{"A", 1, 40.0},
+	}, []string{"key", "n", "v"})
+	gb, err := df.GroupBy("key", "n")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	wantKeys := [][]interface{}{{"A", 1}, {65, 1}, {"A", 2}}
+	if keys := gb.Keys(); !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("Expected keys %v, got %v", wantKeys, keys)
+	}
+
+	group, err := gb.GetGroup("A", int64(1))
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	values, _ := group.GetSeries("v")
+	if got := values.Values(); !reflect.DeepEqual(got, []interface{}{10.0, 40.0}) {
+		t.Errorf("Expected group values [10 40], got %v", got)
+	}
+	if label, _ := group.Index().Get(1); label != 3 {
+		t.Errorf("Expected original label 3, got %v", label)
+	}
+	if _, err := gb.GetGroup(65, 2); err == nil {
+		t.Error("Expected error for missing group")
+	}
+	if _, err := gb.GetGroup("A"); err == nil {
+		t.Error("Expected error for wrong number of key values")
+	}
+
+	var sizes []int
+	err = gb.ForEach(func(key []interface{}, group *dataframe.DataFrame) error {
+		sizes = append(sizes, group.Shape()[0])
+		if key[0] == 65 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("Expected ForEach to return the callback error, got %v", err)
+	}
+	if !reflect.DeepEqual(sizes, []int{2, 1}) {
+		t.Errorf("Expected ForEach to stop after 2 groups, got sizes %v", sizes)
+	}
+}
+
+func TestGroupByFuncAndSeries(t *testing.T) {
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{jan, 10.0},
+		{feb, 20.0},
+		{jan.AddDate(0, 0, 5), 30.0},
+	}, []string{"ts", "amount"})
+
+	gb, err := df.GroupByFunc("month", func(r dataframe.Row) interface{} {
+		ts, _ := r.GetTime("ts")
+		return ts.Format("2006-01")
+	})
+	if err != nil {
+		t.Fatalf("GroupByFunc failed: %v", err)
+	}
+	sums := gb.Sum("amount")
+	if cols := sums.Columns(); !reflect.DeepEqual(cols, []string{"month", "amount_sum"}) {
+		t.Errorf("Expected columns [month amount_sum], got %v", cols)
+	}
+	months, _ := sums.GetSeries("month")
+	totals, _ := sums.GetSeries("amount_sum")
+	if !reflect.DeepEqual(months.Values(), []interface{}{"2024-01", "2024-02"}) ||
+		!reflect.DeepEqual(totals.Values(), []interface{}{40.0, 20.0}) {
+		t.Errorf("Unexpected monthly sums: %v %v", months.Values(), totals.Values())
+	}
+
+	result, err := gb.Agg(map[string][]dataframe.AggFunc{"amount": {dataframe.AggMax}})
+	if err != nil {
+		t.Fatalf("Agg failed: %v", err)
+	}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, []string{"month", "amount_max"}) {
+		t.Errorf("Expected columns [month amount_max], got %v", cols)
+	}
+	if _, err := gb.ParallelAgg(map[string][]dataframe.AggFunc{"amount": {dataframe.AggSum}}); err != nil {
+		t.Errorf("ParallelAgg failed: %v", err)
+	}
+	if size := gb.Size(); size.Shape()[0] != 2 {
+		t.Errorf("Expected 2 groups in Size, got %d", size.Shape()[0])
+	}
+	cumsum, err := gb.CumSum("amount")
+	if err != nil || !reflect.DeepEqual(cumsum.Values(), []interface{}{10.0, 20.0, 40.0}) {
+		t.Errorf("Unexpected CumSum result %v, %v", cumsum, err)
+	}
+
+	region := dataframe.NewSeries([]interface{}{"east", "west", "east"}, "region")
+	gb, err = df.GroupBySeries(region)
+	if err != nil {
+		t.Fatalf("GroupBySeries failed: %v", err)
+	}
+	filtered := gb.Filter(func(group *dataframe.DataFrame) bool { return group.Shape()[0] > 1 })
+	if filtered.Shape()[0] != 2 {
+		t.Errorf("Expected 2 rows in the east group, got %d", filtered.Shape()[0])
+	}
+	if _, ok := filtered.GetSeries("region"); ok {
+		t.Error("Filter should return only the columns of the DataFrame")
+	}
+	means := gb.Mean()
+	if cols := means.Columns(); cols[0] != "region" {
+		t.Errorf("Expected region key column first, got %v", cols)
+	}
+
+	if _, err := df.GroupBySeries(dataframe.NewSeries([]interface{}{"x"}, "short")); err == nil {
+		t.Error("Expected error for key series length mismatch")
+	}
+	if _, err := df.GroupBySeries(region, region); err == nil {
+		t.Error("Expected error for duplicate key names")
+	}
+}
+
+func TestGroupByWithOptions(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"b", 1.0},
+		{nil, 2.0},
+		{"a", 3.0},
+		{"b", 4.0},
+		{nil, 5.0},
+	}, []string{"key", "v"})
+
+	keyValues := func(result *dataframe.DataFrame) []interface{} {
+		s, _ := result.GetSeries("key")
+		return s.Values()
+	}
+
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	if got := keyValues(gb.Sum()); !reflect.DeepEqual(got, []interface{}{"b", "a"}) {
+		t.Errorf("Expected NA keys dropped by default, got %v", got)
+	}
+
+	gb, err = df.GroupByWith(dataframe.GroupByOptions{DropNA: false, Sort: true}, "key")
+	if err != nil {
+		t.Fatalf("GroupByWith failed: %v", err)
+	}
+	want := []interface{}{"a", "b", nil}
+	if got := keyValues(gb.Sum()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected sorted keys with NA last %v, got %v", want, got)
+	}
+	sums, _ := gb.Sum().GetSeries("v_sum")
+	if got := sums.Values(); !reflect.DeepEqual(got, []interface{}{3.0, 5.0, 7.0}) {
+		t.Errorf("Expected sums [3 5 7], got %v", got)
+	}
+	if got := keyValues(gb.Size()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected Size keys %v, got %v", want, got)
+	}
+	agg, err := gb.Agg(map[string][]dataframe.AggFunc{"v": {dataframe.AggCount}})
+	if err != nil {
+		t.Fatalf("Agg failed: %v", err)
+	}
+	if got := keyValues(agg); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected Agg keys %v, got %v", want, got)
+	}
+	if _, err := gb.GetGroup(nil); err != nil {
+		t.Errorf("Expected NA group to be found: %v", err)
+	}
+
+	gb, _ = df.GroupByWith(dataframe.GroupByOptions{DropNA: true, Sort: true}, "key")
+	if got := keyValues(gb.Count()); !reflect.DeepEqual(got, []interface{}{"a", "b"}) {
+		t.Errorf("Expected sorted keys without NA, got %v", got)
+	}
+}
+
+func TestGroupByApplyWithKeys(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"east", "A", 10.0},
+		{"east", "B", 20.0},
+		{"west", "A", 30.0},
+		{"east", "A", 40.0},
+	}, []string{"region", "product", "sales"})
+	gb, err := df.GroupBy("region", "product")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	result := gb.ApplyWithKeys(func(group *dataframe.DataFrame) *dataframe.DataFrame {
+		sales, _ := group.GetSeries("sales")
+		if sales.Len() < 2 {
+			if sales.Sum() > 25 {
+				return nil
+			}
+			out, _ := dataframe.FromRecords([][]interface{}{{sales.Sum()}}, []string{"total"})
+			return out
+		}
+		out, _ := dataframe.FromRecords([][]interface{}{{sales.Sum(), sales.Max()}, {sales.Mean(), nil}}, []string{"total", "extra"})
+		return out
+	})
+
+	want := []string{"region", "product", "total", "extra"}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	column := func(name string) []interface{} {
+		s, _ := result.GetSeries(name)
+		return s.Values()
+	}
+	if got := column("region"); !reflect.DeepEqual(got, []interface{}{"east", "east", "east"}) {
+		t.Errorf("Unexpected region keys %v", got)
+	}
+	if got := column("product"); !reflect.DeepEqual(got, []interface{}{"A", "A", "B"}) {
+		t.Errorf("Unexpected product keys %v", got)
+	}
+	if got := column("total"); !reflect.DeepEqual(got, []interface{}{50.0, 25.0, 20.0}) {
+		t.Errorf("Unexpected totals %v", got)
+	}
+	if got := column("extra"); !reflect.DeepEqual(got, []interface{}{40.0, nil, nil}) {
+		t.Errorf("Expected extra column filled with nil, got %v", got)
+	}
+
+	// Key columns in the callback result are replaced, not duplicated
+	heads := gb.ApplyWithKeys(func(group *dataframe.DataFrame) *dataframe.DataFrame {
+		return group.Head(1)
+	})
+	if cols := heads.Columns(); !reflect.DeepEqual(cols, []string{"region", "product", "sales"}) {
+		t.Errorf("Expected key columns once, got %v", cols)
+	}
+	if heads.Shape()[0] != 3 {
+		t.Errorf("Expected 3 rows, got %d", heads.Shape()[0])
+	}
+}
+
+func TestGroupByDescribeAndAggAll(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1.0, "x", int64(5)},
+		{"a", 3.0, "y", nil},
+		{"b", 2.0, "z", int64(7)},
+	}, []string{"key", "v", "label", "n"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	desc := gb.Describe()
+	want := []string{"key",
+		"v_count", "v_mean", "v_std", "v_min", "v_max",
+		"n_count", "n_mean", "n_std", "n_min", "n_max"}
+	if cols := desc.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	counts, _ := desc.GetSeries("n_count")
+	if got := counts.Values(); !reflect.DeepEqual(got, []interface{}{1, 1}) {
+		t.Errorf("Expected n counts [1 1], got %v", got)
+	}
+	if cols := gb.Describe("v").Columns(); len(cols) != 6 {
+		t.Errorf("Expected key and 5 stats for v, got %v", cols)
+	}
+
+	result, err := gb.AggAll(dataframe.AggMax, dataframe.AggSum)
+	if err != nil {
+		t.Fatalf("AggAll failed: %v", err)
+	}
+	want = []string{"key", "v_max", "v_sum", "n_max", "n_sum"}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	sums, _ := result.GetSeries("v_sum")
+	if got := sums.Values(); !reflect.DeepEqual(got, []interface{}{4.0, 2.0}) {
+		t.Errorf("Expected v sums [4 2], got %v", got)
+	}
+
+	custom := func(s *dataframe.Series) interface{} { return s.Len() }
+	if _, err := gb.AggAll(custom); err == nil {
+		t.Error("Expected error for unnamed aggregation function")
+	}
+}
+
+func TestGroupByIdxMaxAndMaxRows(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"u1", int64(30), "book"},
+		{"u2", nil, "pen"},
+		{"u1", int64(50), "lamp"},
+		{"u3", int64(5), "cup"},
+		{"u1", int64(50), "desk"},
+		{"u3", int64(5), "mug"},
+	}, []string{"user", "amount", "item"})
+	gb, err := df.GroupBy("user")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	idxMax, err := gb.IdxMax("amount")
+	if err != nil {
+		t.Fatalf("IdxMax failed: %v", err)
+	}
+	if got := idxMax.Values(); !reflect.DeepEqual(got, []interface{}{2, nil, 3}) {
+		t.Errorf("Expected IdxMax [2 <nil> 3], got %v", got)
+	}
+	idxMin, _ := gb.IdxMin("amount")
+	if got := idxMin.Values(); !reflect.DeepEqual(got, []interface{}{0, nil, 3}) {
+		t.Errorf("Expected IdxMin [0 <nil> 3], got %v", got)
+	}
+
+	rows, err := gb.MaxRows("amount")
+	if err != nil {
+		t.Fatalf("MaxRows failed: %v", err)
+	}
+	items, _ := rows.GetSeries("item")
+	if got := items.Values(); !reflect.DeepEqual(got, []interface{}{"lamp", "cup"}) {
+		t.Errorf("Expected max rows [lamp cup], got %v", got)
+	}
+	amounts, _ := rows.GetSeries("amount")
+	if amounts.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64 dtype to be kept, got %v", amounts.DType())
+	}
+
+	if _, err := gb.IdxMax("missing"); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestGroupByCumCountAndSizeBroadcast(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1.0},
+		{"b", 2.0},
+		{nil, 3.0},
+		{"a", 4.0},
+		{"a", 5.0},
+	}, []string{"key", "v"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	cumcount := gb.CumCount()
+	want := []interface{}{int64(0), int64(0), nil, int64(1), int64(2)}
+	if got := cumcount.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected cumcount %v, got %v", want, got)
+	}
+	sizes := gb.SizeBroadcast()
+	want = []interface{}{int64(3), int64(1), nil, int64(3), int64(3)}
+	if got := sizes.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected sizes %v, got %v", want, got)
+	}
+	if err := df.SetColumn("n", cumcount); err != nil {
+		t.Errorf("SetColumn failed: %v", err)
+	}
+
+	// Large frames take the parallel path
+	n := 200000
+	keys := make([]interface{}, n)
+	for i := range keys {
+		keys[i] = int64(i % 7)
+	}
+	large, _ := dataframe.New(map[string][]interface{}{"k": keys})
+	gb, _ = large.GroupBy("k")
+	cumcount = gb.CumCount()
+	sizes = gb.SizeBroadcast()
+	for _, i := range []int{0, 6, 7, 123456, n - 1} {
+		c, _ := cumcount.Get(i)
+		size, _ := sizes.Get(i)
+		wantSize := int64(n / 7)
+		if i%7 < n%7 {
+			wantSize++
+		}
+		if c != int64(i/7) || size != wantSize {
+			t.Errorf("Row %d: expected cumcount %d and size %d, got %v and %v", i, i/7, wantSize, c, size)
+		}
+	}
+}
+
+func TestGroupByAggDF(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 10.0, 1.0, 2.0},
+		{"a", 20.0, 3.0, nil},
+		{"b", 30.0, 1.0, 6.0},
+		{"b", nil, 5.0, 3.0},
+	}, []string{"key", "price", "qty", "cost"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	ratio := func(group *dataframe.DataFrame) interface{} {
+		price, _ := group.GetSeries("price")
+		cost, _ := group.GetSeries("cost")
+		return price.Sum() / cost.Sum()
+	}
+	result, err := gb.AggDF(map[string]dataframe.AggFuncDF{
+		"wavg_price": dataframe.AggWeightedMean("price", "qty"),
+		"margin":     ratio,
+	})
+	if err != nil {
+		t.Fatalf("AggDF failed: %v", err)
+	}
+	want := []string{"key", "margin", "wavg_price"}
+	if cols := result.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	wavg, _ := result.GetSeries("wavg_price")
+	if got := wavg.Values(); !reflect.DeepEqual(got, []interface{}{17.5, 30.0}) {
+		t.Errorf("Expected weighted means [17.5 30], got %v", got)
+	}
+	margin, _ := result.GetSeries("margin")
+	if got := margin.Values(); !reflect.DeepEqual(got, []interface{}{15.0, 30.0 / 9.0}) {
+		t.Errorf("Expected margins [15 3.33], got %v", got)
+	}
+
+	if _, err := gb.AggDF(map[string]dataframe.AggFuncDF{"key": ratio}); err == nil {
+		t.Error("Expected error for result column named like a key")
+	}
+}
+
+func TestGroupByUnstack(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"east", int64(2), 10.0},
+		{"west", int64(1), 20.0},
+		{"east", int64(1), 30.0},
+		{"east", int64(2), 40.0},
+		{"west", int64(10), 5.0},
+	}, []string{"region", "month", "sales"})
+	gb, err := df.GroupBy("region", "month")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	wide, err := gb.Unstack("month", "sales", 0.0)
+	if err != nil {
+		t.Fatalf("Unstack failed: %v", err)
+	}
+	want := []string{"region", "1", "2", "10"}
+	if cols := wide.Columns(); !reflect.DeepEqual(cols, want) {
+		t.Fatalf("Expected columns %v, got %v", want, cols)
+	}
+	expected := map[string][]interface{}{
+		"region": {"east", "west"},
+		"1":      {30.0, 20.0},
+		"2":      {50.0, 0.0},
+		"10":     {0.0, 5.0},
+	}
+	for col, values := range expected {
+		s, _ := wide.GetSeries(col)
+		if got := s.Values(); !reflect.DeepEqual(got, values) {
+			t.Errorf("Column %s: expected %v, got %v", col, values, got)
+		}
+	}
+
+	counts, err := gb.Unstack("region", "sales", nil, dataframe.AggCount)
+	if err != nil {
+		t.Fatalf("Unstack with AggCount failed: %v", err)
+	}
+	east, _ := counts.GetSeries("east")
+	if got := east.Values(); !reflect.DeepEqual(got, []interface{}{2, 1, nil}) {
+		t.Errorf("Expected east counts for months 2, 1, 10 of [2 1 <nil>], got %v", got)
+	}
+
+	if _, err := gb.Unstack("sales", "sales", nil); err == nil {
+		t.Error("Expected error for a level that is not a group key")
+	}
+	single, _ := df.GroupBy("region")
+	if _, err := single.Unstack("region", "sales", nil); err == nil {
+		t.Error("Expected error when no other group key remains")
+	}
+}
+
+func TestGroupByParallelMatchesGroupBy(t *testing.T) {
+	n := 20000
+	records := make([][]interface{}, n)
+	for i := range records {
+		var key interface{} = int64((i * 7919) % 1500)
+		switch {
+		case i%11 == 0:
+			key = float64(i % 40)
+		case i%13 == 0:
+			key = fmt.Sprint(i % 40)
+		case i%17 == 0:
+			key = nil
+		}
+		records[i] = []interface{}{key, i % 3, float64(i)}
+	}
+	df, _ := dataframe.FromRecords(records, []string{"k", "sub", "v"})
+
+	want, err := df.GroupBy("k", "sub")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	for _, workers := range []int{1, 2, 7, 64} {
+		got, err := df.GroupByParallel(dataframe.ParallelOptions{NumWorkers: workers}, "k", "sub")
+		if err != nil {
+			t.Fatalf("GroupByParallel failed: %v", err)
+		}
+		if !reflect.DeepEqual(got.Keys(), want.Keys()) {
+			t.Errorf("%d workers: group order differs from GroupBy", workers)
+		}
+		if !reflect.DeepEqual(got.Groups(), want.Groups()) {
+			t.Errorf("%d workers: group rows differ from GroupBy", workers)
+		}
+	}
+	if _, err := df.GroupByParallel(dataframe.DefaultParallelOptions(), "missing"); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestGroupBySample(t *testing.T) {
+	records := make([][]interface{}, 0, 30)
+	for i := 0; i < 30; i++ {
+		group := "big"
+		if i%10 == 0 {
+			group = "small"
+		}
+		records = append(records, []interface{}{group, i})
+	}
+	df, _ := dataframe.FromRecords(records, []string{"g", "i"})
+	gb, err := df.GroupBy("g")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	sample, err := gb.Sample(5, dataframe.SampleOptions{Seed: 42})
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	groups, _ := sample.GetSeries("g")
+	values, _ := sample.GetSeries("i")
+	counts := map[interface{}]int{}
+	prev := map[interface{}]int{}
+	for pos := 0; pos < sample.Shape()[0]; pos++ {
+		g, _ := groups.Get(pos)
+		v, _ := values.Get(pos)
+		label, _ := sample.Index().Get(pos)
+		if label != v {
+			t.Errorf("Expected original label %v, got %v", v, label)
+		}
+		if counts[g] > 0 && v.(int) <= prev[g] {
+			t.Errorf("Expected rows of group %v in original order", g)
+		}
+		counts[g]++
+		prev[g] = v.(int)
+		if pos > 0 {
+			if before, _ := groups.Get(pos - 1); before == "big" && g == "small" {
+				t.Error("Expected rows in group order")
+			}
+		}
+	}
+	if counts["big"] != 5 || counts["small"] != 3 {
+		t.Errorf("Expected all 3 small and 5 big rows, got %v", counts)
+	}
+
+	again, _ := gb.Sample(5, dataframe.SampleOptions{Seed: 42})
+	if !reflect.DeepEqual(again.Index(), sample.Index()) {
+		t.Error("Expected equal seeds to give equal samples")
+	}
+
+	frac, err := gb.Sample(0, dataframe.SampleOptions{Frac: 0.5, Seed: 1})
+	if err != nil {
+		t.Fatalf("Sample with Frac failed: %v", err)
+	}
+	if frac.Shape()[0] != 16 {
+		t.Errorf("Expected round(1.5) small and round(13.5) big rows, got %d rows", frac.Shape()[0])
+	}
+
+	if _, err := gb.Sample(5, dataframe.SampleOptions{Strict: true}); err == nil {
+		t.Error("Expected error for a group smaller than n in strict mode")
+	}
+	if _, err := gb.Sample(1, dataframe.SampleOptions{Frac: 1.5}); err == nil {
+		t.Error("Expected error for a fraction above 1")
+	}
+}
+
+func TestGroupByAggOptions(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1.0},
+		{"a", nil},
+		{"b", nil},
+		{"b", nil},
+		{"c", 2.0},
+		{"c", 3.0},
+	}, []string{"key", "v"})
+	gb, err := df.GroupBy("key")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	column := func(result *dataframe.DataFrame, name string) []interface{} {
+		s, _ := result.GetSeries(name)
+		return s.Values()
+	}
+
+	if got := column(gb.Sum(), "v_sum"); !reflect.DeepEqual(got, []interface{}{1.0, 0.0, 5.0}) {
+		t.Errorf("Expected plain sums [1 0 5], got %v", got)
+	}
+	if got := column(gb.SumOpt(dataframe.AggOptions{MinCount: 1}), "v_sum"); !reflect.DeepEqual(got, []interface{}{1.0, nil, 5.0}) {
+		t.Errorf("Expected MinCount 1 sums [1 <nil> 5], got %v", got)
+	}
+	if got := column(gb.SumOpt(dataframe.AggOptions{MinCount: 2}), "v_sum"); !reflect.DeepEqual(got, []interface{}{nil, nil, 5.0}) {
+		t.Errorf("Expected MinCount 2 sums [<nil> <nil> 5], got %v", got)
+	}
+	if got := column(gb.MeanOpt(dataframe.AggOptions{PropagateNA: true}), "v_mean"); !reflect.DeepEqual(got, []interface{}{nil, nil, 2.5}) {
+		t.Errorf("Expected NA-poisoned means [<nil> <nil> 2.5], got %v", got)
+	}
+	if got := column(gb.MaxOpt(dataframe.DefaultAggOptions()), "v_max"); !reflect.DeepEqual(got, column(gb.Max(), "v_max")) {
+		t.Errorf("Expected default options to match Max, got %v", got)
+	}
+
+	result, err := gb.AggNamed(dataframe.NamedAgg{
+		Column: "v",
+		Func:   dataframe.WithAggOptions(dataframe.AggSum, dataframe.AggOptions{MinCount: 1}),
+		Name:   "total",
+	})
+	if err != nil {
+		t.Fatalf("AggNamed failed: %v", err)
+	}
+	if got := column(result, "total"); !reflect.DeepEqual(got, []interface{}{1.0, nil, 5.0}) {
+		t.Errorf("Expected wrapped sums [1 <nil> 5], got %v", got)
+	}
+
+	sums := df.ParallelSumOpt(dataframe.AggOptions{PropagateNA: true})
+	if !math.IsNaN(sums["v"]) {
+		t.Errorf("Expected NaN sum for a column with NA values, got %v", sums["v"])
+	}
+	means := df.ParallelMeanOpt(dataframe.AggOptions{MinCount: 3})
+	if means["v"] != 2.0 {
+		t.Errorf("Expected mean 2 with 3 values, got %v", means["v"])
+	}
+	means = df.ParallelMeanOpt(dataframe.AggOptions{MinCount: 4})
+	if !math.IsNaN(means["v"]) {
+		t.Errorf("Expected NaN mean below MinCount, got %v", means["v"])
+	}
+}
+
+// wideKeyFrame returns a frame of 100000 rows with eight mixed-type key columns.
+func wideKeyFrame() (*dataframe.DataFrame, []string) {
+	n := 100000
+	data := make(map[string][]interface{})
+	var keys []string
+	for c := 0; c < 8; c++ {
+		name := fmt.Sprintf("k%d", c)
+		keys = append(keys, name)
+		values := make([]interface{}, n)
+		for i := range values {
+			switch c % 3 {
+			case 0:
+				values[i] = int64(i % (c + 7))
+			case 1:
+				values[i] = fmt.Sprintf("key-%d", i%(c+5))
+			default:
+				values[i] = float64(i%(c+3)) / 2
+			}
+		}
+		data[name] = values
+	}
+	df, _ := dataframe.New(data)
+	return df, keys
+}
+
+func BenchmarkHashRowsWideKey(b *testing.B) {
+	df, keys := wideKeyFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dataframe.HashRows(df, keys)
+	}
+}
+
+// BenchmarkStringKeysWideKey builds the fmt-based string keys that HashRows
+// replaces, for comparison.
+func BenchmarkStringKeysWideKey(b *testing.B) {
+	df, keys := wideKeyFrame()
+	cols := make([]*dataframe.Series, len(keys))
+	for j, k := range keys {
+		cols[j], _ = df.GetSeries(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := make([]string, df.Shape()[0])
+		for r := range out {
+			var sb strings.Builder
+			for j, s := range cols {
+				if j > 0 {
+					sb.WriteByte(0)
+				}
+				v, _ := s.Get(r)
+				fmt.Fprintf(&sb, "%T:%v", v, v)
+			}
+			out[r] = sb.String()
+		}
+	}
+}
+
+func BenchmarkGroupByWideKey(b *testing.B) {
+	df, keys := wideKeyFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := df.GroupBy(keys...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largeGroupFrame returns a frame of 10M rows with 100k int64 groups.
+func largeGroupFrame() *dataframe.DataFrame {
+	n := 10000000
+	keys := make([]interface{}, n)
+	for i := range keys {
+		keys[i] = int64((i * 7919) % 100000)
+	}
+	df, _ := dataframe.New(map[string][]interface{}{"k": keys})
+	return df
+}
+
+func BenchmarkGroupByLarge(b *testing.B) {
+	df := largeGroupFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := df.GroupBy("k"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGroupByParallelLarge(b *testing.B) {
+	df := largeGroupFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := df.GroupByParallel(dataframe.DefaultParallelOptions(), "k"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
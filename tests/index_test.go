@@ -0,0 +1,633 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BAIGUANGMEI/datago/dataframe"
+)
+
+func TestIndexGetLoc(t *testing.T) {
+	idx := dataframe.NewIndex([]interface{}{"a", "b", "a", "c"}, "key")
+
+	if pos, err := idx.GetLoc("a"); err != nil || pos != 0 {
+		t.Errorf("Expected first position 0 for duplicate label, got %d (%v)", pos, err)
+	}
+	if pos, err := idx.GetLoc("c"); err != nil || pos != 3 {
+		t.Errorf("Expected position 3, got %d (%v)", pos, err)
+	}
+	if _, err := idx.GetLoc("z"); err == nil {
+		t.Error("Expected error for missing label")
+	}
+	if got := idx.GetLocs("a"); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("Expected positions [0 2], got %v", got)
+	}
+	if got := idx.GetLocs("z"); got != nil {
+		t.Errorf("Expected nil positions for missing label, got %v", got)
+	}
+
+	unique := dataframe.NewRangeIndex(3)
+	if got := unique.GetLocs(2); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("Expected positions [2], got %v", got)
+	}
+
+	// Unhashable labels fall back to a scan instead of panicking
+	mixed := dataframe.NewIndex([]interface{}{[]int{1}, "x"}, "")
+	if pos, err := mixed.GetLoc("x"); err != nil || pos != 1 {
+		t.Errorf("Expected position 1 in index with unhashable labels, got %d (%v)", pos, err)
+	}
+	if _, err := mixed.GetLoc([]int{1}); err == nil {
+		t.Error("Expected unhashable label lookup to fail")
+	}
+}
+
+func TestIndexLookupAfterDerivation(t *testing.T) {
+	idx := dataframe.NewIndex([]interface{}{"a", "b", "c"}, "")
+	if _, err := idx.GetLoc("c"); err != nil {
+		t.Fatalf("GetLoc failed: %v", err)
+	}
+
+	appended := idx.Append("d")
+	if pos, err := appended.GetLoc("d"); err != nil || pos != 3 {
+		t.Errorf("Expected appended label at 3, got %d (%v)", pos, err)
+	}
+	if idx.Contains("d") {
+		t.Error("Append must not change the original index")
+	}
+
+	sliced := idx.Slice(1, 3)
+	if pos, err := sliced.GetLoc("c"); err != nil || pos != 1 {
+		t.Errorf("Expected sliced label at 1, got %d (%v)", pos, err)
+	}
+	if sliced.Contains("a") {
+		t.Error("Sliced index should not contain 'a'")
+	}
+
+	copied := idx.Copy()
+	if pos, err := copied.GetLoc("b"); err != nil || pos != 1 {
+		t.Errorf("Expected copied label at 1, got %d (%v)", pos, err)
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{{1.0}, {2.0}, {3.0}}, []string{"v"})
+	df.Index().GetLoc(2)
+	tail := df.Tail(2)
+	if v, err := tail.At(2, "v"); err != nil || v != 3.0 {
+		t.Errorf("Expected At(2) = 3 after Tail, got %v (%v)", v, err)
+	}
+	if _, err := tail.At(0, "v"); err == nil {
+		t.Error("Expected label 0 to be gone after Tail")
+	}
+}
+
+func TestNewDatetimeIndex(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	monthly, err := dataframe.NewDatetimeIndex(start, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), "1M", "date")
+	if err != nil {
+		t.Fatalf("NewDatetimeIndex failed: %v", err)
+	}
+	var days []string
+	for _, l := range monthly.Labels() {
+		days = append(days, l.(time.Time).Format("01-02"))
+	}
+	if want := []string{"01-31", "02-29", "03-31", "04-30", "05-31"}; !reflect.DeepEqual(days, want) {
+		t.Errorf("Expected month ends %v, got %v", want, days)
+	}
+	if monthly.Name() != "date" {
+		t.Errorf("Expected name 'date', got %q", monthly.Name())
+	}
+
+	hourly, _ := dataframe.NewDatetimeIndex(start, start.Add(5*time.Hour+30*time.Minute), "2h", "")
+	if hourly.Len() != 3 {
+		t.Errorf("Expected 3 two-hour steps, got %d", hourly.Len())
+	}
+	weekly, _ := dataframe.NewDatetimeIndex(start, start.AddDate(0, 0, 14), "1w", "")
+	if weekly.Len() != 3 {
+		t.Errorf("Expected 3 weekly steps including the end, got %d", weekly.Len())
+	}
+	daily, _ := dataframe.NewDatetimeIndex(start, start.AddDate(0, 0, 9), "1d", "")
+	if daily.Len() != 10 {
+		t.Errorf("Expected 10 days, got %d", daily.Len())
+	}
+
+	// Time labels are found by instant through the hash map
+	local := start.AddDate(0, 0, 3).In(time.FixedZone("UTC+8", 8*3600))
+	if pos, err := daily.GetLoc(local); err != nil || pos != 3 {
+		t.Errorf("Expected equal instant at position 3, got %d (%v)", pos, err)
+	}
+
+	if min, max := daily.Min(), daily.Max(); min != start || !max.(time.Time).Equal(start.AddDate(0, 0, 9)) {
+		t.Errorf("Unexpected Min/Max %v %v", min, max)
+	}
+	shifted, err := daily.Shift(12 * time.Hour)
+	if err != nil {
+		t.Fatalf("Shift failed: %v", err)
+	}
+	if first, _ := shifted.Get(0); !first.(time.Time).Equal(start.Add(12 * time.Hour)) {
+		t.Errorf("Expected shifted first label, got %v", first)
+	}
+	if _, err := dataframe.NewRangeIndex(2).Shift(time.Hour); err == nil {
+		t.Error("Expected error shifting non-time labels")
+	}
+
+	for _, freq := range []string{"", "1y", "0d", "-1d", "d1"} {
+		if _, err := dataframe.NewDatetimeIndex(start, start, freq, ""); err == nil {
+			t.Errorf("Expected error for frequency %q", freq)
+		}
+	}
+}
+
+func TestIndexMonotonicAndUnique(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		labels     []interface{}
+		increasing bool
+		decreasing bool
+		unique     bool
+	}{
+		{"ints", []interface{}{1, int64(2), 2.5, 3}, true, false, true},
+		{"ties", []interface{}{3, 3, 1}, false, true, false},
+		{"strings", []interface{}{"10", "9"}, true, false, true},
+		{"times", []interface{}{day, day.Add(time.Hour)}, true, false, true},
+		{"mixed", []interface{}{1, "2"}, false, false, true},
+		{"nil", []interface{}{1, nil, 3}, false, false, true},
+		{"empty", []interface{}{}, true, true, true},
+		{"int kinds", []interface{}{1, int64(1)}, true, true, false},
+	}
+	for _, c := range cases {
+		idx := dataframe.NewIndex(c.labels, "")
+		if got := idx.IsMonotonicIncreasing(); got != c.increasing {
+			t.Errorf("%s: IsMonotonicIncreasing = %v, want %v", c.name, got, c.increasing)
+		}
+		if got := idx.IsMonotonicDecreasing(); got != c.decreasing {
+			t.Errorf("%s: IsMonotonicDecreasing = %v, want %v", c.name, got, c.decreasing)
+		}
+		if got := idx.IsUnique(); got != c.unique {
+			t.Errorf("%s: IsUnique = %v, want %v", c.name, got, c.unique)
+		}
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{{1.0}, {2.0}}, []string{"v"})
+	if !df.IndexIsUnique() {
+		t.Error("Expected range index to be unique")
+	}
+	stacked, _ := dataframe.ConcatWith(dataframe.ConcatOptions{}, df, df)
+	if stacked.IndexIsUnique() {
+		t.Error("Expected labels repeated by ConcatWith to be reported")
+	}
+}
+
+func TestIndexDuplicated(t *testing.T) {
+	idx := dataframe.NewIndex([]interface{}{"a", "b", "a", nil, "c", nil, "a"}, "")
+
+	expected := map[string][]bool{
+		"first": {false, false, true, false, false, true, true},
+		"":      {false, false, true, false, false, true, true},
+		"last":  {true, false, true, true, false, false, false},
+		"none":  {true, false, true, true, false, true, true},
+	}
+	for keep, want := range expected {
+		if got := idx.Duplicated(keep); !reflect.DeepEqual(got, want) {
+			t.Errorf("Duplicated(%q) = %v, want %v", keep, got, want)
+		}
+	}
+}
+
+func TestIndexIntegerLabelEquality(t *testing.T) {
+	ints := dataframe.NewIndex([]interface{}{1, 2, 3}, "")
+	int64s := dataframe.NewIndex([]interface{}{int64(1), int64(2), int64(3)}, "")
+
+	if !ints.Equals(int64s) || !int64s.Equals(ints) {
+		t.Error("Expected int and int64 indexes with equal values to be equal")
+	}
+	if ints.Equals(dataframe.NewIndex([]interface{}{1.0, 2.0, 3.0}, "")) {
+		t.Error("Float labels must not equal integer labels")
+	}
+	if pos, err := ints.GetLoc(int64(2)); err != nil || pos != 1 {
+		t.Errorf("Expected int64(2) at position 1, got %d (%v)", pos, err)
+	}
+	if !int64s.Contains(uint8(3)) || int64s.Contains("3") {
+		t.Error("Contains should match integer kinds but not strings")
+	}
+
+	other := dataframe.NewIndex([]interface{}{int64(3), int64(4)}, "")
+	if got := ints.Union(other).Labels(); !reflect.DeepEqual(got, []interface{}{1, 2, 3, int64(4)}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+	if got := ints.Intersection(other).Labels(); !reflect.DeepEqual(got, []interface{}{3}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if got := ints.Difference(other).Labels(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+	if dataframe.NewIndex([]interface{}{1, int64(1)}, "").IsUnique() {
+		t.Error("Expected int and int64 labels of equal value to be duplicates")
+	}
+
+	// CSV parsing yields int64 values, which must address the int range index
+	df, _ := dataframe.FromRecords([][]interface{}{{"a"}, {"b"}}, []string{"v"})
+	if v, err := df.At(int64(1), "v"); err != nil || v != "b" {
+		t.Errorf("Expected At(int64(1)) = b, got %v (%v)", v, err)
+	}
+	if rows := df.Loc([]interface{}{int64(0), int32(1)}, nil); rows.Shape()[0] != 2 {
+		t.Errorf("Expected Loc to find both rows, got %d", rows.Shape()[0])
+	}
+	if _, err := df.SelectRowsByLabels([]interface{}{int64(1)}); err != nil {
+		t.Errorf("SelectRowsByLabels failed: %v", err)
+	}
+
+	s := dataframe.NewSeriesWithIndex([]interface{}{10, 20}, "s", int64s.Slice(0, 2))
+	if v, err := s.At(2); err != nil || v != 20 {
+		t.Errorf("Expected Series.At(2) = 20 on int64 labels, got %v (%v)", v, err)
+	}
+}
+
+func TestRangeIndex(t *testing.T) {
+	idx := dataframe.NewRangeIndex(5)
+	idx.SetName("row")
+
+	if idx.Len() != 5 {
+		t.Fatalf("Expected length 5, got %d", idx.Len())
+	}
+	if l, err := idx.Get(4); err != nil || l != 4 {
+		t.Errorf("Expected label 4, got %v (%v)", l, err)
+	}
+	if _, err := idx.Get(5); err == nil {
+		t.Error("Expected error for position past the end")
+	}
+	for _, label := range []interface{}{int64(3), uint8(3), int32(3)} {
+		if pos, err := idx.GetLoc(label); err != nil || pos != 3 {
+			t.Errorf("Expected %T(3) at position 3, got %d (%v)", label, pos, err)
+		}
+	}
+	for _, label := range []interface{}{-1, 5, 3.0, "3", nil} {
+		if idx.Contains(label) {
+			t.Errorf("Range index should not contain %v (%T)", label, label)
+		}
+	}
+
+	sliced := idx.Slice(1, 4).Slice(1, 3)
+	if got := sliced.Labels(); !reflect.DeepEqual(got, []interface{}{2, 3}) {
+		t.Errorf("Expected sliced labels [2 3], got %v", got)
+	}
+	if pos, err := sliced.GetLoc(3); err != nil || pos != 1 || sliced.Contains(1) {
+		t.Errorf("Expected label 3 at position 1 of slice, got %d (%v)", pos, err)
+	}
+	if sliced.Name() != "row" || idx.Copy().Name() != "row" {
+		t.Error("Expected Slice and Copy to keep the index name")
+	}
+	if empty := idx.Slice(3, 3); empty.Len() != 0 || empty.Min() != nil {
+		t.Errorf("Expected empty slice, got %v", empty.Labels())
+	}
+
+	next := idx.Append(5)
+	if pos, err := next.GetLoc(5); err != nil || pos != 5 {
+		t.Errorf("Expected appended label at 5, got %d (%v)", pos, err)
+	}
+	custom := idx.Append("x")
+	if got := custom.Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, 2, 3, 4, "x"}) {
+		t.Errorf("Unexpected labels after Append: %v", got)
+	}
+	if pos, err := custom.GetLoc("x"); err != nil || pos != 5 {
+		t.Errorf("Expected custom label at 5, got %d (%v)", pos, err)
+	}
+
+	materialized := dataframe.NewIndex([]interface{}{0, 1, 2, 3, 4}, "")
+	if !idx.Equals(materialized) || !materialized.Equals(idx) || !idx.Equals(dataframe.NewRangeIndex(5)) {
+		t.Error("Expected range index to equal the same labels")
+	}
+	if idx.Equals(idx.Slice(1, 5).Append(5)) {
+		t.Error("Shifted range must not equal the original")
+	}
+	if idx.Min() != 0 || idx.Max() != 4 || !idx.IsMonotonicIncreasing() || idx.IsMonotonicDecreasing() {
+		t.Error("Unexpected order properties of range index")
+	}
+	if !idx.IsUnique() || !reflect.DeepEqual(idx.Duplicated("none"), make([]bool, 5)) {
+		t.Error("Expected range index labels to be unique")
+	}
+	if got := idx.ToStringSlice(); !reflect.DeepEqual(got, []string{"0", "1", "2", "3", "4"}) {
+		t.Errorf("Unexpected string labels %v", got)
+	}
+	if got := idx.Union(dataframe.NewIndex([]interface{}{4, 7}, "")).Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, 2, 3, 4, 7}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+}
+
+func TestIndexInsertDeleteDropRename(t *testing.T) {
+	idx := dataframe.NewIndex([]interface{}{"a", "b", "c"}, "key")
+
+	inserted, err := idx.Insert(1, "x")
+	if err != nil || !reflect.DeepEqual(inserted.Labels(), []interface{}{"a", "x", "b", "c"}) {
+		t.Fatalf("Unexpected Insert result %v (%v)", inserted, err)
+	}
+	if pos, _ := inserted.GetLoc("b"); pos != 2 || inserted.Name() != "key" {
+		t.Errorf("Expected 'b' moved to 2 with name kept, got %d", pos)
+	}
+	if end, _ := idx.Insert(3, "d"); end.Len() != 4 {
+		t.Errorf("Expected insert at the end, got %v", end.Labels())
+	}
+	if _, err := idx.Insert(4, "d"); err == nil {
+		t.Error("Expected error for insert position past the end")
+	}
+
+	deleted, err := idx.Delete(0)
+	if err != nil || !reflect.DeepEqual(deleted.Labels(), []interface{}{"b", "c"}) {
+		t.Errorf("Unexpected Delete result %v (%v)", deleted, err)
+	}
+	if _, err := idx.Delete(3); err == nil {
+		t.Error("Expected error deleting past the end")
+	}
+	rng := dataframe.NewRangeIndex(4)
+	for pos, want := range [][]interface{}{{1, 2, 3}, {0, 2, 3}, {0, 1, 3}, {0, 1, 2}} {
+		got, _ := rng.Delete(pos)
+		if !reflect.DeepEqual(got.Labels(), want) {
+			t.Errorf("Delete(%d) on range = %v, want %v", pos, got.Labels(), want)
+		}
+	}
+
+	dups := dataframe.NewIndex([]interface{}{1, 2, 1, 3}, "")
+	dropped, err := dups.Drop([]interface{}{int64(1)})
+	if err != nil || !reflect.DeepEqual(dropped.Labels(), []interface{}{2, 3}) {
+		t.Errorf("Expected every 1 dropped, got %v (%v)", dropped, err)
+	}
+	if _, err := dups.Drop([]interface{}{2, 9}); err == nil || !strings.Contains(err.Error(), "9") {
+		t.Errorf("Expected error naming label 9, got %v", err)
+	}
+
+	renamed := dups.Rename(map[interface{}]interface{}{int64(1): "one", 3: "three"})
+	if !reflect.DeepEqual(renamed.Labels(), []interface{}{"one", 2, "one", "three"}) {
+		t.Errorf("Unexpected Rename result %v", renamed.Labels())
+	}
+	if locs := renamed.GetLocs("one"); !reflect.DeepEqual(locs, []int{0, 2}) {
+		t.Errorf("Expected renamed label at [0 2], got %v", locs)
+	}
+	if dups.Contains("one") {
+		t.Error("Rename must not change the original index")
+	}
+}
+
+func TestDropRows(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{"a", 1}, {"b", 2}, {"c", 3}}, []string{"k", "v"})
+
+	result, err := df.DropRows(0, int64(2))
+	if err != nil {
+		t.Fatalf("DropRows failed: %v", err)
+	}
+	if result.Shape() != [2]int{1, 2} || !reflect.DeepEqual(result.Index().Labels(), []interface{}{1}) {
+		t.Fatalf("Expected only row 1 left, got %v", result)
+	}
+	if v, err := result.At(1, "k"); err != nil || v != "b" {
+		t.Errorf("Expected At(1, k) = b, got %v (%v)", v, err)
+	}
+	if df.Shape()[0] != 3 {
+		t.Error("DropRows must not change the original DataFrame")
+	}
+	if _, err := df.DropRows(1, 7); err == nil || !strings.Contains(err.Error(), "7") {
+		t.Errorf("Expected error naming label 7, got %v", err)
+	}
+}
+
+func TestIndexAsof(t *testing.T) {
+	idx := dataframe.NewIndex([]interface{}{10, 20, 20, 30}, "")
+	cases := []struct {
+		label interface{}
+		pos   int
+	}{
+		{10, 0}, {15, 0}, {20, 2}, {int64(25), 2}, {29.5, 2}, {30, 3}, {1000, 3},
+	}
+	for _, c := range cases {
+		if pos, err := idx.Asof(c.label); err != nil || pos != c.pos {
+			t.Errorf("Asof(%v) = %d (%v), want %d", c.label, pos, err, c.pos)
+		}
+	}
+	if _, err := idx.Asof(5); !errors.Is(err, dataframe.ErrAsofBeforeStart) {
+		t.Errorf("Expected ErrAsofBeforeStart, got %v", err)
+	}
+	if _, err := idx.Asof("x"); !errors.Is(err, dataframe.ErrIncomparableValue) {
+		t.Errorf("Expected ErrIncomparableValue, got %v", err)
+	}
+	if _, err := dataframe.NewIndex([]interface{}{}, "").Asof(1); !errors.Is(err, dataframe.ErrAsofBeforeStart) {
+		t.Errorf("Expected ErrAsofBeforeStart for an empty index, got %v", err)
+	}
+
+	unsorted := dataframe.NewIndex([]interface{}{3, 1, 2}, "")
+	if _, err := unsorted.Asof(2); !errors.Is(err, dataframe.ErrUnsortedIndex) {
+		t.Errorf("Expected ErrUnsortedIndex, got %v", err)
+	}
+	if _, err := unsorted.Asof(2, dataframe.SearchSortedOptions{AssumeSorted: true}); err != nil {
+		t.Errorf("Expected no check with AssumeSorted, got %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	days, _ := dataframe.NewDatetimeIndex(start, start.AddDate(0, 0, 4), "1d", "")
+	s := dataframe.NewSeriesWithIndex([]interface{}{1.0, 2.0, nil, 4.0, 5.0}, "price", days)
+	if v, err := s.Asof(start.AddDate(0, 0, 1).Add(12 * time.Hour)); err != nil || v != 2.0 {
+		t.Errorf("Expected Series.Asof = 2, got %v (%v)", v, err)
+	}
+	if v, err := s.Asof(start.AddDate(0, 0, 2)); err != nil || v != nil {
+		t.Errorf("Expected the NA value to be returned as stored, got %v (%v)", v, err)
+	}
+	if _, err := s.Asof(start.Add(-time.Second)); !errors.Is(err, dataframe.ErrAsofBeforeStart) {
+		t.Errorf("Expected ErrAsofBeforeStart, got %v", err)
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{{"a"}, {"b"}, {"c"}}, []string{"k"})
+	row, err := df.RowAsof(int64(7))
+	if err != nil {
+		t.Fatalf("RowAsof failed: %v", err)
+	}
+	if k, _ := row.GetString("k"); k != "c" {
+		t.Errorf("Expected last row, got %q", k)
+	}
+	if _, err := df.RowAsof(-1); !errors.Is(err, dataframe.ErrAsofBeforeStart) {
+		t.Errorf("Expected ErrAsofBeforeStart, got %v", err)
+	}
+}
+
+func TestIndexPropagation(t *testing.T) {
+	base, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1.0}, {"x", 0.0}, {"b", nil}, {"a", 3.0}, {"c", 4.0},
+	}, []string{"k", "v"})
+	// Labels 0, 2, 3, 4 so that positions and labels differ
+	df, _ := base.DropRows(1)
+	df.Index().SetName("id")
+
+	must := func(result *dataframe.DataFrame, err error) *dataframe.DataFrame {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	gb, err := df.GroupBy("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey, err := df.GroupByWith(dataframe.GroupByOptions{DropNA: true, KeyIndex: true}, "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keepIndex := func(how dataframe.JoinType, strategy string, parallel *dataframe.ParallelOptions) dataframe.MergeOptions {
+		return dataframe.MergeOptions{How: how, On: []string{"k"}, KeepIndex: true, Strategy: strategy, Parallel: parallel}
+	}
+	cases := []struct {
+		name   string
+		result *dataframe.DataFrame
+		labels []interface{}
+		index  string
+	}{
+		{"Filter", df.Filter(func(r dataframe.Row) bool { k, _ := r.GetString("k"); return k == "a" }), []interface{}{0, 3}, "id"},
+		{"SortBy", must(df.SortBy("v", dataframe.Descending)), []interface{}{4, 3, 0, 2}, "id"},
+		{"FillNA", must(df.FillNA(0.0)), []interface{}{0, 2, 3, 4}, "id"},
+		{"DropNA", must(df.DropNA(dataframe.DropNAOptions{})), []interface{}{0, 3, 4}, "id"},
+		{"Query", must(df.Query("v > 2")), []interface{}{3, 4}, "id"},
+		{"Head", df.Head(2), []interface{}{0, 2}, "id"},
+		{"Tail", df.Tail(2), []interface{}{3, 4}, "id"},
+		{"Loc", df.Loc([]interface{}{4, 0}, nil), []interface{}{4, 0}, "id"},
+		{"SelectRowsAt", must(df.SelectRowsAt([]int{1, 0})), []interface{}{2, 0}, "id"},
+		{"Shift", must(df.Shift(1)), []interface{}{0, 2, 3, 4}, "id"},
+		{"Copy", df.Copy(), []interface{}{0, 2, 3, 4}, "id"},
+		{"ConcatWith", must(dataframe.ConcatWith(dataframe.ConcatOptions{}, df.Head(1), df.Tail(1))), []interface{}{0, 4}, "id"},
+		{"ConcatWith IgnoreIndex", must(dataframe.ConcatWith(dataframe.ConcatOptions{IgnoreIndex: true}, df.Head(1), df.Tail(1))), []interface{}{0, 1}, ""},
+		{"Concat", dataframe.Concat(df.Head(1), df.Tail(1)), []interface{}{0, 1}, ""},
+		{"Merge", must(dataframe.Merge(df.Tail(2), df.Tail(2), dataframe.MergeOptions{On: []string{"k"}})), []interface{}{0, 1}, ""},
+		{"Merge KeepIndex", must(dataframe.Merge(df.Tail(2), df.Tail(2), keepIndex(dataframe.InnerJoin, "", nil))), []interface{}{3, 4}, "id"},
+		{"Merge KeepIndex outer", must(dataframe.Merge(df.Head(2), df.Tail(1), keepIndex(dataframe.OuterJoin, "hash", nil))), []interface{}{0, 2, 4}, "id"},
+		{"Merge KeepIndex outer sort", must(dataframe.Merge(df.Head(2), df.Tail(1), keepIndex(dataframe.OuterJoin, "sort", nil))), []interface{}{0, 2, 4}, "id"},
+		{"Merge KeepIndex right parallel", must(dataframe.Merge(df.Head(2), df.Tail(2), keepIndex(dataframe.RightJoin, "hash", &dataframe.ParallelOptions{NumWorkers: 2}))), []interface{}{0, 4}, "id"},
+		{"GroupBy.Sum", gb.Sum("v"), []interface{}{0, 1, 2}, ""},
+		{"GroupBy.Sum KeyIndex", byKey.Sum("v"), []interface{}{"a", "b", "c"}, "k"},
+		{"GroupBy.Size KeyIndex", byKey.Size(), []interface{}{"a", "b", "c"}, "k"},
+		{"GroupBy.AggDF KeyIndex", must(byKey.AggDF(map[string]dataframe.AggFuncDF{"rows": func(g *dataframe.DataFrame) interface{} { return g.Shape()[0] }})), []interface{}{"a", "b", "c"}, "k"},
+		{"GroupBy.HeadN", gb.HeadN(1), []interface{}{0, 2, 4}, "id"},
+		{"GroupBy.Filter", gb.Filter(func(g *dataframe.DataFrame) bool { return g.Shape()[0] > 1 }), []interface{}{0, 3}, "id"},
+	}
+	for _, c := range cases {
+		if got := c.result.Index().Labels(); !reflect.DeepEqual(got, c.labels) {
+			t.Errorf("%s: index labels %v, want %v", c.name, got, c.labels)
+		}
+		if got := c.result.Index().Name(); got != c.index {
+			t.Errorf("%s: index name %q, want %q", c.name, got, c.index)
+		}
+		if c.result.Index().Len() != c.result.Shape()[0] {
+			t.Errorf("%s: index length %d does not match %d rows", c.name, c.result.Index().Len(), c.result.Shape()[0])
+		}
+		for _, col := range c.result.Columns() {
+			if s, _ := c.result.GetSeries(col); !s.Index().Equals(c.result.Index()) {
+				t.Errorf("%s: column %s has index %v", c.name, col, s.Index().Labels())
+			}
+		}
+	}
+	if size, _ := byKey.Size().GetSeries("size"); !reflect.DeepEqual(size.Values(), []interface{}{2, 1, 1}) {
+		t.Errorf("Size with KeyIndex = %v, want [2 1 1]", size.Values())
+	}
+	none := gb.Filter(func(*dataframe.DataFrame) bool { return false })
+	if !reflect.DeepEqual(none.Columns(), df.Columns()) || none.Shape() != [2]int{0, len(df.Columns())} {
+		t.Errorf("Filter keeping no group = %v columns, shape %v", none.Columns(), none.Shape())
+	}
+	if k, ok := none.GetSeries("k"); !ok || k.Len() != 0 {
+		t.Errorf("Expected an empty k column when Filter keeps no group, got %v, %v", k, ok)
+	}
+	if _, err := df.GroupByWith(dataframe.GroupByOptions{KeyIndex: true}, "k", "v"); err == nil {
+		t.Error("Expected KeyIndex to need a single key column")
+	}
+
+	named := dataframe.NewIndex([]interface{}{"w", "x", "y"}, "id")
+	if named.Copy().Name() != "id" || named.Slice(0, 1).Name() != "id" || named.Union(dataframe.NewRangeIndex(1)).Name() != "id" {
+		t.Error("Expected Copy, Slice and Union to keep the index name")
+	}
+
+	s := dataframe.NewSeriesWithIndex([]interface{}{1, 2, 3}, "s", named)
+	same := s.ChunkedApply(func(c []interface{}) []interface{} { return c }, 2)
+	if !reflect.DeepEqual(same.Index().Labels(), []interface{}{"w", "x", "y"}) || same.Index().Name() != "id" {
+		t.Errorf("Expected ChunkedApply to keep the index, got %v", same.Index().Labels())
+	}
+	firsts := func(c []interface{}) []interface{} { return c[:1] }
+	for _, shrunk := range []*dataframe.Series{s.ChunkedApply(firsts, 2), s.ParallelChunkedApply(firsts, 2)} {
+		if !reflect.DeepEqual(shrunk.Index().Labels(), []interface{}{0, 1}) {
+			t.Errorf("Expected a range index after a length change, got %v", shrunk.Index().Labels())
+		}
+	}
+}
+
+func TestIndexSetOperations(t *testing.T) {
+	left := dataframe.NewIndex([]interface{}{"c", "a", "b", "a"}, "left")
+	right := dataframe.NewIndex([]interface{}{"d", "b", "e", "d"}, "right")
+	sorted := dataframe.IndexSetOptions{Sort: true}
+
+	cases := []struct {
+		name string
+		got  *dataframe.Index
+		want []interface{}
+	}{
+		{"Union", left.Union(right), []interface{}{"c", "a", "b", "d", "e"}},
+		{"Union sorted", left.Union(right, sorted), []interface{}{"a", "b", "c", "d", "e"}},
+		{"Intersection", left.Intersection(right), []interface{}{"b"}},
+		{"Difference", left.Difference(right), []interface{}{"c", "a", "a"}},
+		{"Difference sorted", left.Difference(right, sorted), []interface{}{"a", "a", "c"}},
+		{"SymmetricDifference", left.SymmetricDifference(right), []interface{}{"c", "a", "d", "e"}},
+		{"SymmetricDifference sorted", left.SymmetricDifference(right, sorted), []interface{}{"a", "c", "d", "e"}},
+	}
+	for _, c := range cases {
+		if got := c.got.Labels(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, got, c.want)
+		}
+		if c.got.Name() != "left" {
+			t.Errorf("%s: expected the name of the left index, got %q", c.name, c.got.Name())
+		}
+	}
+
+	ints := dataframe.NewRangeIndex(3)
+	int64s := dataframe.NewIndex([]interface{}{int64(4), int64(2)}, "")
+	if got := ints.SymmetricDifference(int64s, sorted).Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, int64(4)}) {
+		t.Errorf("Expected int and int64 labels to combine, got %v", got)
+	}
+	withNA := dataframe.NewIndex([]interface{}{3, nil, 1}, "")
+	if got := withNA.Union(ints, sorted).Labels(); !reflect.DeepEqual(got, []interface{}{0, 1, 2, 3, nil}) {
+		t.Errorf("Expected NA label sorted last, got %v", got)
+	}
+
+	// Labels that cannot be map keys never match instead of panicking
+	unhashable := dataframe.NewIndex([]interface{}{[]int{1}, "x"}, "")
+	if got := unhashable.Union(unhashable).Len(); got != 3 {
+		t.Errorf("Expected both unhashable labels and 'x' in union, got %d labels", got)
+	}
+	if got := unhashable.Intersection(unhashable).Labels(); !reflect.DeepEqual(got, []interface{}{"x"}) {
+		t.Errorf("Expected only 'x' in intersection, got %v", got)
+	}
+	if got := unhashable.SymmetricDifference(dataframe.NewIndex([]interface{}{"x"}, "")).Len(); got != 1 {
+		t.Errorf("Expected the unhashable label in symmetric difference, got %d labels", got)
+	}
+}
+
+func BenchmarkIndexGetLoc(b *testing.B) {
+	n := 1000000
+	labels := make([]interface{}, n)
+	for i := range labels {
+		labels[i] = i * 2
+	}
+	idx := dataframe.NewIndex(labels, "")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := idx.GetLoc((i * 7919 % n) * 2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRangeIndex measures building, slicing and looking up a large
+// range index, which takes constant memory.
+func BenchmarkRangeIndex(b *testing.B) {
+	n := 5000000
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		idx := dataframe.NewRangeIndex(n)
+		head := idx.Slice(0, n/2)
+		if pos, err := head.GetLoc(n/2 - 1); err != nil || pos != n/2-1 {
+			b.Fatal(pos, err)
+		}
+	}
+}
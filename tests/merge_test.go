@@ -1,7 +1,13 @@
 package tests
 
 import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -121,6 +127,12 @@ func TestOuterJoin(t *testing.T) {
 	if result.Shape()[0] != 4 {
 		t.Errorf("Expected 4 rows, got %d", result.Shape()[0])
 	}
+	ids, _ := result.GetSeries("id")
+	for i, exp := range []int{1, 2, 3, 4} {
+		if v, _ := ids.Get(i); v != exp {
+			t.Errorf("id[%d]: expected %d, got %v", i, exp, v)
+		}
+	}
 }
 
 func TestMergeWithDifferentColumnNames(t *testing.T) {
@@ -148,6 +160,48 @@ func TestMergeWithDifferentColumnNames(t *testing.T) {
 	if result.Shape()[0] != 2 {
 		t.Errorf("Expected 2 rows, got %d", result.Shape()[0])
 	}
+	if _, ok := result.GetSeries("right_id"); ok {
+		t.Error("Expected right_id to be coalesced into left_id")
+	}
+	ids, _ := result.GetSeries("left_id")
+	if got := ids.Values(); !reflect.DeepEqual(got, []interface{}{2, 3}) {
+		t.Errorf("Expected left_id [2 3], got %v", got)
+	}
+}
+
+func TestMergeDifferentKeyNamesOuter(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}}, []string{"left_id", "name"})
+	right, _ := dataframe.FromRecords([][]interface{}{{2, 20}, {3, 30}}, []string{"right_id", "score"})
+
+	opts := dataframe.DefaultMergeOptions()
+	opts.How = dataframe.OuterJoin
+	opts.LeftOn = []string{"left_id"}
+	opts.RightOn = []string{"right_id"}
+	result, err := dataframe.Merge(left, right, opts)
+	if err != nil {
+		t.Fatalf("Outer merge failed: %v", err)
+	}
+	ids, _ := result.GetSeries("left_id")
+	if got := ids.Values(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Errorf("Expected coalesced keys [1 2 3], got %v", got)
+	}
+
+	opts.KeepRightKeys = true
+	result, err = dataframe.Merge(left, right, opts)
+	if err != nil {
+		t.Fatalf("Outer merge with KeepRightKeys failed: %v", err)
+	}
+	if got := strings.Join(result.Columns(), ","); got != "left_id,name,right_id,score" {
+		t.Errorf("Expected columns left_id,name,right_id,score, got %s", got)
+	}
+	leftIDs, _ := result.GetSeries("left_id")
+	rightIDs, _ := result.GetSeries("right_id")
+	if got := leftIDs.Values(); !reflect.DeepEqual(got, []interface{}{1, 2, nil}) {
+		t.Errorf("Expected left_id [1 2 <nil>], got %v", got)
+	}
+	if got := rightIDs.Values(); !reflect.DeepEqual(got, []interface{}{nil, 2, 3}) {
+		t.Errorf("Expected right_id [<nil> 2 3], got %v", got)
+	}
 }
 
 func TestMergeWithIndicator(t *testing.T) {
@@ -257,3 +311,374 @@ func TestMergeMultipleKeys(t *testing.T) {
 		t.Errorf("Expected 2 rows, got %d", result.Shape()[0])
 	}
 }
+
+func TestMergeAsof(t *testing.T) {
+	trades, _ := dataframe.FromRecords([][]interface{}{
+		{1, "A", 100},
+		{3, "A", 101},
+		{5, "B", 102},
+		{9, "A", 103},
+	}, []string{"time", "ticker", "qty"})
+	quotes, _ := dataframe.FromRecords([][]interface{}{
+		{0, "A", 9.5},
+		{2, "A", 9.8},
+		{4, "B", 20.0},
+		{6, "A", 10.1},
+	}, []string{"time", "ticker", "bid"})
+
+	result, err := dataframe.MergeAsof(trades, quotes, dataframe.AsofOptions{On: "time", By: []string{"ticker"}})
+	if err != nil {
+		t.Fatalf("MergeAsof failed: %v", err)
+	}
+	bid, _ := result.GetSeries("bid")
+	for i, exp := range []interface{}{9.5, 9.8, 20.0, 10.1} {
+		if v, _ := bid.Get(i); v != exp {
+			t.Errorf("backward bid[%d]: expected %v, got %v", i, exp, v)
+		}
+	}
+
+	result, _ = dataframe.MergeAsof(trades, quotes, dataframe.AsofOptions{On: "time", By: []string{"ticker"}, Direction: "forward"})
+	bid, _ = result.GetSeries("bid")
+	for i, exp := range []interface{}{9.8, 10.1, nil, nil} {
+		if v, _ := bid.Get(i); v != exp {
+			t.Errorf("forward bid[%d]: expected %v, got %v", i, exp, v)
+		}
+	}
+
+	result, _ = dataframe.MergeAsof(trades, quotes, dataframe.AsofOptions{On: "time", Direction: "nearest", Tolerance: 1})
+	bid, _ = result.GetSeries("bid")
+	for i, exp := range []interface{}{9.5, 9.8, 20.0, nil} {
+		if v, _ := bid.Get(i); v != exp {
+			t.Errorf("nearest bid[%d]: expected %v, got %v", i, exp, v)
+		}
+	}
+	if _, ok := result.GetSeries("ticker_y"); !ok {
+		t.Error("Expected suffixed ticker_y column when ticker is not a By key")
+	}
+
+	unsorted, _ := dataframe.FromRecords([][]interface{}{{5, "A", 1.0}, {2, "A", 2.0}}, []string{"time", "ticker", "bid"})
+	if _, err := dataframe.MergeAsof(trades, unsorted, dataframe.AsofOptions{On: "time", By: []string{"ticker"}}); err == nil {
+		t.Error("Expected error for unsorted right DataFrame")
+	}
+}
+
+func TestMergeAsofTime(t *testing.T) {
+	base := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	left, _ := dataframe.FromRecords([][]interface{}{{base.Add(5 * time.Second)}, {base.Add(time.Minute)}}, []string{"ts"})
+	right, _ := dataframe.FromRecords([][]interface{}{{base, 1.0}}, []string{"ts", "px"})
+
+	result, err := dataframe.MergeAsof(left, right, dataframe.AsofOptions{On: "ts", Tolerance: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("MergeAsof failed: %v", err)
+	}
+	px, _ := result.GetSeries("px")
+	if v, _ := px.Get(0); v != 1.0 {
+		t.Errorf("Expected match within tolerance, got %v", v)
+	}
+	if v, _ := px.Get(1); v != nil {
+		t.Errorf("Expected no match beyond tolerance, got %v", v)
+	}
+	if _, err := dataframe.MergeAsof(left, right, dataframe.AsofOptions{On: "ts", Tolerance: 10}); err == nil {
+		t.Error("Expected error for numeric tolerance on datetime column")
+	}
+}
+
+func TestCrossJoin(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{{"s"}, {"m"}}, []string{"size"})
+	right, _ := dataframe.FromRecords([][]interface{}{{"red"}, {"blue"}, {"green"}}, []string{"color"})
+
+	result, err := left.Join(right, nil, dataframe.CrossJoin)
+	if err != nil {
+		t.Fatalf("Cross join failed: %v", err)
+	}
+	if result.Shape() != [2]int{6, 2} {
+		t.Errorf("Expected shape [6 2], got %v", result.Shape())
+	}
+	if _, err := left.Join(right, []string{"size"}, dataframe.CrossJoin); err == nil {
+		t.Error("Expected error for cross join with keys")
+	}
+	if dataframe.CrossJoin.String() != "cross" {
+		t.Errorf("Expected cross, got %s", dataframe.CrossJoin.String())
+	}
+}
+
+func TestSemiAntiJoin(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}, {3, "c"}}, []string{"id", "name"})
+	right, _ := dataframe.FromRecords([][]interface{}{{2, 10}, {2, 20}, {3, 30}}, []string{"id", "score"})
+
+	semi, err := left.Join(right, []string{"id"}, dataframe.SemiJoin)
+	if err != nil {
+		t.Fatalf("Semi join failed: %v", err)
+	}
+	if semi.Shape() != [2]int{2, 2} {
+		t.Errorf("Expected shape [2 2] without duplicates or right columns, got %v", semi.Shape())
+	}
+
+	anti, err := left.Join(right, []string{"id"}, dataframe.AntiJoin)
+	if err != nil {
+		t.Fatalf("Anti join failed: %v", err)
+	}
+	if v, _ := anti.GetSeries("name"); anti.Shape()[0] != 1 || v.Values()[0] != "a" {
+		t.Errorf("Expected only row 'a', got %v", anti)
+	}
+
+	_, err = dataframe.Merge(left, right, dataframe.MergeOptions{How: dataframe.SemiJoin, On: []string{"id"}, Indicator: true})
+	if err == nil {
+		t.Error("Expected error for indicator with semi join")
+	}
+}
+
+func TestMergeValidate(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{{1, "a"}, {2, "b"}, {2, "c"}}, []string{"id", "name"})
+	right, _ := dataframe.FromRecords([][]interface{}{{1, 10}, {2, 20}}, []string{"id", "score"})
+
+	if _, err := dataframe.Merge(left, right, dataframe.MergeOptions{On: []string{"id"}, Validate: "m:1"}); err != nil {
+		t.Errorf("Expected m:1 to pass, got %v", err)
+	}
+	_, err := dataframe.Merge(left, right, dataframe.MergeOptions{On: []string{"id"}, Validate: "1:1"})
+	if err == nil || !strings.Contains(err.Error(), "left DataFrame") || !strings.Contains(err.Error(), "duplicated 2") {
+		t.Errorf("Expected duplicated key error for left side, got %v", err)
+	}
+	if _, err := dataframe.Merge(left, right, dataframe.MergeOptions{On: []string{"id"}, Validate: "one"}); err == nil {
+		t.Error("Expected error for invalid validate option")
+	}
+
+	unique, dups := left.HasUniqueKey("id")
+	if unique || len(dups) != 1 || dups[0] != "2" {
+		t.Errorf("Expected duplicated key 2, got %v %v", unique, dups)
+	}
+	if unique, _ := left.HasUniqueKey("id", "name"); !unique {
+		t.Error("Expected (id, name) to be unique")
+	}
+}
+
+func randomJoinFrame(rng *rand.Rand, n int, valueCol string) *dataframe.DataFrame {
+	groups := []string{"a", "b", "c"}
+	records := make([][]interface{}, n)
+	for i := range records {
+		records[i] = []interface{}{rng.Intn(n/2 + 1), groups[rng.Intn(len(groups))], rng.Float64()}
+	}
+	df, _ := dataframe.FromRecords(records, []string{"id", "grp", valueCol})
+	return df
+}
+
+// randomMixedKeyFrame is like randomJoinFrame, but its id keys mix ints,
+// whole and fractional floats, NaN, nil and numeric strings, so that equal
+// keys of different Go types meet.
+func randomMixedKeyFrame(rng *rand.Rand, n int, valueCol string) *dataframe.DataFrame {
+	groups := []string{"a", "b", "c"}
+	records := make([][]interface{}, n)
+	for i := range records {
+		k := rng.Intn(n/2 + 1)
+		var id interface{}
+		switch rng.Intn(7) {
+		case 0:
+			id = k
+		case 1:
+			id = int64(k)
+		case 2:
+			id = float64(k)
+		case 3:
+			id = float64(k) + 0.5
+		case 4:
+			id = math.NaN()
+		case 5:
+			id = nil
+		default:
+			id = strconv.Itoa(k)
+		}
+		records[i] = []interface{}{id, groups[rng.Intn(len(groups))], rng.Float64()}
+	}
+	df, _ := dataframe.FromRecords(records, []string{"id", "grp", valueCol})
+	return df
+}
+
+// sameRecords is reflect.DeepEqual for records, except that NaN equals NaN.
+func sameRecords(a, b [][]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j, v := range a[i] {
+			fa, okA := v.(float64)
+			fb, okB := b[i][j].(float64)
+			if okA && okB && math.IsNaN(fa) && math.IsNaN(fb) {
+				continue
+			}
+			if !reflect.DeepEqual(v, b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestMergeSortStrategyMatchesHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	joins := []dataframe.JoinType{
+		dataframe.InnerJoin, dataframe.LeftJoin, dataframe.RightJoin, dataframe.OuterJoin,
+		dataframe.SemiJoin, dataframe.AntiJoin,
+	}
+	for trial := 0; trial < 40; trial++ {
+		frame := randomJoinFrame
+		if trial >= 20 {
+			frame = randomMixedKeyFrame
+		}
+		left := frame(rng, rng.Intn(40)+1, "x")
+		right := frame(rng, rng.Intn(40)+1, "y")
+		if trial%2 == 0 {
+			left, _ = left.SortByColumns([]string{"id", "grp"}, nil)
+			right, _ = right.SortByColumns([]string{"id", "grp"}, nil)
+		}
+		for _, how := range joins {
+			opts := dataframe.DefaultMergeOptions()
+			opts.How = how
+			opts.On = []string{"id", "grp"}
+			opts.Indicator = how != dataframe.SemiJoin && how != dataframe.AntiJoin
+
+			opts.Strategy = dataframe.StrategyHash
+			hashed, err := dataframe.Merge(left, right, opts)
+			if err != nil {
+				t.Fatalf("hash %s join failed: %v", how, err)
+			}
+			opts.Strategy = dataframe.StrategySort
+			sorted, err := dataframe.Merge(left, right, opts)
+			if err != nil {
+				t.Fatalf("sort %s join failed: %v", how, err)
+			}
+			if !reflect.DeepEqual(hashed.Columns(), sorted.Columns()) || !sameRecords(hashed.ToRecords(), sorted.ToRecords()) {
+				t.Fatalf("trial %d: %s join differs between strategies\nhash:\n%v\nsort:\n%v", trial, how, hashed, sorted)
+			}
+		}
+	}
+
+	left := randomJoinFrame(rng, 4, "x")
+	if _, err := dataframe.Merge(left, left, dataframe.MergeOptions{On: []string{"id"}, Strategy: "nested"}); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func TestMergeParallelMatchesSerial(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	joins := []dataframe.JoinType{dataframe.InnerJoin, dataframe.LeftJoin, dataframe.RightJoin, dataframe.OuterJoin}
+	for trial := 0; trial < 20; trial++ {
+		left := randomJoinFrame(rng, rng.Intn(60)+1, "v")
+		right := randomJoinFrame(rng, rng.Intn(60)+1, "v")
+		if trial == 0 {
+			left = left.Head(0)
+		}
+		right = right.Rename(map[string]string{"id": "rid"})
+		for _, how := range joins {
+			opts := dataframe.DefaultMergeOptions()
+			opts.How = how
+			opts.LeftOn = []string{"id", "grp"}
+			opts.RightOn = []string{"rid", "grp"}
+			opts.Indicator = trial%2 == 0
+			opts.KeepRightKeys = trial%3 == 0
+			opts.Strategy = dataframe.StrategyHash
+			serial, err := dataframe.Merge(left, right, opts)
+			if err != nil {
+				t.Fatalf("serial %s join failed: %v", how, err)
+			}
+			for _, workers := range []int{1, 3, 8} {
+				opts.Parallel = &dataframe.ParallelOptions{NumWorkers: workers}
+				parallel, err := dataframe.Merge(left, right, opts)
+				if err != nil {
+					t.Fatalf("parallel %s join failed: %v", how, err)
+				}
+				if !reflect.DeepEqual(serial.Columns(), parallel.Columns()) || !reflect.DeepEqual(serial.ToRecords(), parallel.ToRecords()) {
+					t.Fatalf("trial %d: parallel %s join with %d workers differs\nserial:\n%v\nparallel:\n%v", trial, how, workers, serial, parallel)
+				}
+				for _, col := range serial.Columns() {
+					s, _ := serial.GetSeries(col)
+					p, _ := parallel.GetSeries(col)
+					if s.DType() != p.DType() {
+						t.Errorf("column %s: dtype %v, want %v", col, p.DType(), s.DType())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMergeTypedAndNullKeys(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{
+		{1, "l1"}, {"2", "l2"}, {nil, "l3"}, {3.0, "l4"}, {math.NaN(), "l5"},
+	}, []string{"id", "lv"})
+	right, _ := dataframe.FromRecords([][]interface{}{
+		{"1", "r1"}, {2, "r2"}, {nil, "r3"}, {3, "r4"}, {math.NaN(), "r5"},
+	}, []string{"id", "rv"})
+
+	expected := map[dataframe.JoinType]int{
+		dataframe.InnerJoin: 1,
+		dataframe.LeftJoin:  5,
+		dataframe.RightJoin: 5,
+		dataframe.OuterJoin: 9,
+		dataframe.SemiJoin:  1,
+		dataframe.AntiJoin:  4,
+	}
+	for _, strategy := range []string{dataframe.StrategyHash, dataframe.StrategySort} {
+		for how, rows := range expected {
+			opts := dataframe.DefaultMergeOptions()
+			opts.How = how
+			opts.On = []string{"id"}
+			opts.Strategy = strategy
+			result, err := dataframe.Merge(left, right, opts)
+			if err != nil {
+				t.Fatalf("%s %s join failed: %v", strategy, how, err)
+			}
+			if result.Shape()[0] != rows {
+				t.Errorf("%s %s join: expected %d rows, got %d\n%v", strategy, how, rows, result.Shape()[0], result)
+			}
+			if how == dataframe.InnerJoin {
+				lv, _ := result.GetSeries("lv")
+				rv, _ := result.GetSeries("rv")
+				if a, _ := lv.Get(0); a != "l4" {
+					t.Errorf("%s inner join: expected l4 to match, got %v", strategy, a)
+				}
+				if b, _ := rv.Get(0); b != "r4" {
+					t.Errorf("%s inner join: expected r4 to match, got %v", strategy, b)
+				}
+			}
+		}
+	}
+}
+
+func TestMergePromotesKeyDTypes(t *testing.T) {
+	left, _ := dataframe.FromRecords([][]interface{}{
+		{int64(1), "a"},
+		{int64(2), "b"},
+	}, []string{"id", "l"})
+	right, _ := dataframe.FromRecords([][]interface{}{
+		{2.0, "y"},
+		{3.5, "z"},
+	}, []string{"id", "r"})
+
+	for _, how := range []dataframe.JoinType{dataframe.OuterJoin, dataframe.RightJoin} {
+		merged, err := dataframe.Merge(left, right, dataframe.MergeOptions{On: []string{"id"}, How: how})
+		if err != nil {
+			t.Fatalf("Merge error: %v", err)
+		}
+		id, _ := merged.GetSeries("id")
+		if id.DType() != dataframe.DTypeFloat64 {
+			t.Errorf("%v: expected float64 key, got %v", how, id.DType())
+		}
+		for _, v := range id.Values() {
+			if _, ok := v.(float64); !ok {
+				t.Errorf("%v: expected float64 key values, got %#v", how, v)
+			}
+		}
+	}
+
+	// Inner joins only hold left keys, which keep their dtype
+	merged, err := dataframe.Merge(left, right, dataframe.MergeOptions{On: []string{"id"}, How: dataframe.InnerJoin})
+	if err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	if id, _ := merged.GetSeries("id"); id.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64 key for inner join, got %v", id.DType())
+	}
+}
@@ -1,7 +1,18 @@
 package tests
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -62,6 +73,37 @@ func TestParallelFilter(t *testing.T) {
 	}
 }
 
+func TestParallelFilterMatchesFilter(t *testing.T) {
+	values := make([]interface{}, 100)
+	reversed := make([]int, 100)
+	for i := range values {
+		values[i] = int64(i)
+		reversed[i] = 99 - i
+	}
+	df, _ := dataframe.New(map[string][]interface{}{"value": values})
+	// Reverse the rows so that the labels are not a range index
+	df, _ = df.SelectRowsAt(reversed)
+	even := func(row dataframe.Row) bool {
+		v, err := row.GetInt("value")
+		return err == nil && v%2 == 0
+	}
+
+	serial := df.Filter(even)
+	parallel := df.ParallelFilter(even, dataframe.ParallelOptions{NumWorkers: 4})
+	if serial.Shape()[0] != 50 || parallel.Shape()[0] != 50 {
+		t.Fatalf("Expected 50 rows, got %d and %d", serial.Shape()[0], parallel.Shape()[0])
+	}
+	if !serial.Index().Equals(parallel.Index()) {
+		t.Error("Expected ParallelFilter to keep the same index labels as Filter")
+	}
+	if label, _ := parallel.Index().Get(1); label != 96 {
+		t.Errorf("Expected label 96, got %v", label)
+	}
+	if s, _ := parallel.GetSeries("value"); s.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64 dtype, got %v", s.DType())
+	}
+}
+
 func TestParallelTransform(t *testing.T) {
 	// Create test DataFrame
 	data := map[string][]interface{}{
@@ -135,6 +177,46 @@ func TestParallelGroupByAgg(t *testing.T) {
 	if result.Shape()[0] != 2 {
 		t.Errorf("Expected 2 rows, got %d", result.Shape()[0])
 	}
+	if cols := result.Columns(); len(cols) != 3 || cols[1] != "value_sum" || cols[2] != "value_mean" {
+		t.Errorf("Expected columns [group value_sum value_mean], got %v", cols)
+	}
+	if _, err := gb.ParallelAgg(map[string][]dataframe.AggFunc{"missing": {dataframe.AggSum}}); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestParallelAggMatchesAgg(t *testing.T) {
+	n := 5000
+	records := make([][]interface{}, n)
+	for i := range records {
+		records[i] = []interface{}{fmt.Sprintf("g%03d", (i*37)%211), i % 3, float64(i%97) / 4, fmt.Sprintf("x%d", i%5)}
+	}
+	df, _ := dataframe.FromRecords(records, []string{"group", "sub", "value", "label"})
+	gb, _ := df.GroupBy("group", "sub")
+
+	aggFuncs := map[string][]dataframe.AggFunc{
+		"value": {dataframe.AggSum, dataframe.AggMean, dataframe.AggStd, dataframe.AggMedian},
+		"label": {dataframe.AggFirst, dataframe.AggNUnique, dataframe.AggLast},
+	}
+	want, err := gb.Agg(aggFuncs)
+	if err != nil {
+		t.Fatalf("Agg failed: %v", err)
+	}
+	all := dataframe.DisplayOptions{}
+	for _, workers := range []int{1, 3, 8} {
+		got, err := gb.ParallelAgg(aggFuncs, dataframe.ParallelOptions{NumWorkers: workers})
+		if err != nil {
+			t.Fatalf("ParallelAgg with %d workers failed: %v", workers, err)
+		}
+		if got.StringWith(all) != want.StringWith(all) {
+			t.Errorf("ParallelAgg with %d workers differs from Agg", workers)
+		}
+	}
+
+	_, err = gb.ParallelAgg(map[string][]dataframe.AggFunc{"nope": {dataframe.AggSum}}, dataframe.ParallelOptions{NumWorkers: 4})
+	if err == nil || !strings.Contains(err.Error(), "'nope'") {
+		t.Errorf("Expected error naming the missing column, got %v", err)
+	}
 }
 
 func TestChunkedApply(t *testing.T) {
@@ -163,6 +245,56 @@ func TestChunkedApply(t *testing.T) {
 	}
 }
 
+func TestChunkedApplyDType(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{"1.5", "2", "x", "4"}, "values")
+	parse := func(chunk []interface{}) []interface{} {
+		out := make([]interface{}, len(chunk))
+		for i, v := range chunk {
+			f, err := strconv.ParseFloat(v.(string), 64)
+			if err != nil {
+				f = math.NaN()
+			}
+			out[i] = f
+		}
+		return out
+	}
+	for _, result := range []*dataframe.Series{s.ChunkedApply(parse, 3), s.ParallelChunkedApply(parse, 1, dataframe.ParallelOptions{NumWorkers: 2})} {
+		if result.DType() != dataframe.DTypeFloat64 {
+			t.Errorf("Expected float64 dtype, got %v", result.DType())
+		}
+	}
+	strs := s.ChunkedApply(func(chunk []interface{}) []interface{} { return chunk }, 3)
+	if strs.DType() != dataframe.DTypeString {
+		t.Errorf("Expected string dtype, got %v", strs.DType())
+	}
+}
+
+func TestParallelApplyChunkSize(t *testing.T) {
+	data := make([]interface{}, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	s := dataframe.NewSeries(data, "values")
+	before := runtime.NumGoroutine()
+	var maxGoroutines atomic.Int64
+	fn := func(v interface{}) interface{} {
+		if n := int64(runtime.NumGoroutine()); n > maxGoroutines.Load() {
+			maxGoroutines.Store(n)
+		}
+		return v
+	}
+
+	// Fewer values than ChunkSize leave a single chunk, applied in the caller
+	s.ParallelApply(fn, dataframe.ParallelOptions{NumWorkers: 4, ChunkSize: 1000})
+	if n := maxGoroutines.Load(); n > int64(before) {
+		t.Errorf("Expected no workers for a single chunk, saw %d goroutines (%d before)", n, before)
+	}
+	s.ParallelApply(fn, dataframe.ParallelOptions{NumWorkers: 4})
+	if n := maxGoroutines.Load(); n <= int64(before) {
+		t.Errorf("Expected workers without ChunkSize, saw %d goroutines (%d before)", n, before)
+	}
+}
+
 func TestParallelChunkedApply(t *testing.T) {
 	// Create large data
 	data := make([]interface{}, 100000)
@@ -328,3 +460,959 @@ func BenchmarkDataFrameParallelFilter(b *testing.B) {
 		})
 	}
 }
+
+// wideFilterFrame returns a 20-column frame of 100000 rows.
+func wideFilterFrame() *dataframe.DataFrame {
+	data := make(map[string][]interface{}, 20)
+	for c := 0; c < 20; c++ {
+		values := make([]interface{}, 100000)
+		for i := range values {
+			values[i] = i
+		}
+		data[fmt.Sprintf("c%d", c)] = values
+	}
+	df, _ := dataframe.New(data)
+	return df
+}
+
+func BenchmarkDataFrameFilterWide(b *testing.B) {
+	df := wideFilterFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		df.Filter(func(row dataframe.Row) bool {
+			v, err := row.GetInt("c0")
+			return err == nil && v > 50000
+		})
+	}
+}
+
+func BenchmarkDataFrameParallelFilterWide(b *testing.B) {
+	df := wideFilterFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		df.ParallelFilter(func(row dataframe.Row) bool {
+			v, err := row.GetInt("c0")
+			return err == nil && v > 50000
+		})
+	}
+}
+
+func TestParallelTryApply(t *testing.T) {
+	data := make([]interface{}, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	s := dataframe.NewSeries(data, "values")
+	opts := dataframe.ParallelOptions{NumWorkers: 4}
+
+	result, err := s.ParallelTryApply(func(v interface{}) (interface{}, error) {
+		return v.(int) * 2, nil
+	}, opts)
+	if err != nil {
+		t.Fatalf("ParallelTryApply error: %v", err)
+	}
+	if v, _ := result.Get(9999); v != 19998 {
+		t.Errorf("Expected 19998, got %v", v)
+	}
+
+	_, err = s.ParallelTryApply(func(v interface{}) (interface{}, error) {
+		if v.(int) >= 5000 {
+			return nil, fmt.Errorf("too large: %v", v)
+		}
+		return v, nil
+	}, opts)
+	if err == nil || !strings.Contains(err.Error(), "position 5000") {
+		t.Errorf("Expected failure at position 5000, got %v", err)
+	}
+}
+
+func TestParallelApplyRowsMatchesApplyRows(t *testing.T) {
+	records := make([][]interface{}, 5000)
+	for i := range records {
+		var b interface{} = float64(i%97) / 4
+		if i%11 == 0 {
+			b = nil
+		}
+		records[i] = []interface{}{int64((i * 7919) % 5000), b}
+	}
+	df, _ := dataframe.FromRecords(records, []string{"a", "b"})
+	// Sorting leaves the row labels unordered
+	df, _ = df.SortByColumns([]string{"b", "a"}, nil)
+
+	fn := func(row dataframe.Row) interface{} {
+		a, _ := row.GetInt("a")
+		if a%5 == 0 {
+			return nil
+		}
+		b, err := row.GetFloat("b")
+		if err != nil {
+			return "missing"
+		}
+		if b > 10 {
+			return a
+		}
+		return float64(a) * b
+	}
+	serial, err := df.ApplyRows(fn, "c")
+	if err != nil {
+		t.Fatalf("ApplyRows error: %v", err)
+	}
+	for _, workers := range []int{1, 3, 8} {
+		parallel, err := df.ParallelApplyRows(fn, "c", dataframe.ParallelOptions{NumWorkers: workers})
+		if err != nil {
+			t.Fatalf("ParallelApplyRows error: %v", err)
+		}
+		if !reflect.DeepEqual(parallel.ToRecords(), serial.ToRecords()) {
+			t.Fatalf("%d workers: ParallelApplyRows differs from ApplyRows", workers)
+		}
+		if !parallel.Index().Equals(serial.Index()) {
+			t.Errorf("%d workers: index differs from ApplyRows", workers)
+		}
+		sc, _ := serial.GetSeries("c")
+		pc, _ := parallel.GetSeries("c")
+		if pc.DType() != sc.DType() {
+			t.Errorf("%d workers: dtype %v, want %v", workers, pc.DType(), sc.DType())
+		}
+	}
+
+	tried, err := df.ParallelTryApplyRows(func(row dataframe.Row) (interface{}, error) {
+		return fn(row), nil
+	}, "c", dataframe.ParallelOptions{NumWorkers: 4})
+	if err != nil || !reflect.DeepEqual(tried.ToRecords(), serial.ToRecords()) {
+		t.Errorf("ParallelTryApplyRows differs from ApplyRows: %v", err)
+	}
+
+	_, err = df.ParallelTryApplyRows(func(row dataframe.Row) (interface{}, error) {
+		b, err := row.GetFloat("b")
+		return b, err
+	}, "c", dataframe.ParallelOptions{NumWorkers: 4, AllErrors: true})
+	// The 455 rows with NA in b are sorted last
+	if err == nil || !strings.HasPrefix(err.Error(), "apply failed at position 4545:") {
+		t.Fatalf("Expected the first failure at position 4545, got %v", err)
+	}
+	if n := strings.Count(err.Error(), "apply failed"); n != 455 {
+		t.Errorf("Expected 455 failures, got %d", n)
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	data := make([]interface{}, 10000)
+	for i := range data {
+		if i%7 == 0 {
+			data[i] = nil
+		} else {
+			data[i] = float64(i % 100)
+		}
+	}
+	s := dataframe.NewSeries(data, "values")
+
+	countAbove := func(acc, v interface{}) interface{} {
+		if f, ok := v.(float64); ok && f > 50 {
+			return acc.(int) + 1
+		}
+		return acc
+	}
+	add := func(a, b interface{}) interface{} { return a.(int) + b.(int) }
+	want := s.Reduce(0, countAbove)
+	for _, workers := range []int{1, 3, 8} {
+		if got := s.ParallelReduce(0, countAbove, add, dataframe.ParallelOptions{NumWorkers: workers}); got != want {
+			t.Errorf("%d workers: ParallelReduce = %v, Reduce = %v", workers, got, want)
+		}
+	}
+
+	// Histogram of the values in buckets of 10; the map is created per chunk
+	bucket := func(acc, v interface{}) interface{} {
+		if acc == nil {
+			acc = map[int]int{}
+		}
+		if v == nil {
+			t.Fatal("Expected NA values to be skipped")
+		}
+		acc.(map[int]int)[int(v.(float64))/10]++
+		return acc
+	}
+	merge := func(a, b interface{}) interface{} {
+		for k, n := range b.(map[int]int) {
+			a.(map[int]int)[k] += n
+		}
+		return a
+	}
+	hist := s.ParallelReduce(nil, bucket, merge, dataframe.ParallelOptions{NumWorkers: 4, SkipNA: true}).(map[int]int)
+	total := 0
+	for _, n := range hist {
+		total += n
+	}
+	if len(hist) != 10 || total != s.Count() {
+		t.Errorf("Expected 10 buckets holding %d values, got %v", s.Count(), hist)
+	}
+
+	empty := dataframe.NewSeries([]interface{}{}, "empty")
+	if got := empty.ParallelReduce(0, countAbove, add); got != 0 {
+		t.Errorf("Expected init for an empty Series, got %v", got)
+	}
+}
+
+func TestParallelTryApplyAllErrors(t *testing.T) {
+	data := make([]interface{}, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	s := dataframe.NewSeries(data, "values")
+	errOdd := errors.New("odd")
+	fn := func(v interface{}) (interface{}, error) {
+		if v.(int)%250 == 1 {
+			return nil, errOdd
+		}
+		return v, nil
+	}
+
+	opts := dataframe.ParallelOptions{NumWorkers: 4, AllErrors: true}
+	_, err := s.ParallelTryApply(fn, opts)
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("Expected errOdd, got %v", err)
+	}
+	want := "apply failed at position 1: odd\n" +
+		"apply failed at position 251: odd\n" +
+		"apply failed at position 501: odd\n" +
+		"apply failed at position 751: odd"
+	if err.Error() != want {
+		t.Errorf("Expected all errors in position order, got %q", err.Error())
+	}
+
+	_, err = s.ParallelTryApply(fn, dataframe.ParallelOptions{NumWorkers: 4})
+	if err == nil || err.Error() != "apply failed at position 1: odd" {
+		t.Errorf("Expected only the first error, got %v", err)
+	}
+}
+
+func TestParallelTryFilterAndTransform(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"},
+	}, []string{"n", "s"})
+	opts := dataframe.ParallelOptions{NumWorkers: 2}
+
+	filtered, err := df.ParallelTryFilter(func(row dataframe.Row) (bool, error) {
+		v, err := row.GetInt("n")
+		return v%2 == 0, err
+	}, opts)
+	if err != nil {
+		t.Fatalf("ParallelTryFilter error: %v", err)
+	}
+	if filtered.Shape()[0] != 2 {
+		t.Errorf("Expected 2 rows, got %d", filtered.Shape()[0])
+	}
+
+	_, err = df.ParallelTryFilter(func(row dataframe.Row) (bool, error) {
+		_, err := row.GetInt("s")
+		return true, err
+	}, opts)
+	if err == nil || !strings.HasPrefix(err.Error(), "filter failed at position 0:") {
+		t.Errorf("Expected failure at position 0, got %v", err)
+	}
+
+	_, err = df.ParallelTryTransform(func(s *dataframe.Series) (*dataframe.Series, error) {
+		if s.DType() != dataframe.DTypeInt64 {
+			return nil, fmt.Errorf("not numeric")
+		}
+		return s, nil
+	}, opts)
+	if err == nil || err.Error() != "transform failed for column 's': not numeric" {
+		t.Errorf("Expected failure for column 's', got %v", err)
+	}
+}
+
+// recoverPanicError runs fn and returns the *PanicError it panics with.
+func recoverPanicError(t *testing.T, fn func()) (pe *dataframe.PanicError) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		var ok bool
+		if pe, ok = r.(*dataframe.PanicError); !ok {
+			t.Fatalf("Expected a *PanicError panic, got %v", r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestParallelPanicContainment(t *testing.T) {
+	data := make([]interface{}, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	s := dataframe.NewSeries(data, "values")
+	opts := dataframe.ParallelOptions{NumWorkers: 4}
+	boom := func(v interface{}) interface{} {
+		if v.(int) == 600 {
+			panic("boom")
+		}
+		return v
+	}
+
+	pe := recoverPanicError(t, func() { s.ParallelApply(boom, opts) })
+	if pe.Position != 600 || pe.Value != "boom" || len(pe.Stack) == 0 {
+		t.Errorf("Unexpected panic error: %+v", pe)
+	}
+
+	_, err := s.ParallelTryApply(func(v interface{}) (interface{}, error) {
+		return boom(v), nil
+	}, opts)
+	if !errors.As(err, &pe) || pe.Position != 600 {
+		t.Fatalf("Expected *PanicError at position 600, got %v", err)
+	}
+	if err.Error() != "panic at position 600: boom" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{{1, 2}, {3, 4}}, []string{"a", "b"})
+	pe = recoverPanicError(t, func() {
+		df.ParallelTransform(func(s *dataframe.Series) *dataframe.Series {
+			if s.Name() == "b" {
+				panic("bad column")
+			}
+			return s
+		}, dataframe.ParallelOptions{NumWorkers: 2})
+	})
+	if pe.Position != 1 {
+		t.Errorf("Expected column position 1, got %d", pe.Position)
+	}
+
+	pe = recoverPanicError(t, func() {
+		df.ParallelFilter(func(row dataframe.Row) bool { panic("bad row") }, dataframe.ParallelOptions{NumWorkers: 2})
+	})
+	if pe.Value != "bad row" {
+		t.Errorf("Expected panic value 'bad row', got %v", pe.Value)
+	}
+
+	_, err = df.ParallelTryTransform(func(s *dataframe.Series) (*dataframe.Series, error) {
+		panic("bad column")
+	}, dataframe.ParallelOptions{NumWorkers: 2, AllErrors: true})
+	if err == nil || !strings.Contains(err.Error(), "transform failed for column 'b': panic at position 1: bad column") {
+		t.Errorf("Expected panic for column 'b', got %v", err)
+	}
+}
+
+// waitForGoroutines waits until at most n goroutines are running, so tests
+// can check that cancelled work leaves no workers behind.
+func waitForGoroutines(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected at most %d goroutines, got %d", n, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestParallelCtxCancellation(t *testing.T) {
+	data := make([]interface{}, 200000)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	s := dataframe.NewSeries(data, "values")
+	df, _ := dataframe.FromRecords([][]interface{}{{"a", 1.0}, {"b", 2.0}, {"a", 3.0}}, []string{"k", "v"})
+	opts := dataframe.ParallelOptions{NumWorkers: 4}
+	before := runtime.NumGoroutine()
+
+	// Uncancelled calls match the plain variants
+	doubled, err := s.ParallelApplyCtx(context.Background(), func(v interface{}) interface{} { return v.(float64) * 2 }, opts)
+	if err != nil {
+		t.Fatalf("ParallelApplyCtx failed: %v", err)
+	}
+	if v, _ := doubled.Get(199999); v != 399998.0 {
+		t.Errorf("Expected 399998, got %v", v)
+	}
+	filtered, err := df.ParallelFilterCtx(context.Background(), func(r dataframe.Row) bool {
+		k, _ := r.GetString("k")
+		return k == "a"
+	}, opts)
+	if err != nil || !reflect.DeepEqual(filtered.Index().Labels(), []interface{}{0, 2}) {
+		t.Errorf("Unexpected ParallelFilterCtx result %v (%v)", filtered, err)
+	}
+
+	// Cancelling mid-flight stops every worker and returns the context error
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int64
+	_, err = s.ParallelApplyCtx(ctx, func(v interface{}) interface{} {
+		if calls.Add(1) == 1000 {
+			cancel()
+		}
+		return v
+	}, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if n := calls.Load(); n >= int64(len(data)) {
+		t.Errorf("Expected cancellation to stop the workers early, got %d calls", n)
+	}
+
+	// An already cancelled context does no work
+	calls.Store(0)
+	if _, err := df.ParallelFilterCtx(ctx, func(dataframe.Row) bool { calls.Add(1); return true }, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from ParallelFilterCtx, got %v", err)
+	}
+	if _, err := df.ParallelTransformCtx(ctx, func(s *dataframe.Series) *dataframe.Series { calls.Add(1); return s }, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from ParallelTransformCtx, got %v", err)
+	}
+	gb, _ := df.GroupBy("k")
+	if _, err := gb.ParallelAggCtx(ctx, map[string][]dataframe.AggFunc{"v": {dataframe.AggSum}}, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from ParallelAggCtx, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("Expected no work after cancellation, got %d calls", calls.Load())
+	}
+
+	waitForGoroutines(t, before)
+}
+
+func TestParallelCtxPromptCancellation(t *testing.T) {
+	data := make([]interface{}, 4000)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	s := dataframe.NewSeries(data, "values")
+	df, _ := dataframe.New(map[string][]interface{}{"values": data})
+	opts := dataframe.ParallelOptions{NumWorkers: 2, ChunkSize: 1}
+
+	// With a 1ms callback the work takes about 2s; a cancellation after 20ms
+	// must stop the workers within a few calls
+	const bound = 500 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := s.ParallelApplyCtx(ctx, func(v interface{}) interface{} {
+		time.Sleep(time.Millisecond)
+		return v
+	}, opts)
+	if elapsed := time.Since(start); elapsed > bound {
+		t.Errorf("ParallelApplyCtx took %v to return after cancellation, want under %v", elapsed, bound)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = df.ParallelFilterCtx(ctx, func(dataframe.Row) bool {
+		time.Sleep(time.Millisecond)
+		return true
+	}, opts)
+	if elapsed := time.Since(start); elapsed > bound {
+		t.Errorf("ParallelFilterCtx took %v to return after cancellation, want under %v", elapsed, bound)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestParallelReadCSVCtx(t *testing.T) {
+	paths := make([]string, 50)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file%d.csv", i)
+	}
+	type ctxKey struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "request"))
+	defer cancel()
+	before := runtime.NumGoroutine()
+
+	var reads atomic.Int64
+	read := func(ctx context.Context, path string) (*dataframe.DataFrame, error) {
+		if ctx.Value(ctxKey{}) != "request" {
+			return nil, fmt.Errorf("readFunc did not get the caller's context")
+		}
+		if reads.Add(1) == 5 {
+			cancel()
+		}
+		return dataframe.FromRecords([][]interface{}{{path}}, []string{"path"})
+	}
+	_, err := dataframe.ParallelReadCSVCtx(ctx, paths, read, dataframe.ParallelOptions{NumWorkers: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if n := reads.Load(); n >= int64(len(paths)) {
+		t.Errorf("Expected no reads to start after cancellation, got %d reads", n)
+	}
+	waitForGoroutines(t, before)
+
+	df, err := dataframe.ParallelReadCSVCtx(context.WithValue(context.Background(), ctxKey{}, "request"), paths[:3], read)
+	if err != nil {
+		t.Fatalf("ParallelReadCSVCtx failed: %v", err)
+	}
+	if v, _ := df.At(2, "path"); v != "file2.csv" {
+		t.Errorf("Expected files in input order, got %v", v)
+	}
+}
+
+func TestParallelReadCSVErrors(t *testing.T) {
+	paths := []string{"a.csv", "bad1.csv", "c.csv", "bad2.csv", "e.csv"}
+	errMalformed := errors.New("malformed")
+	read := func(path string) (*dataframe.DataFrame, error) {
+		df, _ := dataframe.FromRecords([][]interface{}{{path}}, []string{"path"})
+		if strings.HasPrefix(path, "bad") {
+			// The frame read before failing must not reach the result
+			return df, errMalformed
+		}
+		return df, nil
+	}
+	opts := dataframe.ParallelOptions{NumWorkers: 3}
+
+	df, err := dataframe.ParallelReadCSV(paths, read, opts)
+	if df != nil {
+		t.Errorf("Expected no result without AllowPartial, got %v", df)
+	}
+	want := "failed to read 'bad1.csv': malformed\nfailed to read 'bad2.csv': malformed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Expected errors for both files, got %v", err)
+	}
+	if !errors.Is(err, errMalformed) {
+		t.Errorf("Expected the joined error to wrap the read error")
+	}
+
+	opts.AllowPartial = true
+	df, err = dataframe.ParallelReadCSV(paths, read, opts)
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected errors for both files, got %v", err)
+	}
+	if df == nil {
+		t.Fatal("Expected the successful frames with AllowPartial")
+	}
+	got, _ := df.GetSeries("path")
+	if !reflect.DeepEqual(got.Values(), []interface{}{"a.csv", "c.csv", "e.csv"}) {
+		t.Errorf("Expected successful files in input order, got %v", got.Values())
+	}
+}
+
+func TestParallelReadCSVOrder(t *testing.T) {
+	paths := make([]string, 8)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file%d.csv", i)
+	}
+	// Later files finish first, so completion order is the reverse of input order
+	read := func(path string) (*dataframe.DataFrame, error) {
+		var i int
+		fmt.Sscanf(path, "file%d.csv", &i)
+		time.Sleep(time.Duration(len(paths)-i) * 2 * time.Millisecond)
+		return dataframe.FromRecords([][]interface{}{{path}}, []string{"path"})
+	}
+
+	df, err := dataframe.ParallelReadCSV(paths, read, dataframe.ParallelOptions{NumWorkers: len(paths)})
+	if err != nil {
+		t.Fatalf("ParallelReadCSV failed: %v", err)
+	}
+	got, _ := df.GetSeries("path")
+	want := make([]interface{}, len(paths))
+	for i, p := range paths {
+		want[i] = p
+	}
+	if !reflect.DeepEqual(got.Values(), want) {
+		t.Errorf("Expected files in input order, got %v", got.Values())
+	}
+}
+
+func TestPool(t *testing.T) {
+	before := runtime.NumGoroutine()
+	pool := dataframe.NewPool(4)
+	if pool.Size() != 4 {
+		t.Errorf("Expected 4 workers, got %d", pool.Size())
+	}
+	opts := dataframe.ParallelOptions{NumWorkers: 4}
+	pooled := dataframe.ParallelOptions{NumWorkers: 4, Pool: pool}
+
+	data := make([]interface{}, 10000)
+	groups := make([]interface{}, len(data))
+	for i := range data {
+		data[i] = i
+		groups[i] = i % 13
+	}
+	s := dataframe.NewSeries(data, "values")
+	double := func(v interface{}) interface{} { return v.(int) * 2 }
+	if got, want := s.ParallelApply(double, pooled).Values(), s.ParallelApply(double, opts).Values(); !reflect.DeepEqual(got, want) {
+		t.Error("ParallelApply with a pool differs from without")
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{{1, 2.0}, {2, 4.0}, {3, 6.0}}, []string{"a", "b"})
+	odd := func(row dataframe.Row) bool {
+		v, _ := row.GetInt("a")
+		return v%2 == 1
+	}
+	if got, want := df.ParallelFilter(odd, pooled), df.ParallelFilter(odd, opts); !reflect.DeepEqual(got.ToRecords(), want.ToRecords()) {
+		t.Error("ParallelFilter with a pool differs from without")
+	}
+
+	// Operations nested in tasks of the same pool run without waiting for a free worker
+	transformed := df.ParallelTransform(func(col *dataframe.Series) *dataframe.Series {
+		return col.ParallelApply(func(v interface{}) interface{} { return v }, pooled)
+	}, pooled)
+	if !reflect.DeepEqual(transformed.ToRecords(), df.ToRecords()) {
+		t.Error("Nested ParallelTransform with a pool changed the values")
+	}
+
+	grouped, _ := dataframe.New(map[string][]interface{}{"g": groups, "v": data})
+	gb, _ := grouped.GroupBy("g")
+	aggFuncs := map[string][]dataframe.AggFunc{"v": {dataframe.AggSum, dataframe.AggCount}}
+	got, err := gb.ParallelAgg(aggFuncs, pooled)
+	if err != nil {
+		t.Fatalf("ParallelAgg with a pool failed: %v", err)
+	}
+	want, _ := gb.Agg(aggFuncs)
+	if !reflect.DeepEqual(got.ToRecords(), want.ToRecords()) {
+		t.Error("ParallelAgg with a pool differs from Agg")
+	}
+
+	pool.Close()
+	pool.Close()
+	waitForGoroutines(t, before)
+}
+
+func benchmarkSmallParallelApply(b *testing.B, pool *dataframe.Pool) {
+	data := make([]interface{}, 256)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	s := dataframe.NewSeries(data, "values")
+	opts := dataframe.ParallelOptions{NumWorkers: 4, Pool: pool}
+	fn := func(v interface{}) interface{} { return v }
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.ParallelApply(fn, opts)
+	}
+}
+
+// Run with -benchtime=10000x to time 10k small calls.
+func BenchmarkParallelApplySmall(b *testing.B) {
+	benchmarkSmallParallelApply(b, nil)
+}
+
+func BenchmarkParallelApplySmallPool(b *testing.B) {
+	pool := dataframe.NewPool(4)
+	defer pool.Close()
+	benchmarkSmallParallelApply(b, pool)
+}
+
+func TestParallelProgressHooks(t *testing.T) {
+	data := make([]interface{}, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	s := dataframe.NewSeries(data, "values")
+
+	// Hooks run one at a time, so they need no locking; -race checks this
+	var calls, lastDone int
+	opts := dataframe.ParallelOptions{
+		NumWorkers: 4,
+		OnProgress: func(done, total int) {
+			if done <= lastDone || total != s.Len() {
+				t.Errorf("Unexpected progress %d/%d after %d", done, total, lastDone)
+			}
+			calls++
+			lastDone = done
+		},
+	}
+	result, stats := s.ParallelApplyStats(func(v interface{}) interface{} { return v }, opts)
+	if result.Len() != s.Len() {
+		t.Fatalf("Expected %d values, got %d", s.Len(), result.Len())
+	}
+	if calls != 4 || lastDone != s.Len() {
+		t.Errorf("Expected 4 progress calls ending at %d, got %d ending at %d", s.Len(), calls, lastDone)
+	}
+	if stats.Workers != 4 || stats.Chunks != 4 || stats.Elapsed <= 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	workerErrors := map[int]string{}
+	_, err := s.ParallelTryApply(func(v interface{}) (interface{}, error) {
+		if v.(int)%2500 == 10 {
+			return nil, fmt.Errorf("bad %v", v)
+		}
+		return v, nil
+	}, dataframe.ParallelOptions{
+		NumWorkers: 4,
+		OnWorkerError: func(worker int, err error) {
+			workerErrors[worker] = err.Error()
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	want := map[int]string{0: "bad 10", 1: "bad 2510", 2: "bad 5010", 3: "bad 7510"}
+	if !reflect.DeepEqual(workerErrors, want) {
+		t.Errorf("Expected worker errors %v, got %v", want, workerErrors)
+	}
+}
+
+func TestParallelProgressHooksColumnOperations(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{{1.0, 2.0, 3.0, 4.0}, {5.0, 6.0, 7.0, 8.0}}, []string{"a", "b", "c", "d"})
+	s := dataframe.NewSeries([]interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, "s")
+
+	// Every operation reports its columns, Series, files or chunks as done
+	cases := []struct {
+		name  string
+		total int
+		run   func(dataframe.ParallelOptions)
+	}{
+		{"ParallelTransform", 4, func(o dataframe.ParallelOptions) {
+			df.ParallelTransform(func(s *dataframe.Series) *dataframe.Series { return s }, o)
+		}},
+		{"ParallelSum", 4, func(o dataframe.ParallelOptions) { df.ParallelSum(o) }},
+		{"ParallelMax", 4, func(o dataframe.ParallelOptions) { df.ParallelMax(o) }},
+		{"ParallelMapSeries", 3, func(o dataframe.ParallelOptions) {
+			dataframe.ParallelMapSeries([]*dataframe.Series{s, s, s}, func(s *dataframe.Series) *dataframe.Series { return s }, o)
+		}},
+		{"ParallelReadCSV", 3, func(o dataframe.ParallelOptions) {
+			dataframe.ParallelReadCSV([]string{"a", "b", "c"}, func(string) (*dataframe.DataFrame, error) { return df, nil }, o)
+		}},
+		{"ParallelChunkedApply", 4, func(o dataframe.ParallelOptions) {
+			s.ParallelChunkedApply(func(c []interface{}) []interface{} { return c }, 3, o)
+		}},
+	}
+	for _, c := range cases {
+		var lastDone, lastTotal int
+		c.run(dataframe.ParallelOptions{NumWorkers: 2, OnProgress: func(done, total int) { lastDone, lastTotal = done, total }})
+		if lastDone != c.total || lastTotal != c.total {
+			t.Errorf("%s: progress ended at %d/%d, want %d/%d", c.name, lastDone, lastTotal, c.total, c.total)
+		}
+	}
+
+	// ParallelReadCSV reports the failures of each worker
+	var workerErrors []string
+	_, err := dataframe.ParallelReadCSV([]string{"a", "b", "c", "d"}, func(path string) (*dataframe.DataFrame, error) {
+		if path == "b" || path == "d" {
+			return nil, fmt.Errorf("missing")
+		}
+		return df, nil
+	}, dataframe.ParallelOptions{NumWorkers: 2, OnWorkerError: func(worker int, err error) {
+		workerErrors = append(workerErrors, fmt.Sprintf("%d: %v", worker, err))
+	}})
+	if err == nil {
+		t.Fatal("Expected a read error")
+	}
+	sort.Strings(workerErrors)
+	want := []string{"0: failed to read 'b': missing", "1: failed to read 'd': missing"}
+	if !reflect.DeepEqual(workerErrors, want) {
+		t.Errorf("Expected worker errors %v, got %v", want, workerErrors)
+	}
+}
+
+func TestParallelAggStats(t *testing.T) {
+	groups := make([]interface{}, 1000)
+	values := make([]interface{}, len(groups))
+	for i := range groups {
+		groups[i] = i % 10
+		values[i] = float64(i)
+	}
+	df, _ := dataframe.New(map[string][]interface{}{"g": groups, "v": values})
+	gb, _ := df.GroupBy("g")
+
+	var progressed int
+	result, stats, err := gb.ParallelAggStats(map[string][]dataframe.AggFunc{"v": {dataframe.AggSum}}, dataframe.ParallelOptions{
+		NumWorkers: 3,
+		OnProgress: func(done, total int) { progressed = done },
+	})
+	if err != nil {
+		t.Fatalf("ParallelAggStats failed: %v", err)
+	}
+	if result.Shape()[0] != 10 || progressed != 10 {
+		t.Errorf("Expected 10 groups done, got %d rows and progress %d", result.Shape()[0], progressed)
+	}
+	if stats.Workers != 3 || stats.Chunks != 3 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestParallelValueCountsUnique(t *testing.T) {
+	// Above the serial threshold, with values of mixed types and NA
+	data := make([]interface{}, 300000)
+	for i := range data {
+		switch i % 5 {
+		case 0:
+			data[i] = int64(i % 1000)
+		case 1:
+			data[i] = strconv.Itoa(i % 1000)
+		case 2:
+			data[i] = float64(i%700) / 2
+		case 3:
+			data[i] = i % 1000
+		default:
+			if i%3 == 0 {
+				data[i] = nil
+			} else {
+				data[i] = math.NaN()
+			}
+		}
+	}
+	s := dataframe.NewSeries(data, "id")
+	opts := dataframe.ParallelOptions{NumWorkers: 4}
+
+	unique := s.Unique()
+	parallelUnique := s.ParallelUnique(opts)
+	if fmt.Sprint(parallelUnique.Values()) != fmt.Sprint(unique.Values()) {
+		t.Errorf("ParallelUnique differs from Unique")
+	}
+
+	counts := s.ValueCounts()
+	parallelCounts := s.ParallelValueCounts(opts)
+	if !reflect.DeepEqual(parallelCounts.Values(), counts.Values()) || fmt.Sprint(parallelCounts.Index().Labels()) != fmt.Sprint(counts.Index().Labels()) {
+		t.Errorf("ParallelValueCounts differs from ValueCounts")
+	}
+	total := 0
+	for _, c := range parallelCounts.Values() {
+		total += c.(int)
+	}
+	if total != s.Len() {
+		t.Errorf("Expected counts to sum to %d, got %d", s.Len(), total)
+	}
+
+	// Below the threshold the serial path is used
+	small := dataframe.NewSeries([]interface{}{"b", "a", "b"}, "v")
+	if got := small.ParallelValueCounts(opts).Values(); !reflect.DeepEqual(got, []interface{}{2, 1}) {
+		t.Errorf("Expected counts [2 1], got %v", got)
+	}
+}
+
+func benchmarkValueCounts(b *testing.B, n int, parallel bool) {
+	// A high-cardinality id column where every id occurs about four times
+	data := make([]interface{}, n)
+	for i := range data {
+		data[i] = int64((i * 7919) % (n / 4))
+	}
+	s := dataframe.NewSeries(data, "id")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if parallel {
+			s.ParallelValueCounts()
+		} else {
+			s.ValueCounts()
+		}
+	}
+}
+
+func BenchmarkValueCounts10M(b *testing.B) {
+	benchmarkValueCounts(b, 10000000, false)
+}
+
+func BenchmarkParallelValueCounts10M(b *testing.B) {
+	benchmarkValueCounts(b, 10000000, true)
+}
+
+// The 100M benchmarks need several GB of memory.
+func BenchmarkValueCounts100M(b *testing.B) {
+	benchmarkValueCounts(b, 100000000, false)
+}
+
+func BenchmarkParallelValueCounts100M(b *testing.B) {
+	benchmarkValueCounts(b, 100000000, true)
+}
+
+func TestParallelDescribe(t *testing.T) {
+	const n = 1000
+	cols := []string{"a", "name", "b", "empty", "mixed"}
+	records := make([][]interface{}, n)
+	for i := range records {
+		var mixed interface{} = float64(i) / 3
+		if i%4 == 0 {
+			mixed = "x"
+		}
+		var b interface{} = int64(i * i % 97)
+		if i%10 == 0 {
+			b = nil
+		}
+		records[i] = []interface{}{float64(i) * 1.5, "n" + strconv.Itoa(i), b, nil, mixed}
+	}
+	df, _ := dataframe.FromRecords(records, cols)
+
+	desc := df.Describe()
+	parallelDesc := df.ParallelDescribe(dataframe.ParallelOptions{NumWorkers: 3})
+	if !reflect.DeepEqual(parallelDesc.Columns(), desc.Columns()) {
+		t.Fatalf("Expected columns %v, got %v", desc.Columns(), parallelDesc.Columns())
+	}
+	// The non-numeric column is skipped, the others keep their order
+	wantRows := []interface{}{"a", "b", "empty", "mixed"}
+	if got := parallelDesc.Index().Labels(); !reflect.DeepEqual(got, wantRows) {
+		t.Fatalf("Expected rows %v, got %v", wantRows, got)
+	}
+	for _, col := range desc.Columns() {
+		want, _ := desc.GetSeries(col)
+		got, _ := parallelDesc.GetSeries(col)
+		for i := 0; i < want.Len(); i++ {
+			w, _ := want.Get(i)
+			g, _ := got.Get(i)
+			wf, gf := w.(float64), g.(float64)
+			if math.IsNaN(wf) != math.IsNaN(gf) || math.Abs(wf-gf) > 1e-9*math.Max(1, math.Abs(wf)) {
+				t.Errorf("%s of %v: expected %v, got %v", col, wantRows[i], wf, gf)
+			}
+		}
+	}
+}
+
+func benchmarkDescribe(b *testing.B, parallel bool) {
+	const rows, numCols = 1000000, 8
+	cols := make([]string, numCols)
+	records := make([][]interface{}, rows)
+	for c := range cols {
+		cols[c] = "c" + strconv.Itoa(c)
+	}
+	for i := range records {
+		records[i] = make([]interface{}, numCols)
+		for c := range cols {
+			records[i][c] = float64((i*(c+7919))%100003) / 7
+		}
+	}
+	df, _ := dataframe.FromRecords(records, cols)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if parallel {
+			df.ParallelDescribe()
+		} else {
+			df.Describe()
+		}
+	}
+}
+
+func BenchmarkDescribe1M(b *testing.B) {
+	benchmarkDescribe(b, false)
+}
+
+func BenchmarkParallelDescribe1M(b *testing.B) {
+	benchmarkDescribe(b, true)
+}
+
+func TestParallelAggSeries(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1.0, "x", nil, 2},
+		{2.0, "y", nil, -2},
+		{4.0, "z", nil, nil},
+	}, []string{"a", "name", "empty", "zero"})
+	opts := dataframe.ParallelOptions{NumWorkers: 3}
+
+	sums := df.ParallelSumSeries(opts)
+	if got := sums.Index().Labels(); !reflect.DeepEqual(got, []interface{}{"a", "name", "empty", "zero"}) {
+		t.Fatalf("Expected labels in column order, got %v", got)
+	}
+	// Columns without numeric values are nil, unlike a sum of 0
+	if got := sums.Values(); !reflect.DeepEqual(got, []interface{}{7.0, nil, nil, 0.0}) {
+		t.Errorf("Unexpected sums %v", got)
+	}
+	if sums.Name() != "sum" || sums.DType() != dataframe.DTypeFloat64 {
+		t.Errorf("Unexpected name %q or dtype %v", sums.Name(), sums.DType())
+	}
+	if got := df.ParallelMeanSeries(opts).Values(); !reflect.DeepEqual(got, []interface{}{7.0 / 3, nil, nil, 0.0}) {
+		t.Errorf("Unexpected means %v", got)
+	}
+	if got := df.ParallelMinSeries(opts).Values(); !reflect.DeepEqual(got, []interface{}{1.0, nil, nil, -2.0}) {
+		t.Errorf("Unexpected minimums %v", got)
+	}
+	if got := df.ParallelMaxSeries(opts).Values(); !reflect.DeepEqual(got, []interface{}{4.0, nil, nil, 2.0}) {
+		t.Errorf("Unexpected maximums %v", got)
+	}
+
+	// The map forms agree on the columns with numeric values
+	if m := df.ParallelSum(opts); m["a"] != 7 || m["empty"] != 0 {
+		t.Errorf("Unexpected ParallelSum %v", m)
+	}
+}
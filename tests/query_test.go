@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BAIGUANGMEI/datago/dataframe"
+)
+
+func newQueryFrame(t *testing.T) *dataframe.DataFrame {
+	t.Helper()
+	df, err := dataframe.FromRecords([][]interface{}{
+		{"Alice", 25, "Berlin", 2.0, 3},
+		{"Bob", 35, "Berlin", 1.5, 4},
+		{"Carol", 40, "Paris", 3.0, nil},
+		{"Dave", 31, "Rome", 4.0, 1},
+	}, []string{"name", "age", "city", "price", "qty"})
+	if err != nil {
+		t.Fatalf("FromRecords() error: %v", err)
+	}
+	return df
+}
+
+func TestQuery(t *testing.T) {
+	df := newQueryFrame(t)
+
+	cases := []struct {
+		expr string
+		want int
+	}{
+		{"age > 30 && city == 'Berlin'", 1},
+		{"age > 30 || city == \"Berlin\"", 4},
+		{"!(age >= 35)", 2},
+		{"city in ['Paris', 'Rome']", 2},
+		{"qty == nil", 1},
+		{"price * qty > 5", 2},
+		{"age - 5 <= 20", 1},
+	}
+	for _, tc := range cases {
+		result, err := df.Query(tc.expr)
+		if err != nil {
+			t.Errorf("Query(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if result.Shape()[0] != tc.want {
+			t.Errorf("Query(%q) rows = %d, want %d", tc.expr, result.Shape()[0], tc.want)
+		}
+	}
+
+	result, _ := df.Query("city == 'Paris'")
+	if label, _ := result.Index().Get(0); label != 2 {
+		t.Errorf("Query() kept label = %v, want 2", label)
+	}
+
+	if _, err := df.Query("salary > 10"); err == nil || !strings.Contains(err.Error(), "salary") {
+		t.Errorf("Query() unknown column error = %v", err)
+	}
+	if _, err := df.Query("age >"); err == nil {
+		t.Errorf("Query() expected syntax error")
+	}
+}
+
+func TestQuerySeriesNAStrings(t *testing.T) {
+	// "NA" is Namibia here, not a missing value
+	df, _ := dataframe.FromRecords([][]interface{}{{"NA", 1}, {"DE", 2}, {nil, 3}}, []string{"country", "v"})
+	country, _ := df.GetSeries("country")
+	country.SetNAStrings(nil)
+
+	cases := []struct {
+		expr string
+		want []interface{}
+	}{
+		{"country == 'NA'", []interface{}{1}},
+		{"country in ['NA']", []interface{}{1}},
+		{"country != 'NA'", []interface{}{2, 3}},
+		{"country == nil", []interface{}{3}},
+	}
+	for _, tc := range cases {
+		result, err := df.Query(tc.expr)
+		if err != nil {
+			t.Fatalf("Query(%q) error: %v", tc.expr, err)
+		}
+		if v, _ := result.GetSeries("v"); !reflect.DeepEqual(v.Values(), tc.want) {
+			t.Errorf("Query(%q) = %v, want %v", tc.expr, v.Values(), tc.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	df := newQueryFrame(t)
+	total, err := df.Eval("price * qty")
+	if err != nil {
+		t.Fatalf("Eval() error: %v", err)
+	}
+	if v, _ := total.Get(1); v != 6.0 {
+		t.Errorf("Eval() total[1] = %v, want 6", v)
+	}
+	if v, _ := total.Get(2); v != nil {
+		t.Errorf("Eval() with NA operand = %v, want nil", v)
+	}
+}
+
+func TestFilterExpr(t *testing.T) {
+	df := newQueryFrame(t)
+	col := dataframe.Col
+
+	cases := []struct {
+		name string
+		cond dataframe.Condition
+		want []string
+	}{
+		{"and", col("age").Gt(30).And(col("city").Eq("Berlin")), []string{"Bob"}},
+		{"or", col("age").Lt(30).Or(col("city").Eq("Rome")), []string{"Alice", "Dave"}},
+		{"ge le", col("age").Ge(31).And(col("age").Le(35)), []string{"Bob", "Dave"}},
+		{"ne", col("city").Ne("Berlin"), []string{"Carol", "Dave"}},
+		{"isin", col("city").IsIn("Paris", "Rome"), []string{"Carol", "Dave"}},
+		{"isnull", col("qty").IsNull(), []string{"Carol"}},
+		{"not", col("qty").IsNull().Not(), []string{"Alice", "Bob", "Dave"}},
+		{"columns", col("qty").Gt(col("price")), []string{"Alice", "Bob"}},
+		{"null compare", col("qty").Lt(100), []string{"Alice", "Bob", "Dave"}},
+	}
+	for _, c := range cases {
+		got, err := df.FilterExpr(c.cond)
+		if err != nil {
+			t.Fatalf("%s: FilterExpr error: %v", c.name, err)
+		}
+		names, _ := got.GetSeries("name")
+		values, _ := names.Strings()
+		if strings.Join(values, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: got %v, want %v", c.name, values, c.want)
+		}
+	}
+
+	got, _ := df.FilterExpr(col("city").Eq("Paris"))
+	if label, _ := got.Index().Get(0); label != 2 {
+		t.Errorf("Expected index label 2, got %v", label)
+	}
+
+	// A literal "NA" is a plain string, as in IsIn
+	countries, _ := dataframe.FromRecords([][]interface{}{{"NA", 1}, {"DE", 2}, {nil, 3}}, []string{"country", "v"})
+	country, _ := countries.GetSeries("country")
+	country.SetNAStrings(nil)
+	for _, cond := range []dataframe.Condition{col("country").Eq("NA"), col("country").IsIn("NA")} {
+		got, err := countries.FilterExpr(cond)
+		if err != nil {
+			t.Fatalf("FilterExpr error: %v", err)
+		}
+		if v, _ := got.GetSeries("v"); !reflect.DeepEqual(v.Values(), []interface{}{1}) {
+			t.Errorf("FilterExpr country NA = %v, want [1]", v.Values())
+		}
+	}
+
+	_, err := df.FilterExpr(col("age").Gt(col("missing")))
+	if err == nil || !strings.Contains(err.Error(), "'missing'") {
+		t.Errorf("Expected unknown column error, got %v", err)
+	}
+	if _, err := df.FilterExpr(dataframe.Condition{}); err == nil {
+		t.Error("Expected error for empty condition")
+	}
+}
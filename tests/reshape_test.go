@@ -0,0 +1,221 @@
+package tests
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/BAIGUANGMEI/datago/dataframe"
+)
+
+func TestPivot(t *testing.T) {
+	data := map[string][]interface{}{
+		"date": {"d1", "d1", "d2", "d2"},
+		"city": {"Paris", "Berlin", "Paris", "Berlin"},
+		"temp": {10.0, 8.0, 12.0, 9.0},
+	}
+	df, _ := dataframe.New(data)
+
+	wide, err := df.Pivot("date", "city", "temp")
+	if err != nil {
+		t.Fatalf("Pivot() error: %v", err)
+	}
+	cols := wide.Columns()
+	if len(cols) != 3 || cols[0] != "date" || cols[1] != "Berlin" || cols[2] != "Paris" {
+		t.Fatalf("Pivot() columns = %v, want [date Berlin Paris]", cols)
+	}
+	v, _ := wide.At(1, "Paris")
+	if v != 12.0 {
+		t.Errorf("Pivot() d2/Paris = %v, want 12", v)
+	}
+
+	dup, _ := dataframe.New(map[string][]interface{}{
+		"k": {"a", "a"},
+		"c": {"x", "x"},
+		"v": {1, 2},
+	})
+	if _, err := dup.Pivot("k", "c", "v"); err == nil {
+		t.Errorf("Pivot() expected error for duplicate entries")
+	}
+}
+
+func TestPivotColumnNameCollisions(t *testing.T) {
+	// 1 and "1" would both be named "1"
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", 1, 10},
+		{"a", "1", 20},
+	}, []string{"key", "col", "val"})
+	if _, err := df.Pivot("key", "col", "val"); err == nil || !strings.Contains(err.Error(), "duplicate column '1'") {
+		t.Errorf("Expected duplicate column error, got %v", err)
+	}
+
+	// A value equal to the index column name would replace the index column
+	df, _ = dataframe.FromRecords([][]interface{}{
+		{"a", "key", 10},
+		{"a", "other", 20},
+	}, []string{"key", "col", "val"})
+	if _, err := df.Pivot("key", "col", "val"); err == nil {
+		t.Error("Expected error for a pivot value named like the index column")
+	}
+	if _, err := df.PivotTable(dataframe.PivotOptions{Index: []string{"key"}, Columns: "col", Values: "val"}); err == nil {
+		t.Error("Expected error from PivotTable too")
+	}
+
+	gb, err := df.GroupBy("key", "col")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	if _, err := gb.Unstack("col", "val", nil); err == nil {
+		t.Error("Expected error from GroupBy.Unstack too")
+	}
+}
+
+func TestPivotTable(t *testing.T) {
+	data := map[string][]interface{}{
+		"region":  {"East", "East", "West", "East"},
+		"product": {"A", "A", "B", "B"},
+		"sales":   {10.0, 20.0, 5.0, 7.0},
+	}
+	df, _ := dataframe.New(data)
+
+	table, err := df.PivotTable(dataframe.PivotOptions{
+		Index:     []string{"region"},
+		Columns:   "product",
+		Values:    "sales",
+		AggFunc:   dataframe.AggSum,
+		FillValue: 0.0,
+	})
+	if err != nil {
+		t.Fatalf("PivotTable() error: %v", err)
+	}
+	if table.Shape()[0] != 2 || table.Shape()[1] != 3 {
+		t.Fatalf("PivotTable() shape = %v, want [2 3]", table.Shape())
+	}
+	if v, _ := table.At(0, "A"); v != 30.0 {
+		t.Errorf("PivotTable() East/A = %v, want 30", v)
+	}
+	if v, _ := table.At(1, "A"); v != 0.0 {
+		t.Errorf("PivotTable() West/A = %v, want fill value 0", v)
+	}
+}
+
+func TestMeltPivotRoundTrip(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"r1", 1, 2},
+		{"r2", 3, 4},
+	}, []string{"id", "a", "b"})
+
+	long, err := df.Melt([]string{"id"}, nil, "", "")
+	if err != nil {
+		t.Fatalf("Melt() error: %v", err)
+	}
+	if long.Shape()[0] != 4 || long.Shape()[1] != 3 {
+		t.Fatalf("Melt() shape = %v, want [4 3]", long.Shape())
+	}
+
+	wide, err := long.Pivot("id", "variable", "value")
+	if err != nil {
+		t.Fatalf("Pivot() error: %v", err)
+	}
+	for _, col := range []string{"a", "b"} {
+		orig, _ := df.GetSeries(col)
+		back, _ := wide.GetSeries(col)
+		for i := 0; i < 2; i++ {
+			want, _ := orig.Get(i)
+			got, _ := back.Get(i)
+			if got != want {
+				t.Errorf("round trip %s[%d] = %v, want %v", col, i, got, want)
+			}
+		}
+	}
+}
+
+func TestGetDummies(t *testing.T) {
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{1, "red", "s"},
+		{2, "blue", "m"},
+		{3, nil, "s"},
+		{4, "red", "l"},
+	}, []string{"id", "color", "size"})
+
+	result, err := dataframe.GetDummies(df, dataframe.DummiesOptions{Columns: []string{"color"}, DummyNA: true})
+	if err != nil {
+		t.Fatalf("GetDummies failed: %v", err)
+	}
+	want := []string{"id", "size", "color_blue", "color_red", "color_nan"}
+	if got := result.Columns(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Expected columns %v, got %v", want, got)
+	}
+	red, _ := result.GetSeries("color_red")
+	nan, _ := result.GetSeries("color_nan")
+	for i, exp := range []int64{1, 0, 0, 1} {
+		if v, _ := red.Get(i); v != exp {
+			t.Errorf("color_red[%d]: expected %d, got %v", i, exp, v)
+		}
+	}
+	if v, _ := nan.Get(2); v != int64(1) {
+		t.Errorf("Expected color_nan[2] = 1, got %v", v)
+	}
+
+	result, err = dataframe.GetDummies(df, dataframe.DummiesOptions{
+		DropFirst: true,
+		Prefix:    map[string]string{"size": "sz"},
+		Separator: ":",
+	})
+	if err != nil {
+		t.Fatalf("GetDummies failed: %v", err)
+	}
+	want = []string{"id", "color:red", "sz:m", "sz:s"}
+	if got := result.Columns(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected columns %v, got %v", want, got)
+	}
+
+	if _, err := dataframe.GetDummies(df, dataframe.DummiesOptions{Columns: []string{"missing"}}); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestCrosstab(t *testing.T) {
+	region := dataframe.NewSeries([]interface{}{"east", "west", "east", "east", "west"}, "region")
+	product := dataframe.NewSeries([]interface{}{"b", "a", "a", "b", "a"}, "product")
+	sales := dataframe.NewSeries([]interface{}{10, 20, 30, 40, 50}, "sales")
+
+	result, err := dataframe.Crosstab(region, product, dataframe.CrosstabOptions{Margins: true})
+	if err != nil {
+		t.Fatalf("Crosstab failed: %v", err)
+	}
+	if got := strings.Join(result.Columns(), ","); got != "region,a,b,All" {
+		t.Fatalf("Expected columns region,a,b,All, got %s", got)
+	}
+	b, _ := result.GetSeries("b")
+	all, _ := result.GetSeries("All")
+	if v, _ := b.Get(0); v != int64(2) {
+		t.Errorf("Expected east/b count 2, got %v", v)
+	}
+	if v, _ := all.Get(2); v != int64(5) {
+		t.Errorf("Expected grand total 5, got %v", v)
+	}
+
+	result, err = dataframe.Crosstab(region, product, dataframe.CrosstabOptions{Values: sales, AggFunc: dataframe.AggSum})
+	if err != nil {
+		t.Fatalf("Crosstab with values failed: %v", err)
+	}
+	a, _ := result.GetSeries("a")
+	if v, _ := a.Get(1); v != 70.0 {
+		t.Errorf("Expected west/a sum 70, got %v", v)
+	}
+	b, _ = result.GetSeries("b")
+	if v, _ := b.Get(1); v != nil {
+		t.Errorf("Expected empty west/b cell, got %v", v)
+	}
+
+	result, _ = dataframe.Crosstab(region, product, dataframe.CrosstabOptions{Normalize: "index"})
+	a, _ = result.GetSeries("a")
+	if v, _ := a.Get(0); math.Abs(v.(float64)-1.0/3) > 1e-9 {
+		t.Errorf("Expected east/a share 1/3, got %v", v)
+	}
+
+	if _, err := dataframe.Crosstab(region, dataframe.NewSeries([]interface{}{"a"}, "p"), dataframe.CrosstabOptions{}); err == nil {
+		t.Error("Expected length mismatch error")
+	}
+}
@@ -1,8 +1,13 @@
 package tests
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/BAIGUANGMEI/datago/dataframe"
 )
@@ -49,6 +54,121 @@ func TestSeriesFillDropNA(t *testing.T) {
 	}
 }
 
+func TestNAStrings(t *testing.T) {
+	defer dataframe.SetNAStrings(dataframe.NAStrings())
+	defer dataframe.SetEmptyStringNA(true)
+
+	countries := []interface{}{"NA", "DE", "", "null", nil}
+	if got := dataframe.NewSeries(countries, "country").Count(); got != 1 {
+		t.Fatalf("Count() with default NA strings = %d, want 1", got)
+	}
+
+	// Namibia is a country, not a missing value
+	dataframe.SetNAStrings([]string{"null"})
+	s := dataframe.NewSeries(countries, "country")
+	if got := s.Count(); got != 2 {
+		t.Fatalf("Count() = %d, want 2", got)
+	}
+	if dataframe.IsNA("NA") || !dataframe.IsNA("null") {
+		t.Fatal("IsNA does not follow SetNAStrings")
+	}
+	if got := s.DropNA().Values(); !reflect.DeepEqual(got, []interface{}{"NA", "DE"}) {
+		t.Fatalf("DropNA() = %v, want [NA DE]", got)
+	}
+
+	dataframe.SetEmptyStringNA(false)
+	if got := s.FillNA("?").Values(); !reflect.DeepEqual(got, []interface{}{"NA", "DE", "", "?", "?"}) {
+		t.Fatalf("FillNA() = %v", got)
+	}
+
+	// A Series can override the package setting, and keeps it when derived
+	nums := dataframe.NewSeries([]interface{}{1.0, "-999", 3.0, ""}, "n").SetNAStrings([]string{"-999"}).SetEmptyStringNA(true)
+	if got := nums.Head(4).Count(); got != 2 {
+		t.Fatalf("Count() with Series NA strings = %d, want 2", got)
+	}
+	if !dataframe.IsNA("null") || dataframe.IsNA("-999") {
+		t.Fatal("Series NA strings changed the package setting")
+	}
+	df, _ := dataframe.FromRecords([][]interface{}{{"NA", 1}, {"null", 2}}, []string{"country", "v"})
+	dropped, _ := df.DropNA(dataframe.DropNAOptions{})
+	if dropped.Shape()[0] != 1 {
+		t.Fatalf("DataFrame.DropNA() rows = %d, want 1", dropped.Shape()[0])
+	}
+
+	// Queries, grouping and reshaping follow the NA strings of the column
+	df, _ = dataframe.FromRecords([][]interface{}{{"null", 1}, {"x", 2}, {"-", 3}, {"x", 4}}, []string{"code", "v"})
+	code, _ := df.GetSeries("code")
+	code.SetNAStrings([]string{"-"})
+	matched, err := df.Query("code == nil")
+	if err != nil {
+		t.Fatalf("Query error: %v", err)
+	}
+	if v, _ := matched.GetSeries("v"); !reflect.DeepEqual(v.Values(), []interface{}{3}) {
+		t.Errorf("Query(code == nil) = %v, want [3]", v.Values())
+	}
+	gb, _ := df.GroupBy("code")
+	if got := gb.Keys(); !reflect.DeepEqual(got, [][]interface{}{{"null"}, {"x"}}) {
+		t.Errorf("GroupBy keys = %v, want [[null] [x]]", got)
+	}
+	gb, _ = df.GroupByWith(dataframe.GroupByOptions{Sort: true}, "code")
+	if got := gb.Keys(); !reflect.DeepEqual(got, [][]interface{}{{"null"}, {"x"}, {"-"}}) {
+		t.Errorf("Sorted GroupBy keys = %v, want NA key last", got)
+	}
+	table, err := dataframe.Crosstab(code, code, dataframe.CrosstabOptions{})
+	if err != nil {
+		t.Fatalf("Crosstab error: %v", err)
+	}
+	if got := table.Columns(); !reflect.DeepEqual(got, []string{"code", "null", "x"}) {
+		t.Errorf("Crosstab columns = %v, want [code null x]", got)
+	}
+}
+
+func TestZeroTimeNA(t *testing.T) {
+	defer dataframe.SetZeroTimeNA(true)
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := dataframe.NewSeries([]interface{}{day, time.Time{}, nil}, "when")
+	if !dataframe.IsNA(time.Time{}) || dataframe.IsNA(day) {
+		t.Error("Expected only the zero time to be NA")
+	}
+	if got := s.Count(); got != 1 {
+		t.Errorf("Count() = %d, want 1", got)
+	}
+	if got := s.DropNA().Len(); got != 1 {
+		t.Errorf("DropNA().Len() = %d, want 1", got)
+	}
+	if v, _ := s.FillNA(day).Get(1); v != day {
+		t.Errorf("Expected zero time filled, got %v", v)
+	}
+	converted, err := s.TzConvert(time.FixedZone("UTC+8", 8*3600))
+	if err != nil {
+		t.Fatalf("TzConvert error: %v", err)
+	}
+	if v, _ := converted.Get(1); !v.(time.Time).IsZero() {
+		t.Errorf("Expected zero time unchanged, got %v", v)
+	}
+
+	df, _ := dataframe.FromRecords([][]interface{}{
+		{"a", day},
+		{"a", time.Time{}},
+		{"b", time.Time{}},
+	}, []string{"key", "when"})
+	gb, _ := df.GroupBy("key")
+	counts := gb.Count("when")
+	if got, _ := counts.GetSeries("when_count"); !reflect.DeepEqual(got.Values(), []interface{}{1, 0}) {
+		t.Errorf("GroupBy Count = %v, want [1 0]", got.Values())
+	}
+
+	// The per-Series toggle keeps genuine zero times
+	if got := dataframe.NewSeries([]interface{}{day, time.Time{}}, "when").SetZeroTimeNA(false).Count(); got != 2 {
+		t.Errorf("Count() with SetZeroTimeNA(false) = %d, want 2", got)
+	}
+	dataframe.SetZeroTimeNA(false)
+	if dataframe.IsNA(time.Time{}) || s.Count() != 2 {
+		t.Error("Expected the zero time not to be NA after SetZeroTimeNA(false)")
+	}
+}
+
 func TestSeriesArithmetic(t *testing.T) {
 	s := dataframe.NewSeries([]interface{}{1, 2, 3}, "nums")
 	add := s.Add(1)
@@ -60,3 +180,509 @@ func TestSeriesArithmetic(t *testing.T) {
 		t.Fatalf("Mul(2) third = %v, want 6", v)
 	}
 }
+
+func TestSeriesCheckedAggregations(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2, nil, 3}, "nums")
+	sum, err := s.SumE()
+	if err != nil {
+		t.Fatalf("SumE() error: %v", err)
+	}
+	if sum != 6 {
+		t.Fatalf("SumE() = %v, want 6", sum)
+	}
+
+	mixed := dataframe.NewSeries([]interface{}{1, "abc", 3}, "mixed")
+	if got := mixed.Sum(); got != 4 {
+		t.Fatalf("Sum() = %v, want 4", got)
+	}
+	if _, err := mixed.SumE(); err == nil {
+		t.Fatalf("SumE() expected error for non-numeric value")
+	}
+	if _, err := mixed.MeanE(); err == nil {
+		t.Fatalf("MeanE() expected error for non-numeric value")
+	}
+}
+
+func TestSeriesIsNumeric(t *testing.T) {
+	if !dataframe.NewSeries([]interface{}{1, nil, 2.5}, "a").IsNumeric() {
+		t.Fatalf("IsNumeric() = false, want true")
+	}
+	if dataframe.NewSeries([]interface{}{"x", "y"}, "b").IsNumeric() {
+		t.Fatalf("IsNumeric() = true, want false")
+	}
+}
+
+func TestSeriesStdVarDDOF(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2, 3, 4, 5}, "nums")
+	if got := s.Var(0); math.Abs(got-2.0) > 1e-9 {
+		t.Fatalf("Var(0) = %v, want 2", got)
+	}
+	if got := s.Std(0); math.Abs(got-math.Sqrt(2.0)) > 1e-9 {
+		t.Fatalf("Std(0) = %v, want sqrt(2)", got)
+	}
+	if got := s.Var(5); !math.IsNaN(got) {
+		t.Fatalf("Var(5) = %v, want NaN", got)
+	}
+
+	single := dataframe.NewSeries([]interface{}{3}, "one")
+	if got := single.Var(); !math.IsNaN(got) {
+		t.Fatalf("Var() of single value = %v, want NaN", got)
+	}
+	if got := single.Var(0); got != 0 {
+		t.Fatalf("Var(0) of single value = %v, want 0", got)
+	}
+}
+
+func TestSeriesTypedExtraction(t *testing.T) {
+	floats := []float64{1.5, math.NaN(), -3}
+	s := dataframe.NewSeriesFromFloat64s(floats, "f")
+	got, err := s.Float64s()
+	if err != nil {
+		t.Fatalf("Float64s() error: %v", err)
+	}
+	round := dataframe.NewSeriesFromFloat64s(got, "f")
+	for i := range floats {
+		a, _ := round.Get(i)
+		if af := a.(float64); af != floats[i] && !(math.IsNaN(af) && math.IsNaN(floats[i])) {
+			t.Fatalf("round trip at %d = %v, want %v", i, af, floats[i])
+		}
+	}
+
+	ints, err := dataframe.NewSeries([]interface{}{1, int64(2), 3.0}, "i").Int64s()
+	if err != nil || len(ints) != 3 || ints[2] != 3 {
+		t.Fatalf("Int64s() = %v, %v", ints, err)
+	}
+
+	withNil := dataframe.NewSeries([]interface{}{1, nil}, "n")
+	if _, err := withNil.Int64s(); err == nil {
+		t.Fatalf("Int64s() expected error for nil value")
+	}
+	lenient, err := withNil.Float64sLenient()
+	if err != nil || !math.IsNaN(lenient[1]) {
+		t.Fatalf("Float64sLenient() = %v, %v", lenient, err)
+	}
+
+	strs, err := dataframe.NewSeries([]interface{}{"a", "", 3}, "s").Strings()
+	if err != nil || strs[1] != "" || strs[2] != "3" {
+		t.Fatalf("Strings() = %v, %v", strs, err)
+	}
+}
+
+func TestSeriesApplyVariants(t *testing.T) {
+	s := dataframe.NewSeriesWithIndex([]interface{}{1, 2, 3}, "nums",
+		dataframe.NewIndex([]interface{}{"a", "b", "c"}, ""))
+
+	labeled := s.ApplyWithIndex(func(label, v interface{}) interface{} {
+		return fmt.Sprintf("%v=%v", label, v)
+	})
+	if v, _ := labeled.Get(1); v != "b=2" {
+		t.Fatalf("ApplyWithIndex() at 1 = %v, want b=2", v)
+	}
+	if labeled.DType() != dataframe.DTypeString {
+		t.Fatalf("ApplyWithIndex() dtype = %v, want string", labeled.DType())
+	}
+
+	_, err := s.TryApply(func(v interface{}) (interface{}, error) {
+		if v == 2 {
+			return nil, errors.New("boom")
+		}
+		return v, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "position 1") {
+		t.Fatalf("TryApply() error = %v, want failure at position 1", err)
+	}
+}
+
+func TestSeriesHeadDoesNotAlias(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2, 3, 4}, "nums")
+
+	head := s.Head(2)
+	_ = head.Set(0, 100)
+	if v, _ := s.Get(0); v != 1 {
+		t.Fatalf("parent changed after Head().Set: got %v, want 1", v)
+	}
+
+	_ = s.Set(3, 400)
+	tail := s.Tail(1)
+	_ = s.Set(3, 4)
+	if v, _ := tail.Get(0); v != 400 {
+		t.Fatalf("Tail() changed after parent Set: got %v, want 400", v)
+	}
+
+	view := s.SliceView(1, 3)
+	_ = view.Set(0, 200)
+	if v, _ := s.Get(1); v != 200 {
+		t.Fatalf("SliceView() should share data: got %v, want 200", v)
+	}
+}
+
+func TestSeriesItems(t *testing.T) {
+	s := dataframe.NewSeriesWithIndex([]interface{}{10, 20, 30}, "nums",
+		dataframe.NewIndex([]interface{}{"a", "b", "c"}, ""))
+
+	var labels []interface{}
+	sum := 0
+	for label, v := range s.Items() {
+		labels = append(labels, label)
+		sum += v.(int)
+	}
+	if len(labels) != 3 || labels[2] != "c" || sum != 60 {
+		t.Fatalf("Items() labels = %v, sum = %d", labels, sum)
+	}
+
+	visited := 0
+	s.ForEach(func(i int, label, v interface{}) bool {
+		visited++
+		return i < 1
+	})
+	if visited != 2 {
+		t.Fatalf("ForEach() visited %d elements, want 2", visited)
+	}
+}
+
+func TestSeriesFactorize(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{"b", "a", nil, "b", int64(1), "1"}, "vals")
+	codes, uniques := s.Factorize()
+	want := []int64{0, 1, -1, 0, 2, 3}
+	for i, w := range want {
+		if v, _ := codes.Get(i); v != w {
+			t.Fatalf("Factorize() code at %d = %v, want %d", i, v, w)
+		}
+	}
+	if uniques.Len() != 4 {
+		t.Fatalf("Factorize() uniques len = %d, want 4", uniques.Len())
+	}
+
+	sorted, sortedUniques := dataframe.NewSeries([]interface{}{"b", "a", "c", "a"}, "v").
+		Factorize(dataframe.FactorizeOptions{Sort: true})
+	if v, _ := sortedUniques.Get(0); v != "a" {
+		t.Fatalf("sorted uniques first = %v, want a", v)
+	}
+	if v, _ := sorted.Get(0); v != int64(1) {
+		t.Fatalf("sorted code for b = %v, want 1", v)
+	}
+}
+
+func TestSeriesUniqueTypeAware(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{int64(1), "1", 1, math.NaN(), math.NaN()}, "v")
+	if got := s.NUnique(); got != 3 {
+		t.Fatalf("NUnique() = %d, want 3", got)
+	}
+}
+
+func TestSeriesValueCounts(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{"b", int64(1), "a", "1", "a", 1, nil, "b", "a"}, "v")
+	counts := s.ValueCounts()
+	// Largest count first, ties in order of first appearance
+	wantLabels := []interface{}{"a", "b", int64(1), "1", nil}
+	wantCounts := []interface{}{3, 2, 2, 1, 1}
+	if got := counts.Index().Labels(); !reflect.DeepEqual(got, wantLabels) {
+		t.Fatalf("labels = %v, want %v", got, wantLabels)
+	}
+	if got := counts.Values(); !reflect.DeepEqual(got, wantCounts) {
+		t.Fatalf("counts = %v, want %v", got, wantCounts)
+	}
+	if counts.Name() != "count" || counts.Index().Name() != "v" {
+		t.Fatalf("names = %q, %q; want count, v", counts.Name(), counts.Index().Name())
+	}
+}
+
+func TestSeriesSearchSorted(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2, 2, 5}, "v")
+	if pos, err := s.SearchSorted(2, "left"); err != nil || pos != 1 {
+		t.Fatalf("SearchSorted(2, left) = %d, %v; want 1", pos, err)
+	}
+	if pos, err := s.SearchSorted(2.5, "right"); err != nil || pos != 3 {
+		t.Fatalf("SearchSorted(2.5, right) = %d, %v; want 3", pos, err)
+	}
+	if _, err := s.SearchSorted("x", "left"); !errors.Is(err, dataframe.ErrIncomparableValue) {
+		t.Fatalf("SearchSorted(\"x\") error = %v, want ErrIncomparableValue", err)
+	}
+
+	batch, err := s.SearchSortedMany([]interface{}{0, 2, 9}, "right")
+	if err != nil {
+		t.Fatalf("SearchSortedMany() error: %v", err)
+	}
+	if v, _ := batch.Get(1); v != int64(3) {
+		t.Fatalf("SearchSortedMany() at 1 = %v, want 3", v)
+	}
+
+	unsorted := dataframe.NewSeries([]interface{}{3, 1}, "u")
+	if _, err := unsorted.SearchSorted(2, "left"); !errors.Is(err, dataframe.ErrUnsortedSeries) {
+		t.Fatalf("SearchSorted() on unsorted error = %v, want ErrUnsortedSeries", err)
+	}
+}
+
+func TestSeriesExtendedArithmetic(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{2, -7, 9}, "nums")
+	if v, _ := s.Pow(2).Get(0); v != float64(4) {
+		t.Fatalf("Pow(2) first = %v, want 4", v)
+	}
+	if v, _ := s.Mod(3).Get(1); v != float64(-1) {
+		t.Fatalf("Mod(3) second = %v, want -1", v)
+	}
+	if v, _ := s.FloorDiv(2).Get(1); v != float64(-4) {
+		t.Fatalf("FloorDiv(2) second = %v, want -4", v)
+	}
+	if v, _ := s.RSub(100).Get(2); v != float64(91) {
+		t.Fatalf("RSub(100) third = %v, want 91", v)
+	}
+	if v, _ := s.RDiv(18).Get(2); v != float64(2) {
+		t.Fatalf("RDiv(18) third = %v, want 2", v)
+	}
+
+	short := dataframe.NewSeries([]interface{}{1}, "short")
+	if _, err := s.AddE(short); err == nil {
+		t.Fatalf("AddE() expected length mismatch error")
+	}
+	if _, err := s.MulE("abc"); err == nil {
+		t.Fatalf("MulE() expected error for non-numeric operand")
+	}
+	if _, err := s.AddE(s); err != nil {
+		t.Fatalf("AddE() error: %v", err)
+	}
+}
+
+func TestSeriesElementwiseMath(t *testing.T) {
+	ints := dataframe.NewSeries([]interface{}{int64(-3), nil, int64(4)}, "i")
+	abs := ints.Abs()
+	if v, _ := abs.Get(0); v != int64(3) {
+		t.Fatalf("Abs() first = %v, want int64 3", v)
+	}
+	if v, _ := abs.Get(1); v != nil {
+		t.Fatalf("Abs() nil = %v, want nil", v)
+	}
+	if abs.DType() != dataframe.DTypeInt64 {
+		t.Fatalf("Abs() dtype = %v, want int64", abs.DType())
+	}
+
+	floats := dataframe.NewSeriesFromFloat64s([]float64{1.256, -2.5, 3.7}, "f")
+	if v, _ := floats.Round(2).Get(0); v != 1.26 {
+		t.Fatalf("Round(2) first = %v, want 1.26", v)
+	}
+	if v, _ := floats.Floor().Get(1); v != float64(-3) {
+		t.Fatalf("Floor() second = %v, want -3", v)
+	}
+	if v, _ := floats.Ceil().Get(2); v != float64(4) {
+		t.Fatalf("Ceil() third = %v, want 4", v)
+	}
+}
+
+func TestSeriesWhereMask(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{-1, 2, -3, 4}, "v")
+	cond := s.Apply(func(v interface{}) interface{} { return v.(int) >= 0 })
+
+	clipped, err := s.Where(cond, 0)
+	if err != nil {
+		t.Fatalf("Where() error: %v", err)
+	}
+	if v, _ := clipped.Get(0); v != 0 {
+		t.Fatalf("Where() first = %v, want 0", v)
+	}
+	if v, _ := clipped.Get(1); v != 2 {
+		t.Fatalf("Where() second = %v, want 2", v)
+	}
+
+	negated, err := s.Mask(cond, func(v interface{}) interface{} { return -v.(int) })
+	if err != nil {
+		t.Fatalf("Mask() error: %v", err)
+	}
+	if v, _ := negated.Get(3); v != -4 {
+		t.Fatalf("Mask() fourth = %v, want -4", v)
+	}
+
+	withNA := dataframe.NewSeries([]interface{}{true, nil, true, true}, "c")
+	masked, _ := s.Mask(withNA, 99)
+	if v, _ := masked.Get(1); v != 99 {
+		t.Fatalf("Mask() with NA cond = %v, want 99", v)
+	}
+
+	if _, err := s.Where(dataframe.NewSeriesFromBools([]bool{true}, "c"), 0); err == nil {
+		t.Fatalf("Where() expected length mismatch error")
+	}
+}
+
+func TestSeriesDescribe(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{1, 2, 3, 4, nil}, "nums")
+	summary := s.Describe()
+	if !summary.Numeric || summary.Count != 4 {
+		t.Fatalf("Describe() = %+v", summary)
+	}
+	if summary.Q1 != 1.75 || summary.Median != 2.5 || summary.Q3 != 3.25 {
+		t.Fatalf("Describe() quartiles = %v %v %v, want 1.75 2.5 3.25", summary.Q1, summary.Median, summary.Q3)
+	}
+	if summary.Min != 1 || summary.Max != 4 {
+		t.Fatalf("Describe() min/max = %v/%v", summary.Min, summary.Max)
+	}
+
+	str := dataframe.NewSeries([]interface{}{"a", "b", "b", nil}, "letters").Describe()
+	if str.Numeric || str.NUnique != 2 || str.Top != "b" || str.Freq != 2 {
+		t.Fatalf("Describe() on strings = %+v", str)
+	}
+	if !strings.Contains(str.String(), "top") {
+		t.Fatalf("String() missing top: %s", str.String())
+	}
+}
+
+func TestSeriesExplode(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{
+		[]interface{}{"a", "b"}, []interface{}{}, nil, "c", []string{"d"},
+	}, "tags")
+	exploded := s.Explode()
+	if exploded.Len() != 6 {
+		t.Fatalf("Explode() len = %d, want 6", exploded.Len())
+	}
+	if v, _ := exploded.Get(1); v != "b" {
+		t.Fatalf("Explode() second = %v, want b", v)
+	}
+	if v, _ := exploded.Get(2); v != nil {
+		t.Fatalf("Explode() empty slice = %v, want nil", v)
+	}
+	if label, _ := exploded.Index().Get(1); label != 0 {
+		t.Fatalf("Explode() label = %v, want 0", label)
+	}
+}
+
+func TestSeriesRepeatAndRange(t *testing.T) {
+	rep := dataframe.NewSeriesRepeat(1.5, 3, "const")
+	if rep.Len() != 3 || rep.DType() != dataframe.DTypeFloat64 {
+		t.Fatalf("NewSeriesRepeat() len = %d, dtype = %v", rep.Len(), rep.DType())
+	}
+
+	r, err := dataframe.NewSeriesRange(0, 10, 3, "r")
+	if err != nil || r.Len() != 4 {
+		t.Fatalf("NewSeriesRange(0, 10, 3) len = %d, err = %v; want 4", r.Len(), err)
+	}
+	down, _ := dataframe.NewSeriesRange(5, 0, -2, "d")
+	if v, _ := down.Get(2); v != int64(1) || down.Len() != 3 {
+		t.Fatalf("NewSeriesRange(5, 0, -2) = %v", down.Values())
+	}
+	if _, err := dataframe.NewSeriesRange(0, 5, 0, "z"); err == nil {
+		t.Fatalf("NewSeriesRange() expected error for zero step")
+	}
+
+	s := dataframe.NewSeries([]interface{}{"a", "b"}, "s")
+	twice, _ := s.Repeat(2)
+	if twice.Len() != 4 {
+		t.Fatalf("Repeat(2) len = %d, want 4", twice.Len())
+	}
+	per, err := s.Repeat(dataframe.NewSeriesFromInts([]int{0, 3}, "n"))
+	if err != nil || per.Len() != 3 {
+		t.Fatalf("Repeat(series) len = %d, err = %v; want 3", per.Len(), err)
+	}
+	if label, _ := per.Index().Get(0); label != 1 {
+		t.Fatalf("Repeat(series) label = %v, want 1", label)
+	}
+}
+
+func TestSeriesStringFormat(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{int64(5), int64(120), int64(-3)}, "n")
+	expected := "Series: n (dtype: int64, length: 3)\n" +
+		"0    5\n" +
+		"1  120\n" +
+		"2   -3\n"
+	if got := s.String(); got != expected {
+		t.Errorf("Unexpected String output:\n%s", got)
+	}
+
+	long := make([]interface{}, 12)
+	for i := range long {
+		long[i] = "x"
+	}
+	got := dataframe.NewSeries(long, "x").StringWith(dataframe.DisplayOptions{MaxRows: 4})
+	expected = "Series: x (dtype: string, length: 12)\n" +
+		"0   x\n" +
+		"1   x\n" +
+		"…   …\n" +
+		"10  x\n" +
+		"11  x\n"
+	if got != expected {
+		t.Errorf("Unexpected elided output:\n%s", got)
+	}
+}
+
+func TestSeriesRank(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{3, 1, 3, nil, 2}, "v")
+	cases := []struct {
+		opts dataframe.RankOptions
+		want []interface{}
+	}{
+		{dataframe.RankOptions{}, []interface{}{3.5, 1.0, 3.5, nil, 2.0}},
+		{dataframe.RankOptions{Method: "min"}, []interface{}{3.0, 1.0, 3.0, nil, 2.0}},
+		{dataframe.RankOptions{Method: "max"}, []interface{}{4.0, 1.0, 4.0, nil, 2.0}},
+		{dataframe.RankOptions{Method: "first"}, []interface{}{3.0, 1.0, 4.0, nil, 2.0}},
+		{dataframe.RankOptions{Method: "dense"}, []interface{}{3.0, 1.0, 3.0, nil, 2.0}},
+		{dataframe.RankOptions{Method: "dense", Descending: true}, []interface{}{1.0, 3.0, 1.0, nil, 2.0}},
+		{dataframe.RankOptions{Method: "min", Pct: true}, []interface{}{0.75, 0.25, 0.75, nil, 0.5}},
+	}
+	for _, c := range cases {
+		ranked, err := s.Rank(c.opts)
+		if err != nil {
+			t.Fatalf("Rank(%+v) error: %v", c.opts, err)
+		}
+		if !reflect.DeepEqual(ranked.Values(), c.want) {
+			t.Errorf("Rank(%+v) = %v, want %v", c.opts, ranked.Values(), c.want)
+		}
+	}
+	if _, err := s.Rank(dataframe.RankOptions{Method: "bogus"}); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+}
+
+func TestSeriesShiftDiff(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{int64(1), int64(4), nil, int64(10)}, "v")
+
+	if got := s.Shift(1).Values(); !reflect.DeepEqual(got, []interface{}{nil, int64(1), int64(4), nil}) {
+		t.Errorf("Shift(1) = %v", got)
+	}
+	if got := s.Shift(-2).Values(); !reflect.DeepEqual(got, []interface{}{nil, int64(10), nil, nil}) {
+		t.Errorf("Shift(-2) = %v", got)
+	}
+	diff := s.Diff(1)
+	if got := diff.Values(); !reflect.DeepEqual(got, []interface{}{nil, int64(3), nil, nil}) {
+		t.Errorf("Diff(1) = %v", got)
+	}
+	if diff.DType() != dataframe.DTypeInt64 {
+		t.Errorf("Expected int64 diff, got %v", diff.DType())
+	}
+	floats := dataframe.NewSeries([]interface{}{1.5, 1.0, 4.0}, "f")
+	if got := floats.Diff(-1).Values(); !reflect.DeepEqual(got, []interface{}{0.5, -3.0, nil}) {
+		t.Errorf("Diff(-1) = %v", got)
+	}
+}
+
+func TestSeriesClip(t *testing.T) {
+	s := dataframe.NewSeries([]interface{}{int64(-5), 2.5, nil, math.NaN(), int64(9), "x"}, "v")
+	clipped, err := s.Clip(-1.5, 7.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []interface{}{int64(-1), 2.5, nil, nil, int64(7), "x"}
+	for i, w := range want {
+		got, _ := clipped.Get(i)
+		if i == 3 {
+			if f, ok := got.(float64); !ok || !math.IsNaN(f) {
+				t.Errorf("element 3: expected NaN, got %v", got)
+			}
+			continue
+		}
+		if got != w {
+			t.Errorf("element %d: expected %v (%T), got %v (%T)", i, w, w, got, got)
+		}
+	}
+
+	upperOnly, err := dataframe.NewSeriesFromFloat64s([]float64{-10, 10}, "v").Clip(nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(upperOnly.Values(), []interface{}{-10.0, 0.0}) {
+		t.Errorf("unexpected values: %v", upperOnly.Values())
+	}
+
+	if _, err := s.Clip(5, 1); err == nil {
+		t.Error("expected error for lower bound above upper bound")
+	}
+	if _, err := s.Clip("low", nil); !errors.Is(err, dataframe.ErrTypeConversion) {
+		t.Errorf("expected type conversion error, got %v", err)
+	}
+}
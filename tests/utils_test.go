@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BAIGUANGMEI/datago/utils"
+)
+
+func TestFromInterfaces(t *testing.T) {
+	values := utils.ToInterfaces([]int{1, 2, 3})
+	ints, err := utils.FromInterfaces[int](values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(ints, []int{1, 2, 3}) {
+		t.Fatalf("unexpected result: %v", ints)
+	}
+
+	_, err = utils.FromInterfaces[string]([]interface{}{"a", 2, "c"})
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Fatalf("expected position in error, got %v", err)
+	}
+	_, err = utils.FromInterfaces[float64]([]interface{}{1.5, nil})
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Fatalf("expected error for nil, got %v", err)
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	got := utils.MapSlice([]int{1, 2, 3}, strconv.Itoa)
+	if !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Fatalf("unexpected result: %v", got)
+	}
+	if got := utils.MapSlice(nil, strconv.Itoa); len(got) != 0 {
+		t.Fatalf("expected empty result, got %v", got)
+	}
+}
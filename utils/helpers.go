@@ -1,5 +1,7 @@
 package utils
 
+import "fmt"
+
 // ToInterfaces converts a slice of any type to []interface{}.
 func ToInterfaces[T any](values []T) []interface{} {
 	result := make([]interface{}, len(values))
@@ -8,3 +10,27 @@ func ToInterfaces[T any](values []T) []interface{} {
 	}
 	return result
 }
+
+// FromInterfaces converts []interface{} back to a slice of T. It is the
+// inverse of ToInterfaces: every element must hold a T, and the first one
+// that does not (including nil) returns an error naming its position.
+func FromInterfaces[T any](values []interface{}) ([]T, error) {
+	result := make([]T, len(values))
+	for i, v := range values {
+		t, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("element %d: cannot convert %v (%T) to %T", i, v, v, t)
+		}
+		result[i] = t
+	}
+	return result, nil
+}
+
+// MapSlice returns a new slice holding fn applied to each element of in.
+func MapSlice[T, U any](in []T, fn func(T) U) []U {
+	result := make([]U, len(in))
+	for i, v := range in {
+		result[i] = fn(v)
+	}
+	return result
+}